@@ -28,21 +28,31 @@
 // We detect the new model heuristically: if we got a 429 before and `Retry-After` <= 60s, we assume token bucket mode.
 //
 // NOTE: This logic is partly guesswork since docs don't give distinct header keys for the new model.
+//
+// Tokens: APIToken is a fixed bearer token. For long-running processes, NewAzureAdapterWithTokenSource
+// takes an oauth2.TokenSource instead (e.g. utils.AzureSPN.TokenSource); wrap it in a
+// BackgroundTokenSource to renew tokens off the request path, and call Close when done.
 
 package adapters
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	resilientbridge "github.com/opengovern/resilient-bridge"
+	"golang.org/x/oauth2"
 )
 
 type AzureAdapter struct {
-	APIToken          string
+	APIToken    string
+	TokenSource oauth2.TokenSource // Used when APIToken is empty
+
 	lastScope         string // "subscription" or "tenant"
 	lastOperationType string // "read", "write", "delete"
 
@@ -56,15 +66,30 @@ func NewAzureAdapter(apiToken string) *AzureAdapter {
 	}
 }
 
+// NewAzureAdapterWithTokenSource creates an AzureAdapter signing requests with tokens from tokenSource.
+func NewAzureAdapterWithTokenSource(tokenSource oauth2.TokenSource) *AzureAdapter {
+	return &AzureAdapter{
+		TokenSource: reuseTokenSource(tokenSource),
+	}
+}
+
+// Close stops the token source's background refresher, if it has one (see BackgroundTokenSource).
+func (a *AzureAdapter) Close() error {
+	if closer, ok := a.TokenSource.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 func (a *AzureAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
 	// No manual overrides; rely on headers and heuristics.
 }
 
 func (a *AzureAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
-	method := strings.ToUpper(req.Method)
-	switch method {
-	case "GET", "HEAD", "OPTIONS":
+	if resilientbridge.IsReadMethod(req.Method) {
 		return "read"
+	}
+	switch resilientbridge.NormalizeMethod(req.Method) {
 	case "POST", "PUT", "PATCH":
 		return "write"
 	case "DELETE":
@@ -74,7 +99,18 @@ func (a *AzureAdapter) IdentifyRequestType(req *resilientbridge.NormalizedReques
 	}
 }
 
+// Capabilities reports the optional features the Azure adapter supports.
+func (a *AzureAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true}
+}
+
 func (a *AzureAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return a.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (a *AzureAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	// Determine scope
 	scope := "tenant"
 	if strings.Contains(strings.ToLower(req.Endpoint), "/subscriptions/") {
@@ -87,16 +123,22 @@ func (a *AzureAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*
 	a.lastOperationType = opType
 
 	client := &http.Client{}
-	baseURL := "https://management.azure.com"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://management.azure.com")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
 
-	if a.APIToken != "" && httpReq.Header.Get("Authorization") == "" {
+	trusted := resilientbridge.TrustedHost(httpReq.URL.String(), "management.azure.com", req.TrustedOverride())
+	if a.APIToken != "" && httpReq.Header.Get("Authorization") == "" && trusted {
 		httpReq.Header.Set("Authorization", "Bearer "+a.APIToken)
+	} else if a.TokenSource != nil && httpReq.Header.Get("Authorization") == "" && trusted {
+		token, err := a.TokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("azure: error obtaining access token: %w", err)
+		}
+		token.SetAuthHeader(httpReq)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
@@ -105,34 +147,21 @@ func (a *AzureAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*
 		httpReq.Header.Set(k, v)
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
 
 	// If we got a 429, check Retry-After. If <= 60, assume token bucket mode for future calls
 	if resp.StatusCode == 429 {
-		if val, ok := headers["retry-after"]; ok {
-			if sec, err := strconv.Atoi(val); err == nil && sec <= 60 {
+		if val, ok := resp.Headers["retry-after"]; ok {
+			if wait, ok := resilientbridge.ParseRetryAfter(val, resilientbridge.ClockFromContext(ctx).Now()); ok && wait <= 60*time.Second {
 				a.useTokenBucket = true
 			}
 		}
 	}
 
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 func (a *AzureAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
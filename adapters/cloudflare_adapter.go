@@ -23,7 +23,7 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
 	"strings"
 	"sync"
@@ -71,11 +71,26 @@ func (c *CloudflareAdapter) IdentifyRequestType(req *resilientbridge.NormalizedR
 	return "rest"
 }
 
+// Capabilities reports the optional features the Cloudflare adapter supports.
+func (c *CloudflareAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{
+		SupportsAbsoluteURLs: true,
+		SupportsGraphQL:      true,
+		SupportsHealthProbe:  true,
+	}
+}
+
 // ExecuteRequest sends the request to Cloudflare if not rate-limited.
 // If rate-limited, returns a synthetic 429 directly, without hitting the API.
 func (c *CloudflareAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return c.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (c *CloudflareAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	isGraphQL := c.isGraphQLRequest(req)
-	if c.isRateLimited(isGraphQL) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && c.isRateLimited(isGraphQL) {
 		// Return synthetic 429 if we're locally rate-limited.
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
@@ -85,10 +100,9 @@ func (c *CloudflareAdapter) ExecuteRequest(req *resilientbridge.NormalizedReques
 	}
 
 	client := &http.Client{}
-	baseURL := "https://api.cloudflare.com/client/v4"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://api.cloudflare.com/client/v4")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -98,35 +112,22 @@ func (c *CloudflareAdapter) ExecuteRequest(req *resilientbridge.NormalizedReques
 		httpReq.Header.Set(k, v)
 	}
 	// If APIToken is provided, use it in Authorization header if not already present.
-	if c.APIToken != "" && httpReq.Header.Get("Authorization") == "" {
+	if c.APIToken != "" && httpReq.Header.Get("Authorization") == "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.cloudflare.com", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+c.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Record request timestamp after successful completion
 	c.recordRequest(isGraphQL)
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 // ParseRateLimitInfo returns nil because Cloudflare general APIs do not consistently provide rate-limit headers.
@@ -140,6 +141,11 @@ func (c *CloudflareAdapter) IsRateLimitError(resp *resilientbridge.NormalizedRes
 	return resp.StatusCode == 429
 }
 
+// HealthProbe returns the token verification endpoint.
+func (c *CloudflareAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/user/tokens/verify"}
+}
+
 // isGraphQLRequest checks if the request endpoint includes "/graphql".
 func (c *CloudflareAdapter) isGraphQLRequest(req *resilientbridge.NormalizedRequest) bool {
 	return strings.Contains(req.Endpoint, "/graphql")
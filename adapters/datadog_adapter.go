@@ -0,0 +1,142 @@
+// datadog_adapter.go
+// ------------------
+// This adapter integrates with the Datadog API (https://api.<site>, e.g. api.datadoghq.com or api.datadoghq.eu).
+//
+// Key Points:
+// - Authentication uses the DD-API-KEY and DD-APPLICATION-KEY headers.
+// - Datadog limits each endpoint group separately (metrics queries, log searches, monitors, ...), so
+//   IdentifyRequestType returns the group derived from the path ("/api/v1/monitor/123" -> "monitor").
+//   The SDK therefore tracks the reported limits per group.
+// - Each response carries X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset (seconds until the
+//   window resets) and X-RateLimit-Period (the window length in seconds). Once Remaining reaches 0, the
+//   SDK waits for the reported reset before sending the next request to that group.
+// - There is no fixed local window; the limits come entirely from Datadog's headers.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const DatadogDefaultSite = "datadoghq.com"
+
+type DatadogAdapter struct {
+	APIKey string
+	AppKey string
+	Site   string // datadoghq.com, datadoghq.eu, us3.datadoghq.com, ...
+}
+
+// NewDatadogAdapter creates a DatadogAdapter. An empty site selects DatadogDefaultSite.
+func NewDatadogAdapter(apiKey, appKey, site string) *DatadogAdapter {
+	if site == "" {
+		site = DatadogDefaultSite
+	}
+	return &DatadogAdapter{APIKey: apiKey, AppKey: appKey, Site: site}
+}
+
+// SetRateLimitDefaultsForType is a no-op: Datadog reports its limits on every response.
+func (d *DatadogAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+// IdentifyRequestType returns the endpoint group: the first path segment after /api/vN.
+func (d *DatadogAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	path := req.Endpoint
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) >= 3 && parts[0] == "api" && parts[2] != "" {
+		return parts[2]
+	}
+	return "rest"
+}
+
+// Capabilities reports the optional features the Datadog adapter supports.
+func (d *DatadogAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (d *DatadogAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return d.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (d *DatadogAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	client := &http.Client{}
+	fullURL := req.URL("https://api." + d.Site)
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	trusted := resilientbridge.TrustedHost(httpReq.URL.String(), "api."+d.Site, req.TrustedOverride())
+	if httpReq.Header.Get("DD-API-KEY") == "" && d.APIKey != "" && trusted {
+		httpReq.Header.Set("DD-API-KEY", d.APIKey)
+	}
+	if httpReq.Header.Get("DD-APPLICATION-KEY") == "" && d.AppKey != "" && trusted {
+		httpReq.Header.Set("DD-APPLICATION-KEY", d.AppKey)
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	return resilientbridge.DoRoundTrip(client, httpReq)
+}
+
+// ParseRateLimitInfo reads the X-RateLimit-Limit/-Remaining/-Reset/-Period quartet.
+// Reset is relative; if it is missing but the group is exhausted, a full Period is assumed.
+func (d *DatadogAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	h := resp.Headers
+	parseInt := func(key string) *int {
+		if val, ok := h[key]; ok {
+			if i, err := strconv.Atoi(val); err == nil {
+				return resilientbridge.IntPtr(i)
+			}
+		}
+		return nil
+	}
+
+	limit := parseInt("x-ratelimit-limit")
+	remaining := parseInt("x-ratelimit-remaining")
+	reset := parseInt("x-ratelimit-reset")
+	period := parseInt("x-ratelimit-period")
+	if limit == nil && remaining == nil && reset == nil {
+		return nil, nil
+	}
+
+	secs := reset
+	if secs == nil && remaining != nil && *remaining <= 0 {
+		secs = period
+	}
+	var resetAt *int64
+	if secs != nil {
+		ms := time.Now().Add(time.Duration(*secs) * time.Second).UnixMilli()
+		resetAt = &ms
+	}
+
+	return &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       limit,
+		RemainingRequests: remaining,
+		ResetRequestsAt:   resetAt,
+	}, nil
+}
+
+func (d *DatadogAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns the API key validation endpoint.
+func (d *DatadogAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/api/v1/validate"}
+}
@@ -0,0 +1,374 @@
+// discord_adapter.go
+// ------------------
+// This adapter integrates with the Discord bot API (https://discord.com/api/v10).
+//
+// Key Points:
+// - Authentication uses "Authorization: Bot <token>". Discord expects a "DiscordBot (url, version)"
+//   User-Agent, which is sent unless the request or ProviderConfig.UserAgent sets one.
+// - Discord limits requests per route bucket. A response names its bucket in X-RateLimit-Bucket and reports
+//   X-RateLimit-Limit / X-RateLimit-Remaining / X-RateLimit-Reset-After (seconds, fractional). Buckets
+//   are shared by several routes and are only known from responses, so the adapter learns which bucket a
+//   route belongs to and tracks remaining requests per bucket and major parameter (channel, guild, or
+//   webhook): the same bucket of two channels is limited independently.
+// - Routes are the method and path with IDs replaced by placeholders, so e.g. fetching any message of any
+//   channel is one route. Until a route's bucket is known, the route is its own bucket.
+// - A request to a bucket with nothing remaining before its reset gets a synthetic 429 whose body carries
+//   "retry_after" (seconds), which RateLimitWait turns into the exact wait.
+// - On top of the buckets, Discord caps a bot at 50 requests per second globally. That cap is tracked as
+//   the "rest" request type (ProviderConfig.MaxRequestsOverride / WindowSecsOverride apply to it), and a
+//   429 flagged with X-RateLimit-Global (or X-RateLimit-Scope: global) pauses every request until it
+//   clears. Discord's Retry-After header is honored by the SDK as usual.
+// - IdentifyRequestType returns "bucket:<bucket>:<major parameter>", so the SDK's own preemptive waits on
+//   a depleted bucket don't hold back requests to other buckets.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	DiscordDefaultGlobalMaxRequests = 50
+	DiscordDefaultGlobalWindowSecs  = 1 // 50/second
+)
+
+const discordAPIBase = "https://discord.com/api/v10"
+
+var (
+	discordSnowflakePattern = regexp.MustCompile(`^\d{15,21}$`)
+	discordAPIPathPattern   = regexp.MustCompile(`^/api(/v\d+)?`)
+)
+
+type DiscordAdapter struct {
+	BotToken string
+
+	mu sync.Mutex
+
+	// Maps route -> bucket hash, learned from X-RateLimit-Bucket
+	routeBuckets map[string]string
+	// Maps bucket key ("<bucket hash or route>:<major parameter>") -> bucket state
+	buckets map[string]*discordBucket
+
+	// Global limit: timestamps (Unix ms) of recent requests, and the end of a global 429
+	globalMaxRequests int
+	globalWindowSecs  int64
+	globalHistory     []int64
+	globalUntil       time.Time
+}
+
+type discordBucket struct {
+	limit     int
+	remaining int
+	resetAt   time.Time
+}
+
+// NewDiscordAdapter creates a DiscordAdapter authenticating as the bot with botToken.
+func NewDiscordAdapter(botToken string) *DiscordAdapter {
+	return &DiscordAdapter{
+		BotToken:          botToken,
+		routeBuckets:      make(map[string]string),
+		buckets:           make(map[string]*discordBucket),
+		globalMaxRequests: DiscordDefaultGlobalMaxRequests,
+		globalWindowSecs:  DiscordDefaultGlobalWindowSecs,
+	}
+}
+
+// SetRateLimitDefaultsForType sets the global limit for the "rest" (or "global") request type. Per-route
+// buckets are learned from Discord's headers and cannot be configured.
+func (d *DiscordAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	if requestType != "rest" && requestType != "global" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if maxRequests == 0 {
+		maxRequests = DiscordDefaultGlobalMaxRequests
+	}
+	if windowSecs == 0 {
+		windowSecs = DiscordDefaultGlobalWindowSecs
+	}
+	d.globalMaxRequests = maxRequests
+	d.globalWindowSecs = windowSecs
+}
+
+// IdentifyRequestType returns "bucket:" followed by the request's bucket key.
+func (d *DiscordAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	route, major := discordRoute(req)
+	return "bucket:" + d.bucketKey(route, major)
+}
+
+// Capabilities reports the optional features the Discord adapter supports.
+func (d *DiscordAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (d *DiscordAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return d.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (d *DiscordAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	route, major := discordRoute(req)
+	if wait, global := d.reserve(route, major); wait > 0 && !resilientbridge.RateLimitingDisabled(ctx) {
+		body, _ := json.Marshal(map[string]any{
+			"message":     "Discord rate limit reached",
+			"retry_after": wait.Seconds(),
+			"global":      global,
+		})
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{},
+			Data:       body,
+		}, nil
+	}
+
+	client := &http.Client{}
+	fullURL := req.URL(discordAPIBase)
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && d.BotToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), discordAPIBase, req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bot "+d.BotToken)
+	}
+	if httpReq.Header.Get("User-Agent") == "" {
+		if config := resilientbridge.ProviderConfigFromContext(ctx); config == nil || config.UserAgent == "" {
+			httpReq.Header.Set("User-Agent", "DiscordBot (https://github.com/opengovern/resilient-bridge, "+resilientbridge.Version+")")
+		}
+	}
+	if len(req.Body) > 0 && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		return nil, err
+	}
+
+	d.learn(route, major, resp)
+
+	return resp, err
+}
+
+// ParseRateLimitInfo reads the bucket's X-RateLimit-* headers. Reset times are derived from
+// X-RateLimit-Reset-After, which doesn't depend on the local clock being in sync with Discord's. A global
+// 429 also sets GlobalResetAt.
+func (d *DiscordAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	h := resp.Headers
+	info := &resilientbridge.NormalizedRateLimitInfo{}
+	now := time.Now()
+
+	if val, ok := h["x-ratelimit-limit"]; ok {
+		if i, err := strconv.Atoi(val); err == nil {
+			info.MaxRequests = resilientbridge.IntPtr(i)
+		}
+	}
+	if val, ok := h["x-ratelimit-remaining"]; ok {
+		if i, err := strconv.Atoi(val); err == nil {
+			info.RemainingRequests = resilientbridge.IntPtr(i)
+		}
+	}
+	if after, ok := discordSeconds(h["x-ratelimit-reset-after"]); ok {
+		reset := now.Add(after).UnixMilli()
+		info.ResetRequestsAt = &reset
+	}
+	if resp.StatusCode == 429 && discordIsGlobal(resp) {
+		if wait := d.RateLimitWait(resp); wait > 0 {
+			reset := now.Add(wait).UnixMilli()
+			info.GlobalResetAt = &reset
+		}
+	}
+
+	if info.MaxRequests == nil && info.RemainingRequests == nil && info.ResetRequestsAt == nil && info.GlobalResetAt == nil {
+		return nil, nil
+	}
+	return info, nil
+}
+
+func (d *DiscordAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// RateLimitWait returns the "retry_after" (fractional seconds) of a 429 body, falling back to
+// X-RateLimit-Reset-After. It is used when a 429 has no Retry-After header, as with the adapter's own.
+func (d *DiscordAdapter) RateLimitWait(resp *resilientbridge.NormalizedResponse) time.Duration {
+	var body struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if err := json.Unmarshal(resp.Data, &body); err == nil && body.RetryAfter > 0 {
+		return time.Duration(body.RetryAfter * float64(time.Second))
+	}
+	if after, ok := discordSeconds(resp.Headers["x-ratelimit-reset-after"]); ok {
+		return after
+	}
+	return 0
+}
+
+// ClassifyError reports 401 as resilientbridge.ErrUnauthorized: an invalid bot token is not retried.
+func (d *DiscordAdapter) ClassifyError(resp *resilientbridge.NormalizedResponse) error {
+	if resp.StatusCode == 401 {
+		return resilientbridge.ErrUnauthorized
+	}
+	return nil
+}
+
+// HealthProbe returns GET /users/@me, the bot's own user.
+func (d *DiscordAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/users/@me"}
+}
+
+// bucketKey returns the key of the bucket state for route and major: the learned bucket hash, or the
+// route itself while the bucket is unknown, followed by the major parameter. Callers must not hold d.mu.
+func (d *DiscordAdapter) bucketKey(route, major string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.bucketKeyLocked(route, major)
+}
+
+func (d *DiscordAdapter) bucketKeyLocked(route, major string) string {
+	bucket, ok := d.routeBuckets[route]
+	if !ok {
+		bucket = route
+	}
+	return bucket + ":" + major
+}
+
+// reserve takes one request from the global window and from the request's bucket. If either is exhausted,
+// nothing is taken and it returns how long until the request may be sent, and whether the global limit
+// is the cause.
+func (d *DiscordAdapter) reserve(route, major string) (time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(d.globalUntil) {
+		return d.globalUntil.Sub(now), true
+	}
+
+	windowStart := now.UnixMilli() - d.globalWindowSecs*1000
+	var kept []int64
+	for _, ts := range d.globalHistory {
+		if ts > windowStart {
+			kept = append(kept, ts)
+		}
+	}
+	d.globalHistory = kept
+	if len(kept) >= d.globalMaxRequests {
+		return time.Duration(kept[0]-windowStart) * time.Millisecond, true
+	}
+
+	if b, ok := d.buckets[d.bucketKeyLocked(route, major)]; ok {
+		if !now.Before(b.resetAt) {
+			b.remaining = b.limit
+		}
+		if b.remaining <= 0 {
+			return b.resetAt.Sub(now), false
+		}
+		b.remaining--
+	}
+
+	d.globalHistory = append(d.globalHistory, now.UnixMilli())
+	return 0, false
+}
+
+// learn records the route's bucket and the bucket's state from a response, and starts a global pause on
+// a global 429.
+func (d *DiscordAdapter) learn(route, major string, resp *resilientbridge.NormalizedResponse) {
+	h := resp.Headers
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if resp.StatusCode == 429 && discordIsGlobal(resp) {
+		if wait := d.RateLimitWait(resp); wait > 0 {
+			d.globalUntil = now.Add(wait)
+		}
+		return
+	}
+
+	if bucket := h["x-ratelimit-bucket"]; bucket != "" {
+		d.routeBuckets[route] = bucket
+	}
+	limit, errLimit := strconv.Atoi(h["x-ratelimit-limit"])
+	remaining, errRemaining := strconv.Atoi(h["x-ratelimit-remaining"])
+	after, ok := discordSeconds(h["x-ratelimit-reset-after"])
+	if errLimit != nil || errRemaining != nil || !ok {
+		return
+	}
+	d.buckets[d.bucketKeyLocked(route, major)] = &discordBucket{
+		limit:     limit,
+		remaining: remaining,
+		resetAt:   now.Add(after),
+	}
+}
+
+// discordRoute returns the rate limit route of req ("GET /channels/:id/messages/:id") and its major parameter
+// ("channels/<id>", "guilds/<id>", "webhooks/<id>", or "" if none).
+func discordRoute(req *resilientbridge.NormalizedRequest) (string, string) {
+	path := req.Endpoint
+	if u, err := url.Parse(req.Endpoint); err == nil {
+		path = u.Path
+	}
+	path = discordAPIPathPattern.ReplaceAllString(path, "")
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var major string
+	for i := 0; i < len(segments); i++ {
+		var prev string
+		if i > 0 {
+			prev = segments[i-1]
+		}
+		switch {
+		case major == "" && (prev == "channels" || prev == "guilds" || prev == "webhooks") && discordSnowflakePattern.MatchString(segments[i]):
+			major = prev + "/" + segments[i]
+			segments[i] = ":id"
+		case i > 1 && (segments[i-2] == "webhooks" || segments[i-2] == "interactions"):
+			// Keep webhook and interaction tokens out of routes (and so out of request types and logs)
+			segments[i] = ":token"
+		case prev == "reactions":
+			segments[i] = ":emoji"
+		case discordSnowflakePattern.MatchString(segments[i]):
+			segments[i] = ":id"
+		}
+	}
+
+	return resilientbridge.NormalizeMethod(req.Method) + " /" + strings.Join(segments, "/"), major
+}
+
+// discordIsGlobal reports whether a 429 is for the global limit rather than a route bucket.
+func discordIsGlobal(resp *resilientbridge.NormalizedResponse) bool {
+	if strings.EqualFold(resp.Headers["x-ratelimit-global"], "true") || resp.Headers["x-ratelimit-scope"] == "global" {
+		return true
+	}
+	var body struct {
+		Global bool `json:"global"`
+	}
+	return json.Unmarshal(resp.Data, &body) == nil && body.Global
+}
+
+// discordSeconds parses a fractional seconds value such as "1.5".
+func discordSeconds(val string) (time.Duration, bool) {
+	secs, err := strconv.ParseFloat(val, 64)
+	if err != nil || secs < 0 || math.IsInf(secs, 0) || math.IsNaN(secs) {
+		return 0, false
+	}
+	return time.Duration(secs * float64(time.Second)), true
+}
@@ -0,0 +1,238 @@
+// fastly_adapter.go
+// -----------------
+// This adapter integrates with the Fastly API (https://api.fastly.com).
+//
+// Key Points:
+// - Authentication uses the "Fastly-Key: <token>" header.
+// - Fastly limits configuration changes (POST/PUT/PATCH/DELETE) to 1000 requests per hour per user and
+//   reports the budget on those responses via Fastly-RateLimit-Remaining / Fastly-RateLimit-Reset (Unix
+//   seconds). Reads (GET/HEAD) don't count against it. On 429, Fastly sends Retry-After, which the SDK honors.
+// - Purges are limited separately from configuration writes, and per service, so they are tracked as their
+//   own "purge" request type with one local window per service ID (purges by URL share a single window).
+// - Request types and their local windows: "read", "write" (config changes), and "purge".
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	FastlyDefaultReadMaxRequests = 10000
+	FastlyDefaultReadWindowSecs  = 3600 // Reads aren't limited by Fastly; a generous window keeps bursts in check
+
+	FastlyDefaultWriteMaxRequests = 1000
+	FastlyDefaultWriteWindowSecs  = 3600 // 1000/hour
+
+	FastlyDefaultPurgeMaxRequests = 100000
+	FastlyDefaultPurgeWindowSecs  = 3600 // per service
+)
+
+var (
+	// Matches /service/<id>/purge/<key>, /service/<id>/purge (surrogate key batches), and /service/<id>/purge_all
+	fastlyServicePurgePattern = regexp.MustCompile(`^/service/([^/?]+)/purge(_all)?(/|\?|$)`)
+	// Matches purges by URL: POST /purge/<url>
+	fastlyURLPurgePattern = regexp.MustCompile(`^/purge/`)
+)
+
+type FastlyAdapter struct {
+	APIToken string
+
+	mu sync.Mutex
+	// Maps window key ("read", "write", "purge", or "purge:<service id>") -> slice of timestamps
+	requestHistory map[string][]int64
+
+	// Maps request type -> (maxRequests, windowSecs)
+	limits map[string]struct {
+		maxReq     int
+		windowSecs int64
+	}
+}
+
+// NewFastlyAdapter creates a FastlyAdapter authenticating with an API token.
+func NewFastlyAdapter(token string) *FastlyAdapter {
+	return &FastlyAdapter{
+		APIToken:       token,
+		requestHistory: make(map[string][]int64),
+		limits: make(map[string]struct {
+			maxReq     int
+			windowSecs int64
+		}),
+	}
+}
+
+// SetRateLimitDefaultsForType sets the local window of "read", "write", or "purge" requests. The purge
+// window applies to each service separately.
+func (f *FastlyAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var defMax int
+	var defWindow int64
+	switch requestType {
+	case "read":
+		defMax, defWindow = FastlyDefaultReadMaxRequests, FastlyDefaultReadWindowSecs
+	case "write":
+		defMax, defWindow = FastlyDefaultWriteMaxRequests, FastlyDefaultWriteWindowSecs
+	case "purge":
+		defMax, defWindow = FastlyDefaultPurgeMaxRequests, FastlyDefaultPurgeWindowSecs
+	default:
+		return
+	}
+	if maxRequests == 0 {
+		maxRequests = defMax
+	}
+	if windowSecs == 0 {
+		windowSecs = defWindow
+	}
+	f.limits[requestType] = struct {
+		maxReq     int
+		windowSecs int64
+	}{maxRequests, windowSecs}
+}
+
+// IdentifyRequestType returns "purge" for purge endpoints, "read" for GET, HEAD, and OPTIONS requests, and
+// "write" for everything else.
+func (f *FastlyAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	method := resilientbridge.NormalizeMethod(req.Method)
+	switch {
+	case method == "PURGE" || fastlyServicePurgePattern.MatchString(req.Endpoint) || fastlyURLPurgePattern.MatchString(req.Endpoint):
+		return "purge"
+	case resilientbridge.IsReadMethod(method):
+		return "read"
+	}
+	return "write"
+}
+
+// Capabilities reports the optional features the Fastly adapter supports.
+func (f *FastlyAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (f *FastlyAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return f.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (f *FastlyAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	requestType := f.IdentifyRequestType(req)
+	windowKey := fastlyWindowKey(requestType, req.Endpoint)
+	if !resilientbridge.RateLimitingDisabled(ctx) && f.isRateLimited(requestType, windowKey) {
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{},
+			Data:       []byte(`{"msg":"Fastly rate limit reached"}`),
+		}, nil
+	}
+
+	client := &http.Client{}
+	fullURL := req.URL("https://api.fastly.com")
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Fastly-Key") == "" && f.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.fastly.com", req.TrustedOverride()) {
+		httpReq.Header.Set("Fastly-Key", f.APIToken)
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		return nil, err
+	}
+
+	f.recordRequest(windowKey)
+
+	return resp, err
+}
+
+// ParseRateLimitInfo reads Fastly's Fastly-RateLimit-* headers, which are only sent on configuration writes.
+// Fastly-RateLimit-Reset is in Unix seconds.
+func (f *FastlyAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	h := resp.Headers
+	info := &resilientbridge.NormalizedRateLimitInfo{}
+	if val, ok := h["fastly-ratelimit-remaining"]; ok {
+		if i, err := strconv.Atoi(val); err == nil {
+			info.RemainingRequests = resilientbridge.IntPtr(i)
+		}
+	}
+	if val, ok := h["fastly-ratelimit-reset"]; ok {
+		if ts, err := strconv.ParseInt(val, 10, 64); err == nil {
+			ms := ts * 1000
+			info.ResetRequestsAt = &ms
+		}
+	}
+	if info.RemainingRequests == nil && info.ResetRequestsAt == nil {
+		return nil, nil
+	}
+	info.MaxRequests = resilientbridge.IntPtr(FastlyDefaultWriteMaxRequests)
+	return info, nil
+}
+
+func (f *FastlyAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns GET /current_user, the user behind the token.
+func (f *FastlyAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/current_user"}
+}
+
+// fastlyWindowKey returns the local window a request counts against: purges of a service get one window
+// per service ID, all other requests share the window of their request type.
+func fastlyWindowKey(requestType, endpoint string) string {
+	if requestType == "purge" {
+		if m := fastlyServicePurgePattern.FindStringSubmatch(endpoint); m != nil {
+			return "purge:" + m[1]
+		}
+	}
+	return requestType
+}
+
+func (f *FastlyAdapter) isRateLimited(requestType, windowKey string) bool {
+	f.mu.Lock()
+	limit, ok := f.limits[requestType]
+	f.mu.Unlock()
+	if !ok {
+		f.SetRateLimitDefaultsForType(requestType, 0, 0)
+		f.mu.Lock()
+		limit = f.limits[requestType]
+		f.mu.Unlock()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now().Unix()
+	windowStart := now - limit.windowSecs
+	var newTimestamps []int64
+	for _, ts := range f.requestHistory[windowKey] {
+		if ts >= windowStart {
+			newTimestamps = append(newTimestamps, ts)
+		}
+	}
+	f.requestHistory[windowKey] = newTimestamps
+
+	return len(newTimestamps) >= limit.maxReq
+}
+
+func (f *FastlyAdapter) recordRequest(windowKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requestHistory[windowKey] = append(f.requestHistory[windowKey], time.Now().Unix())
+}
@@ -2,7 +2,7 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"log"
 	"net/http"
 	"regexp"
@@ -45,9 +45,20 @@ func (f *FlyIOAdapter) IdentifyRequestType(req *resilientbridge.NormalizedReques
 	return "rest"
 }
 
+// Capabilities reports the optional features the Fly.io adapter supports.
+func (f *FlyIOAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true}
+}
+
 func (f *FlyIOAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return f.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (f *FlyIOAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	action, machineID := f.classifyRequest(req)
-	if f.isRateLimited(action, machineID) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && f.isRateLimited(action, machineID) {
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
 			Headers:    map[string]string{},
@@ -56,10 +67,9 @@ func (f *FlyIOAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*
 	}
 
 	client := &http.Client{}
-	baseURL := "https://api.machines.dev/v1"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://api.machines.dev/v1")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -67,34 +77,21 @@ func (f *FlyIOAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && f.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && f.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.machines.dev", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+f.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	f.recordRequest(action, machineID)
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 func (f *FlyIOAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -108,9 +105,9 @@ func (f *FlyIOAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse
 
 func (f *FlyIOAdapter) classifyRequest(req *resilientbridge.NormalizedRequest) (action string, machineID string) {
 	path := req.Endpoint
-	method := strings.ToUpper(req.Method)
+	method := resilientbridge.NormalizeMethod(req.Method)
 
-	if method == "GET" {
+	if resilientbridge.IsReadMethod(method) {
 		if machineIDMatch := machineIDPattern.FindStringSubmatch(path); machineIDMatch != nil {
 			action = "get_machine"
 			machineID = machineIDMatch[1]
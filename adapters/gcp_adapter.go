@@ -0,0 +1,219 @@
+// gcp_adapter.go
+// --------------
+// This adapter integrates with Google Cloud REST APIs on *.googleapis.com, defaulting to Cloud Resource
+// Manager (https://cloudresourcemanager.googleapis.com).
+//
+// Key Points:
+// - Requests are signed with an OAuth2 bearer token from an oauth2.TokenSource, e.g. a service account's
+//   JWT exchange from golang.org/x/oauth2/google (google.CredentialsFromJSON(..., scope).TokenSource).
+//   Tokens are cached and refreshed shortly before they expire.
+// - Endpoints are paths on BaseURL, or full https URLs on any googleapis.com host (compute, storage,
+//   iam, ...), so one adapter covers every GCP API the token is scoped for. NormalizedRequest.BaseURLOverride
+//   must name a googleapis.com host as well: the token is never sent elsewhere.
+// - GCP sends no rate limit headers. Quota errors come as 429, or as 403 with a "rateLimitExceeded" /
+//   "userRateLimitExceeded" reason (or a RATE_LIMIT_EXCEEDED ErrorInfo) in the JSON error body; both are
+//   treated as rate limit errors, and Retry-After is honored when present. Daily quota exhaustion
+//   ("dailyLimitExceeded", "quotaExceeded") is not: it won't clear within a retry.
+// - List APIs paginate with "nextPageToken" / "pageToken"; GCPNextPage plugs that into sdk.Paginate.
+// - To renew tokens off the request path, pass a BackgroundTokenSource; Close stops its refresher.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"golang.org/x/oauth2"
+)
+
+const GCPDefaultBaseURL = "https://cloudresourcemanager.googleapis.com"
+
+// gcpRateLimitReasons are the error reasons GCP uses for per-minute quota errors.
+var gcpRateLimitReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+	"RATE_LIMIT_EXCEEDED":   true,
+}
+
+type GCPAdapter struct {
+	BaseURL     string // API used for relative endpoints; defaults to GCPDefaultBaseURL
+	TokenSource oauth2.TokenSource
+}
+
+// NewGCPAdapter creates a GCPAdapter signing requests with tokens from tokenSource.
+func NewGCPAdapter(tokenSource oauth2.TokenSource) *GCPAdapter {
+	return &GCPAdapter{
+		BaseURL:     GCPDefaultBaseURL,
+		TokenSource: reuseTokenSource(tokenSource),
+	}
+}
+
+// SetRateLimitDefaultsForType is a no-op: GCP quotas are per API and per project, and are enforced
+// server-side with 429 / 403 rateLimitExceeded responses.
+func (g *GCPAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+// IdentifyRequestType returns "rest" for all GCP requests.
+func (g *GCPAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the GCP adapter supports.
+func (g *GCPAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (g *GCPAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return g.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (g *GCPAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	fullURL := req.URL(strings.TrimRight(g.BaseURL, "/"))
+	if (req.BaseURLOverride != "" || strings.Contains(req.Endpoint, "://")) && !isGoogleAPIsURL(fullURL) {
+		return nil, fmt.Errorf("gcp: %s is not a googleapis.com URL", fullURL)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && g.TokenSource != nil {
+		token, err := g.TokenSource.Token()
+		if err != nil {
+			return nil, fmt.Errorf("gcp: error obtaining access token: %w", err)
+		}
+		token.SetAuthHeader(httpReq)
+	}
+	if len(req.Body) > 0 && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{}
+	return resilientbridge.DoRoundTrip(client, httpReq)
+}
+
+// Close stops the token source's background refresher, if it has one (see BackgroundTokenSource).
+func (g *GCPAdapter) Close() error {
+	if closer, ok := g.TokenSource.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ParseRateLimitInfo returns nil: GCP doesn't send rate limit headers.
+func (g *GCPAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+// IsRateLimitError reports 429s and 403s whose error body carries a rate limit reason.
+func (g *GCPAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	if resp.StatusCode == 429 {
+		return true
+	}
+	if resp.StatusCode != 403 {
+		return false
+	}
+	body, ok := parseGCPError(resp.Data)
+	if !ok {
+		return false
+	}
+	for _, e := range body.Error.Errors {
+		if gcpRateLimitReasons[e.Reason] {
+			return true
+		}
+	}
+	for _, d := range body.Error.Details {
+		if gcpRateLimitReasons[d.Reason] {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassifyError reports 401 as resilientbridge.ErrUnauthorized: an expired or revoked token is not retried.
+func (g *GCPAdapter) ClassifyError(resp *resilientbridge.NormalizedResponse) error {
+	if resp.StatusCode == 401 {
+		return resilientbridge.ErrUnauthorized
+	}
+	return nil
+}
+
+// ExtractErrorMessage returns the "error.message" of a GCP error body.
+func (g *GCPAdapter) ExtractErrorMessage(resp *resilientbridge.NormalizedResponse) string {
+	body, ok := parseGCPError(resp.Data)
+	if !ok {
+		return ""
+	}
+	return body.Error.Message
+}
+
+// HealthProbe returns a one-project listing of the v1 Resource Manager API, which needs no parent.
+func (g *GCPAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: GCPDefaultBaseURL + "/v1/projects?pageSize=1"}
+}
+
+// gcpErrorBody is the error format shared by Google APIs: the legacy "errors" array and the newer
+// google.rpc "details" both carry a reason.
+type gcpErrorBody struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Errors  []struct {
+			Reason string `json:"reason"`
+			Domain string `json:"domain"`
+		} `json:"errors"`
+		Details []struct {
+			Type   string `json:"@type"`
+			Reason string `json:"reason"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func parseGCPError(data []byte) (*gcpErrorBody, bool) {
+	var body gcpErrorBody
+	if err := json.Unmarshal(data, &body); err != nil || (body.Error.Code == 0 && body.Error.Message == "") {
+		return nil, false
+	}
+	return &body, true
+}
+
+// isGoogleAPIsURL reports whether endpoint is a full https URL on a googleapis.com host.
+func isGoogleAPIsURL(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := u.Hostname()
+	return host == "googleapis.com" || strings.HasSuffix(host, ".googleapis.com")
+}
+
+// gcpPage holds the pagination field of GCP list responses.
+type gcpPage struct {
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// GCPNextPage is a resilientbridge.NextPageFunc for GCP list APIs. It passes the response's
+// "nextPageToken" as the next request's "pageToken" and stops when the token is empty.
+func GCPNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	var page gcpPage
+	if err := json.Unmarshal(resp.Data, &page); err != nil {
+		return nil, err
+	}
+	if page.NextPageToken == "" {
+		return nil, nil
+	}
+	return resilientbridge.NextPageRequest(req, resilientbridge.WithQueryParam(req.Endpoint, "pageToken", page.NextPageToken)), nil
+}
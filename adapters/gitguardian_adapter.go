@@ -2,9 +2,8 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
@@ -37,9 +36,20 @@ func (g *GitGuardianAdapter) IdentifyRequestType(req *resilientbridge.Normalized
 	return "rest"
 }
 
+// Capabilities reports the optional features the GitGuardian adapter supports.
+func (g *GitGuardianAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
 func (g *GitGuardianAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return g.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (g *GitGuardianAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	limit := g.getRateLimit()
-	if limit > 0 && g.isRateLimited(limit, 60) {
+	if limit > 0 && !resilientbridge.RateLimitingDisabled(ctx) && g.isRateLimited(limit, 60) {
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
 			Headers:    map[string]string{},
@@ -48,10 +58,9 @@ func (g *GitGuardianAdapter) ExecuteRequest(req *resilientbridge.NormalizedReque
 	}
 
 	client := &http.Client{}
-	baseURL := "https://api.gitguardian.com"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://api.gitguardian.com")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -59,34 +68,21 @@ func (g *GitGuardianAdapter) ExecuteRequest(req *resilientbridge.NormalizedReque
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && g.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && g.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.gitguardian.com", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Token "+g.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	g.recordRequest()
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 func (g *GitGuardianAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -98,6 +94,11 @@ func (g *GitGuardianAdapter) IsRateLimitError(resp *resilientbridge.NormalizedRe
 	return resp.StatusCode == 429
 }
 
+// HealthProbe returns GET /v1/health, which validates the API key.
+func (g *GitGuardianAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/v1/health"}
+}
+
 func (g *GitGuardianAdapter) getRateLimit() int {
 	if g.APIKeyType == "service" {
 		// Service account key
@@ -8,11 +8,45 @@
 // - Rate limits:
 //   * REST: 5000 requests/hour by default
 //   * GraphQL: 5000 requests/hour by default
-// - We differentiate between "rest" and "graphql" requests.
+//   * Search: 30 requests/minute, and code search (/search/code) 10 requests/minute
+// - We differentiate between "rest", "graphql", "search", and "code_search" requests. Search responses
+//   report their own X-RateLimit-* headers, so tracking them separately keeps a burst of searches from
+//   stalling ordinary REST calls (and vice versa).
+// - GitHub names the pool a response counted against in X-RateLimit-Resource ("core", "search",
+//   "code_scanning_upload", ...). Each pool gets its own local window, with "core" being the "rest" type.
+//   Endpoints known to use a separate pool are classified up front; for others, a response naming a
+//   different pool moves the request into that pool's window and the endpoint is remembered, so later
+//   requests to it are typed (and paced) correctly.
 // - On the first request (if CHECK_REQUEST_RATE_LIMIT_AHEAD = true), we call GET /rate_limit once to
 //   proactively fetch current rate limits without counting against primary rate limit.
-// - If 429 or 403 is encountered, consider it a rate limit error.
+// - GET /rate_limit requests (github.GetRateLimit, HealthProbe) are not counted in the local windows,
+//   as GitHub doesn't count them against any quota.
+// - A 429 is a rate limit error. A 403 is one only when GitHub says so: X-RateLimit-Remaining is 0, a
+//   Retry-After header is present, or the message mentions a secondary rate limit or abuse detection.
+//   Any other 403 (e.g. "Resource not accessible by integration") fails at once with an *HTTPError.
+// - GraphQL reports its limits with a 200 whose "errors" array has type RATE_LIMITED (or
+//   MAX_NODE_LIMIT_EXCEEDED), so such bodies are rate limit errors too and are retried the same way.
+// - A secondary limit answered without Retry-After (and with quota remaining) waits SecondaryLimitBackoff,
+//   at least 60 seconds as GitHub documents, instead of the generic exponential backoff (RateLimitWait).
+// - 401 and 403 "Bad credentials" responses fail immediately with ErrUnauthorized, and a 403 carrying the
+//   X-GitHub-SSO header fails with a GitHubSSORequiredError; retrying cannot fix either.
 // - We'll parse rate limit headers from each response to keep track of the current state.
+// - The local rolling windows count real round trips only: a slot is reserved atomically before sending and
+//   released if no response comes back, and synthetic 429s are never counted. A logical sdk.Request that is
+//   retried three times therefore counts as three requests only if all three reached GitHub.
+// - RateLimitWindows reports each local window to sdk.RateLimitStatus, plus a "secondary" entry with the
+//   REST points spent in the last minute (GET/HEAD/OPTIONS = 1 point, other methods = 5) against GitHub's
+//   documented secondary limit of 900 points/minute. Points are reported only, not enforced.
+// - Export/Import carry the local windows, learned pools, and points across restarts (sdk.ExportRateLimitState).
+// - DeprecationNotice turns the Deprecation / Sunset / Warning headers into ProviderConfig.OnDeprecation calls.
+// - MaxPageSize knows GitHub's list endpoints, so sdk.Paginate with PaginateOptions.MaxPageSize adds
+//   per_page=100 (50 for notifications) to requests that set no page size.
+// - ExecuteStreamRequest supports sdk.RequestStream for large downloads (logs, artifact zips).
+// - Endpoints may also be full https URLs, such as the archive_download_url and logs_url values GitHub
+//   returns. URLs on api.github.com are handled like the equivalent path; the token is never sent to
+//   other hosts. Redirects (e.g. a download's 302 to signed storage) are followed by net/http; requests
+//   setting FollowLocationStripAuth (as the github package's downloads do) never send the token past
+//   the first host.
 //
 // Note: Secondary rate limits and request "points" are not explicitly tracked in this example,
 // but could be added if GitHub documents them more specifically. Here we rely on standard headers.
@@ -23,10 +57,13 @@ package adapters
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -41,72 +78,186 @@ const (
 	GitHubDefaultGraphQLMaxRequests = 5000
 	GitHubDefaultGraphQLWindowSecs  = 3600
 
+	GitHubDefaultSearchMaxRequests     = 30
+	GitHubDefaultSearchWindowSecs      = 60 // 30/min
+	GitHubDefaultCodeSearchMaxRequests = 10
+	GitHubDefaultCodeSearchWindowSecs  = 60 // 10/min
+
+	GitHubDefaultCodeScanningUploadMaxRequests  = 1000
+	GitHubDefaultCodeScanningUploadWindowSecs   = 3600
+	GitHubDefaultDependencySnapshotsMaxRequests = 100
+	GitHubDefaultDependencySnapshotsWindowSecs  = 60
+	GitHubDefaultAuditLogMaxRequests            = 1750
+	GitHubDefaultAuditLogWindowSecs             = 3600
+
+	// Secondary rate limit on REST points per minute (see RateLimitWindows)
+	GitHubSecondaryPointsPerMinute = 900
+
+	// Minimum wait after a secondary rate limit response without Retry-After (see RateLimitWait)
+	GitHubMinSecondaryLimitBackoff = 60 * time.Second
+
 	// Set this to true if you want to proactively check the rate limit before the first request
 	CHECK_REQUEST_RATE_LIMIT_AHEAD = false
 )
 
+const githubAPIBase = "https://api.github.com"
+
 type GitHubAdapter struct {
 	APIToken string
 
+	// SecondaryLimitBackoff is the wait after a secondary rate limit response without Retry-After.
+	// Values below GitHubMinSecondaryLimitBackoff (including 0) use the minimum, as GitHub requires.
+	SecondaryLimitBackoff time.Duration
+
 	mu sync.Mutex
 
-	// Configured max and windows
-	restMaxRequests    int
-	restWindowSecs     int64
-	graphqlMaxRequests int
-	graphqlWindowSecs  int64
+	// Maps request type -> configured max, window, and recent request timestamps
+	windows map[string]*githubWindow
+
+	// Maps "METHOD /path" -> request type, learned from X-RateLimit-Resource
+	learnedTypes map[string]string
 
-	restRequestTimes    []int64
-	graphqlRequestTimes []int64
+	// REST points spent per request in the last minute, for the "secondary" window report
+	points []githubPoints
 
 	// Indicates if we've performed the initial rate limit check
 	didInitialRateCheck bool
 }
 
+type githubWindow struct {
+	maxRequests int
+	windowSecs  int64
+	times       []int64
+}
+
+type githubPoints struct {
+	ts     int64
+	points int
+}
+
+// githubDefaultLimits are the (maxRequests, windowSecs) defaults per request type.
+var githubDefaultLimits = map[string]struct {
+	maxRequests int
+	windowSecs  int64
+}{
+	"rest":        {GitHubDefaultRestMaxRequests, GitHubDefaultRestWindowSecs},
+	"graphql":     {GitHubDefaultGraphQLMaxRequests, GitHubDefaultGraphQLWindowSecs},
+	"search":      {GitHubDefaultSearchMaxRequests, GitHubDefaultSearchWindowSecs},
+	"code_search": {GitHubDefaultCodeSearchMaxRequests, GitHubDefaultCodeSearchWindowSecs},
+
+	"code_scanning_upload": {GitHubDefaultCodeScanningUploadMaxRequests, GitHubDefaultCodeScanningUploadWindowSecs},
+	"dependency_snapshots": {GitHubDefaultDependencySnapshotsMaxRequests, GitHubDefaultDependencySnapshotsWindowSecs},
+	"audit_log":            {GitHubDefaultAuditLogMaxRequests, GitHubDefaultAuditLogWindowSecs},
+}
+
+// githubResourcePatterns classifies endpoints known to count against a pool other than "core".
+var githubResourcePatterns = []struct {
+	method       string // "" matches any method
+	pattern      *regexp.Regexp
+	resourceType string
+}{
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/code-scanning/sarifs/?$`), "code_scanning_upload"},
+	{"POST", regexp.MustCompile(`^/repos/[^/]+/[^/]+/dependency-graph/snapshots/?$`), "dependency_snapshots"},
+	{"", regexp.MustCompile(`^/(orgs|enterprises)/[^/]+/audit-log/?$`), "audit_log"},
+}
+
+// githubListEndpoints are GET endpoints known to return lists, with the largest per_page they accept.
+// Patterns are anchored so single-resource endpoints (e.g. a commit by SHA) never match.
+var githubListEndpoints = []struct {
+	pattern    *regexp.Regexp
+	maxPerPage int
+}{
+	{regexp.MustCompile(`^/repos/[^/]+/[^/]+/(commits|branches|tags|releases|contributors|forks|stargazers|subscribers|collaborators|labels|milestones|deployments|events|comments|hooks|issues|pulls|issues/comments|issues/events|pulls/comments)/?$`), 100},
+	{regexp.MustCompile(`^/repos/[^/]+/[^/]+/(issues/\d+/(comments|events|labels)|pulls/\d+/(commits|files|comments|reviews)|releases/\d+/assets)/?$`), 100},
+	{regexp.MustCompile(`^/repos/[^/]+/[^/]+/(actions/(runs|workflows|artifacts|secrets|variables|runners)|actions/runs/\d+/(jobs|artifacts)|actions/workflows/[^/]+/runs)/?$`), 100},
+	{regexp.MustCompile(`^/repos/[^/]+/[^/]+/(code-scanning|dependabot|secret-scanning)/alerts/?$`), 100},
+	{regexp.MustCompile(`^/orgs/[^/]+/(repos|members|teams|outside_collaborators|hooks|events|invitations|installations|audit-log)/?$`), 100},
+	{regexp.MustCompile(`^/orgs/[^/]+/teams/[^/]+/(members|repos)/?$`), 100},
+	{regexp.MustCompile(`^/users/[^/]+/(repos|orgs|followers|following|starred|subscriptions|events|gists)/?$`), 100},
+	{regexp.MustCompile(`^/user/(repos|orgs|followers|following|starred|subscriptions|issues|teams|installations|memberships/orgs)/?$`), 100},
+	{regexp.MustCompile(`^/installation/repositories/?$`), 100},
+	{regexp.MustCompile(`^/search/(repositories|issues|code|commits|users|topics|labels)/?$`), 100},
+	{regexp.MustCompile(`^(/repos/[^/]+/[^/]+)?/notifications/?$`), 50},
+}
+
 func NewGitHubAdapter(apiToken string) *GitHubAdapter {
-	return &GitHubAdapter{
-		APIToken:           apiToken,
-		restMaxRequests:    GitHubDefaultRestMaxRequests,
-		restWindowSecs:     GitHubDefaultRestWindowSecs,
-		graphqlMaxRequests: GitHubDefaultGraphQLMaxRequests,
-		graphqlWindowSecs:  GitHubDefaultGraphQLWindowSecs,
+	g := &GitHubAdapter{
+		APIToken: apiToken,
+		windows:  make(map[string]*githubWindow),
 	}
+	for requestType, def := range githubDefaultLimits {
+		g.windows[requestType] = &githubWindow{maxRequests: def.maxRequests, windowSecs: def.windowSecs}
+	}
+	return g
 }
 
 func (g *GitHubAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	if requestType == "rest" {
-		if maxRequests == 0 {
-			maxRequests = GitHubDefaultRestMaxRequests
-		}
-		if windowSecs == 0 {
-			windowSecs = GitHubDefaultRestWindowSecs
-		}
-		g.restMaxRequests = maxRequests
-		g.restWindowSecs = windowSecs
-	} else if requestType == "graphql" {
-		if maxRequests == 0 {
-			maxRequests = GitHubDefaultGraphQLMaxRequests
-		}
-		if windowSecs == 0 {
-			windowSecs = GitHubDefaultGraphQLWindowSecs
-		}
-		g.graphqlMaxRequests = maxRequests
-		g.graphqlWindowSecs = windowSecs
+	def, ok := githubDefaultLimits[requestType]
+	if !ok {
+		return
+	}
+	if maxRequests == 0 {
+		maxRequests = def.maxRequests
 	}
+	if windowSecs == 0 {
+		windowSecs = def.windowSecs
+	}
+	w := g.window(requestType)
+	w.maxRequests = maxRequests
+	w.windowSecs = windowSecs
 }
 
+// IdentifyRequestType returns "graphql" for /graphql, "code_search" for /search/code, "search" for the
+// other /search endpoints, the pool name for endpoints with a pool of their own (known or learned from
+// X-RateLimit-Resource), and "rest" for everything else.
 func (g *GitHubAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
-	if g.isGraphQLRequest(req) {
+	key := githubEndpointKey(req)
+	g.mu.Lock()
+	learned, ok := g.learnedTypes[key]
+	g.mu.Unlock()
+	if ok {
+		return learned
+	}
+	method, path, _ := strings.Cut(key, " ")
+	for _, p := range githubResourcePatterns {
+		if (p.method == "" || p.method == method) && p.pattern.MatchString(path) {
+			return p.resourceType
+		}
+	}
+
+	switch {
+	case g.isGraphQLRequest(req):
 		return "graphql"
+	case strings.HasPrefix(path, "/search/code"):
+		return "code_search"
+	case strings.HasPrefix(path, "/search/"):
+		return "search"
 	}
 	return "rest"
 }
 
+// Capabilities reports the optional features the GitHub adapter supports. Absolute URLs are accepted for https hosts; the token is only sent to github.com hosts and the caller's BaseURLOverride.
+func (g *GitHubAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{
+		SupportsAbsoluteURLs: true,
+		SupportsGraphQL:      true,
+		SupportsStreaming:    true,
+		SupportsHealthProbe:  true,
+		SupportsStateExport:  true,
+	}
+}
+
 func (g *GitHubAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
-	isGraphQL := g.isGraphQLRequest(req)
+	return g.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (g *GitHubAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	requestType := g.IdentifyRequestType(req)
 
 	// If CHECK_REQUEST_RATE_LIMIT_AHEAD is true and we haven't done the initial check, do it now.
 	if CHECK_REQUEST_RATE_LIMIT_AHEAD {
@@ -123,7 +274,18 @@ func (g *GitHubAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (
 		}
 	}
 
-	if g.isRateLimited(isGraphQL) {
+	// GET /rate_limit costs no quota, so it takes no slot in the local windows
+	if githubEndpointKey(req) == "GET /rate_limit" {
+		httpReq, err := g.newHTTPRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return resilientbridge.DoRoundTrip(&http.Client{}, httpReq)
+	}
+
+	// Synthetic 429s are not recorded: they never reach GitHub
+	ts, ok := g.reserveRequest(requestType)
+	if !ok && !resilientbridge.RateLimitingDisabled(ctx) {
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
 			Headers:    map[string]string{},
@@ -132,45 +294,77 @@ func (g *GitHubAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (
 	}
 
 	client := &http.Client{}
-	baseURL := "https://api.github.com"
-	fullURL := baseURL + req.Endpoint
-
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := g.newHTTPRequest(ctx, req)
 	if err != nil {
+		g.releaseRequest(requestType, ts)
 		return nil, err
 	}
 
-	for k, v := range req.Headers {
-		httpReq.Header.Set(k, v)
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		g.releaseRequest(requestType, ts)
+		return nil, err
 	}
-	if httpReq.Header.Get("Authorization") == "" && g.APIToken != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+g.APIToken)
+	requestType = g.reconcileResource(req, requestType, ts, resp.Headers)
+	g.recordPoints(requestType, req.Method)
+
+	return resp, err
+}
+
+// ExecuteStreamRequest behaves like ExecuteRequest but returns the response body unread, so large
+// downloads such as workflow run logs or artifact archives can be streamed by the caller.
+func (g *GitHubAdapter) ExecuteStreamRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.StreamResponse, error) {
+	requestType := g.IdentifyRequestType(req)
+	ts, ok := g.reserveRequest(requestType)
+	if !ok && !resilientbridge.RateLimitingDisabled(ctx) {
+		return &resilientbridge.StreamResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{},
+			Body:       io.NopCloser(strings.NewReader(`{"error":"GitHub rate limit reached"}`)),
+		}, nil
 	}
-	if httpReq.Header.Get("Content-Type") == "" {
-		httpReq.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	httpReq, err := g.newHTTPRequest(ctx, req)
+	if err != nil {
+		g.releaseRequest(requestType, ts)
+		return nil, err
 	}
 
-	resp, err := client.Do(httpReq)
+	resp, err := resilientbridge.DoStreamRoundTrip(client, httpReq)
 	if err != nil {
+		g.releaseRequest(requestType, ts)
 		return nil, err
 	}
-	defer resp.Body.Close()
+	requestType = g.reconcileResource(req, requestType, ts, resp.Headers)
+	g.recordPoints(requestType, req.Method)
 
-	g.recordRequest(isGraphQL)
+	return resp, nil
+}
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
+// newHTTPRequest builds the outgoing *http.Request for a NormalizedRequest, applying the
+// adapter's token and default content type when the request doesn't set them.
+func (g *GitHubAdapter) newHTTPRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*http.Request, error) {
+	fullURL := req.BaseURL(githubAPIBase) + req.Endpoint
+	if strings.HasPrefix(req.Endpoint, "https://") {
+		fullURL = req.Endpoint
 	}
 
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && g.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "github.com", req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+g.APIToken)
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	return httpReq, nil
 }
 
 func (g *GitHubAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -201,70 +395,350 @@ func (g *GitHubAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedRespo
 		ResetRequestsAt:   parseReset("x-ratelimit-reset"),
 	}
 
+	// The GraphQL pool is a budget of points (a query costs at least one), reported in the same headers
+	if h["x-ratelimit-resource"] == "graphql" {
+		info.MaxPoints = parseInt("x-ratelimit-limit")
+		info.RemainingPoints = parseInt("x-ratelimit-remaining")
+		info.ResetPointsAt = parseReset("x-ratelimit-reset")
+	}
+
 	return info, nil
 }
 
 func (g *GitHubAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
-	// 429 or 403 can indicate rate limits
-	return resp.StatusCode == 429 || resp.StatusCode == 403
+	// 429 or 403 can indicate rate limits, but a 403 may also be a credentials, SSO or permission problem
+	switch resp.StatusCode {
+	case 403:
+		return g.ClassifyError(resp) == nil && isRateLimit403(resp)
+	case 200:
+		return isGraphQLRateLimited(resp.Data)
+	}
+	return resp.StatusCode == 429
+}
+
+// isRateLimit403 reports whether a 403 is GitHub's primary or secondary rate limit rather than a refusal:
+// the quota is used up, the response asks to retry later, or its message names the secondary limit.
+func isRateLimit403(resp *resilientbridge.NormalizedResponse) bool {
+	if resp.Headers["x-ratelimit-remaining"] == "0" {
+		return true
+	}
+	if _, ok := resp.Headers["retry-after"]; ok {
+		return true
+	}
+	body := bytes.ToLower(resp.Data)
+	return bytes.Contains(body, []byte("secondary rate limit")) || bytes.Contains(body, []byte("abuse"))
+}
+
+// githubGraphQLRateLimitTypes are the GraphQL error types reporting a rate limit.
+var githubGraphQLRateLimitTypes = map[string]bool{
+	"RATE_LIMITED":            true,
+	"MAX_NODE_LIMIT_EXCEEDED": true,
+}
+
+// isGraphQLRateLimited reports whether data is a GraphQL response whose errors include a rate limit.
+func isGraphQLRateLimited(data []byte) bool {
+	// Cheap check first: most 200s are not GraphQL errors at all
+	if !bytes.Contains(data, []byte("RATE_LIMITED")) && !bytes.Contains(data, []byte("MAX_NODE_LIMIT_EXCEEDED")) {
+		return false
+	}
+	var body struct {
+		Errors []struct {
+			Type string `json:"type"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return false
+	}
+	for _, e := range body.Errors {
+		if githubGraphQLRateLimitTypes[e.Type] {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitWait implements GitHub's guidance for rate limit responses without Retry-After: if
+// X-RateLimit-Remaining is 0 the primary limit is exhausted and the SDK already waits for
+// X-RateLimit-Reset, so 0 is returned; otherwise the response is a secondary limit (including GraphQL's
+// RATE_LIMITED errors) and the wait is SecondaryLimitBackoff, at least one minute.
+func (g *GitHubAdapter) RateLimitWait(resp *resilientbridge.NormalizedResponse) time.Duration {
+	if !g.IsRateLimitError(resp) || resp.Headers["x-ratelimit-remaining"] == "0" {
+		return 0
+	}
+	if g.SecondaryLimitBackoff > GitHubMinSecondaryLimitBackoff {
+		return g.SecondaryLimitBackoff
+	}
+	return GitHubMinSecondaryLimitBackoff
+}
+
+// MaxPageSize returns "per_page" and the largest page size for GET requests to known list endpoints (100
+// for most, 50 for notifications), for sdk.Paginate with PaginateOptions.MaxPageSize.
+func (g *GitHubAdapter) MaxPageSize(req *resilientbridge.NormalizedRequest) (string, int) {
+	method, path, _ := strings.Cut(githubEndpointKey(req), " ")
+	if method != "GET" {
+		return "", 0
+	}
+	for _, e := range githubListEndpoints {
+		if e.pattern.MatchString(path) {
+			return "per_page", e.maxPerPage
+		}
+	}
+	return "", 0
+}
+
+// HealthProbe returns GET /rate_limit, which does not count against the primary rate limit.
+func (g *GitHubAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/rate_limit"}
+}
+
+// GitHubSSORequiredError is returned when an organization enforces SAML SSO and the token has not been
+// authorized for it. URL, when GitHub provides it, is where the token owner can grant that authorization.
+type GitHubSSORequiredError struct {
+	URL string
+}
+
+func (e *GitHubSSORequiredError) Error() string {
+	if e.URL != "" {
+		return "github: organization enforces SAML SSO; authorize the token at " + e.URL
+	}
+	return "github: organization enforces SAML SSO; authorize the token for this organization in your GitHub token settings"
+}
+
+// Unwrap lets callers treat SSO failures as ErrUnauthorized.
+func (e *GitHubSSORequiredError) Unwrap() error {
+	return resilientbridge.ErrUnauthorized
+}
+
+// ClassifyError reports credential failures that must not be retried:
+// - 401 (missing, revoked, or expired token) -> ErrUnauthorized
+// - 403 with an X-GitHub-SSO header -> *GitHubSSORequiredError
+// - 403 with a "Bad credentials" message -> ErrUnauthorized
+func (g *GitHubAdapter) ClassifyError(resp *resilientbridge.NormalizedResponse) error {
+	switch resp.StatusCode {
+	case 401:
+		return resilientbridge.ErrUnauthorized
+	case 403:
+		// Header format: "required; url=https://github.com/orgs/ORG/sso?authorization_request=..."
+		if sso, ok := resp.Headers["x-github-sso"]; ok {
+			ssoErr := &GitHubSSORequiredError{}
+			if idx := strings.Index(sso, "url="); idx >= 0 {
+				ssoErr.URL = strings.TrimSpace(sso[idx+len("url="):])
+			}
+			return ssoErr
+		}
+		if bytes.Contains(bytes.ToLower(resp.Data), []byte("bad credentials")) {
+			return resilientbridge.ErrUnauthorized
+		}
+	}
+	return nil
+}
+
+// ExtractErrorMessage formats GitHub's error body, e.g.
+// {"message":"Validation Failed","errors":[{"resource":"Issue","field":"title","code":"missing_field"}]}
+// becomes "Validation Failed (Issue.title: missing_field)".
+func (g *GitHubAdapter) ExtractErrorMessage(resp *resilientbridge.NormalizedResponse) string {
+	var body struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Resource string `json:"resource"`
+			Field    string `json:"field"`
+			Code     string `json:"code"`
+			Message  string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.Data, &body); err != nil || body.Message == "" {
+		return ""
+	}
+
+	var details []string
+	for _, e := range body.Errors {
+		switch {
+		case e.Message != "":
+			details = append(details, e.Message)
+		case e.Field != "":
+			details = append(details, strings.TrimPrefix(e.Resource+"."+e.Field, ".")+": "+e.Code)
+		case e.Code != "":
+			details = append(details, e.Code)
+		}
+	}
+	if len(details) == 0 {
+		return body.Message
+	}
+	return body.Message + " (" + strings.Join(details, "; ") + ")"
 }
 
 func (g *GitHubAdapter) isGraphQLRequest(req *resilientbridge.NormalizedRequest) bool {
 	return req.Endpoint == "/graphql"
 }
 
-func (g *GitHubAdapter) isRateLimited(isGraphQL bool) bool {
+// window returns the rolling window for the given request type, creating it with REST defaults if
+// the type is unknown. Callers must hold g.mu.
+func (g *GitHubAdapter) window(requestType string) *githubWindow {
+	if g.windows == nil {
+		g.windows = make(map[string]*githubWindow)
+	}
+	w, ok := g.windows[requestType]
+	if !ok {
+		def, known := githubDefaultLimits[requestType]
+		if !known {
+			def = githubDefaultLimits["rest"]
+		}
+		w = &githubWindow{maxRequests: def.maxRequests, windowSecs: def.windowSecs}
+		g.windows[requestType] = w
+	}
+	return w
+}
+
+// reserveRequest prunes the rolling window and, if it has room, records a request in it. The check and
+// the record happen under one lock so concurrent callers cannot overshoot the window. It returns the
+// recorded timestamp, or false if the window is full and nothing was recorded.
+func (g *GitHubAdapter) reserveRequest(requestType string) (int64, bool) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	var maxReq int
-	var windowSecs int64
-	var timestamps []int64
-
-	if isGraphQL {
-		maxReq = g.graphqlMaxRequests
-		windowSecs = g.graphqlWindowSecs
-		timestamps = g.graphqlRequestTimes
-	} else {
-		maxReq = g.restMaxRequests
-		windowSecs = g.restWindowSecs
-		timestamps = g.restRequestTimes
-	}
-
+	w := g.window(requestType)
 	now := time.Now().Unix()
-	windowStart := now - windowSecs
+	windowStart := now - w.windowSecs
 	var newTimestamps []int64
-	for _, ts := range timestamps {
+	for _, ts := range w.times {
 		if ts >= windowStart {
 			newTimestamps = append(newTimestamps, ts)
 		}
 	}
 
-	if isGraphQL {
-		g.graphqlRequestTimes = newTimestamps
-	} else {
-		g.restRequestTimes = newTimestamps
+	if len(newTimestamps) >= w.maxRequests {
+		w.times = newTimestamps
+		return 0, false
+	}
+	w.times = append(newTimestamps, now)
+	return now, true
+}
+
+// releaseRequest removes a timestamp recorded by reserveRequest, for attempts that never got a
+// response from GitHub (network errors, cancelled contexts).
+func (g *GitHubAdapter) releaseRequest(requestType string, ts int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	w := g.window(requestType)
+	for i := len(w.times) - 1; i >= 0; i-- {
+		if w.times[i] == ts {
+			w.times = append(w.times[:i], w.times[i+1:]...)
+			return
+		}
+	}
+}
+
+// githubEndpointKey returns "METHOD /path" for req, without the query string.
+func githubEndpointKey(req *resilientbridge.NormalizedRequest) string {
+	path, _, _ := strings.Cut(strings.TrimPrefix(req.Endpoint, githubAPIBase), "?")
+	return resilientbridge.NormalizeMethod(req.Method) + " " + path
+}
+
+// reconcileResource moves a request recorded in requestType's window into the window of the pool named
+// by the response's X-RateLimit-Resource header, if that differs, and remembers the endpoint's pool. It
+// returns the request type the request ended up counted as.
+func (g *GitHubAdapter) reconcileResource(req *resilientbridge.NormalizedRequest, requestType string, ts int64, headers map[string]string) string {
+	actual := headers["x-ratelimit-resource"]
+	if actual == "core" {
+		actual = "rest"
+	}
+	if actual == "" || actual == requestType {
+		return requestType
+	}
+
+	g.releaseRequest(requestType, ts)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, known := g.windows[actual]; !known {
+		if _, hasDefault := githubDefaultLimits[actual]; !hasDefault {
+			// Unknown pool: size its window from the reported limit, assuming GitHub's usual hourly reset
+			w := &githubWindow{maxRequests: GitHubDefaultRestMaxRequests, windowSecs: GitHubDefaultRestWindowSecs}
+			if limit, err := strconv.Atoi(headers["x-ratelimit-limit"]); err == nil && limit > 0 {
+				w.maxRequests = limit
+			}
+			g.windows[actual] = w
+		}
+	}
+	w := g.window(actual)
+	w.times = append(w.times, ts)
+
+	if g.learnedTypes == nil {
+		g.learnedTypes = make(map[string]string)
+	}
+	g.learnedTypes[githubEndpointKey(req)] = actual
+	return actual
+}
+
+// recordPoints adds the secondary-limit points of a REST request that reached GitHub.
+func (g *GitHubAdapter) recordPoints(requestType, method string) {
+	if requestType == "graphql" {
+		return
+	}
+	points := 5
+	if resilientbridge.IsReadMethod(method) {
+		points = 1
 	}
 
-	return len(newTimestamps) >= maxReq
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := time.Now().Unix()
+	g.points = append(g.prunePoints(now), githubPoints{ts: now, points: points})
 }
 
-func (g *GitHubAdapter) recordRequest(isGraphQL bool) {
+// prunePoints drops point entries older than a minute. Callers must hold g.mu.
+func (g *GitHubAdapter) prunePoints(now int64) []githubPoints {
+	kept := g.points[:0]
+	for _, p := range g.points {
+		if p.ts >= now-60 {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// RateLimitWindows reports the usage of each local window, plus the REST points spent in the last
+// minute as the "secondary" window.
+func (g *GitHubAdapter) RateLimitWindows() map[string]resilientbridge.WindowUsage {
 	g.mu.Lock()
 	defer g.mu.Unlock()
+
 	now := time.Now().Unix()
-	if isGraphQL {
-		g.graphqlRequestTimes = append(g.graphqlRequestTimes, now)
-	} else {
-		g.restRequestTimes = append(g.restRequestTimes, now)
+	result := make(map[string]resilientbridge.WindowUsage, len(g.windows)+1)
+	for requestType, w := range g.windows {
+		usage := resilientbridge.WindowUsage{Max: w.maxRequests, WindowSecs: w.windowSecs}
+		for _, ts := range w.times {
+			if ts < now-w.windowSecs {
+				continue
+			}
+			if usage.Used == 0 {
+				reset := (ts + w.windowSecs) * 1000
+				usage.ResetAt = &reset
+			}
+			usage.Used++
+		}
+		result[requestType] = usage
 	}
+
+	g.points = g.prunePoints(now)
+	secondary := resilientbridge.WindowUsage{Max: GitHubSecondaryPointsPerMinute, WindowSecs: 60}
+	for i, p := range g.points {
+		if i == 0 {
+			reset := (p.ts + 60) * 1000
+			secondary.ResetAt = &reset
+		}
+		secondary.Used += p.points
+	}
+	result["secondary"] = secondary
+	return result
 }
 
 // checkInitialRateLimit calls the /rate_limit endpoint once to proactively fetch the current
 // rate limit info. This call does not count against the primary rate limit, but can affect secondary limits.
 // If successful, we use the returned data to update our known limits if needed.
 func (g *GitHubAdapter) checkInitialRateLimit() error {
-	client := &http.Client{}
+	client := &http.Client{Transport: resilientbridge.DefaultTransport}
 	req, err := http.NewRequest("GET", "https://api.github.com/rate_limit", nil)
 	if err != nil {
 		return err
@@ -272,6 +746,7 @@ func (g *GitHubAdapter) checkInitialRateLimit() error {
 	if g.APIToken != "" {
 		req.Header.Set("Authorization", "Bearer "+g.APIToken)
 	}
+	req.Header.Set("User-Agent", resilientbridge.DefaultUserAgent)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -346,3 +821,85 @@ func (g *GitHubAdapter) checkInitialRateLimit() error {
 
 	return nil
 }
+
+// githubState is the Export encoding of the adapter's local state.
+type githubState struct {
+	Windows      map[string][]int64 `json:"windows"`
+	LearnedTypes map[string]string  `json:"learned_types,omitempty"`
+	Points       [][2]int64         `json:"points,omitempty"` // [unix seconds, points]
+}
+
+// Export returns the request timestamps of each local window, the learned endpoint pools, and the
+// recent secondary points, for sdk.ExportRateLimitState.
+func (g *GitHubAdapter) Export() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	state := githubState{Windows: make(map[string][]int64), LearnedTypes: g.learnedTypes}
+	for requestType, w := range g.windows {
+		if len(w.times) > 0 {
+			state.Windows[requestType] = w.times
+		}
+	}
+	for _, p := range g.points {
+		state.Points = append(state.Points, [2]int64{p.ts, int64(p.points)})
+	}
+	return json.Marshal(state)
+}
+
+// Import merges a snapshot produced by Export into the adapter's state. Window limits are kept as
+// configured; only the recorded requests are restored.
+func (g *GitHubAdapter) Import(data []byte) error {
+	var state githubState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for requestType, times := range state.Windows {
+		w := g.window(requestType)
+		w.times = append(w.times, times...)
+		sort.Slice(w.times, func(i, j int) bool { return w.times[i] < w.times[j] })
+	}
+	for key, requestType := range state.LearnedTypes {
+		if g.learnedTypes == nil {
+			g.learnedTypes = make(map[string]string)
+		}
+		g.learnedTypes[key] = requestType
+	}
+	for _, p := range state.Points {
+		g.points = append(g.points, githubPoints{ts: p[0], points: int(p[1])})
+	}
+	sort.Slice(g.points, func(i, j int) bool { return g.points[i].ts < g.points[j].ts })
+	return nil
+}
+
+// githubWarningRe extracts the quoted text of a Warning header such as `299 - "Deprecated API"`.
+var githubWarningRe = regexp.MustCompile(`^\d{3}\s+\S+\s+"([^"]*)"`)
+
+// DeprecationNotice reports the Deprecation, Sunset, and Warning headers GitHub sends for endpoints and
+// media types that are going away.
+func (g *GitHubAdapter) DeprecationNotice(resp *resilientbridge.NormalizedResponse) *resilientbridge.Deprecation {
+	h := resp.Headers
+	deprecation, sunset, warning := h["deprecation"], h["sunset"], h["warning"]
+	if (deprecation == "" || deprecation == "false") && sunset == "" && warning == "" {
+		return nil
+	}
+
+	notice := &resilientbridge.Deprecation{Message: "endpoint is deprecated"}
+	if m := githubWarningRe.FindStringSubmatch(warning); m != nil {
+		notice.Message = m[1]
+	} else if warning != "" {
+		notice.Message = warning
+	}
+	if sunset != "" {
+		if t, err := http.ParseTime(sunset); err == nil {
+			notice.Sunset = &t
+		}
+	}
+	if link, ok := resp.Link("deprecation"); ok {
+		notice.Link = link
+	}
+	return notice
+}
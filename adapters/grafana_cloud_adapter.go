@@ -0,0 +1,244 @@
+// grafana_cloud_adapter.go
+// ------------------------
+// This adapter integrates with a Grafana Cloud stack (https://<stack>.grafana.net), covering both the
+// Grafana HTTP API (/api/...) and the stack's Mimir / Prometheus endpoints.
+//
+// Key Points:
+// - Requests carry the token (a service account token or Cloud access policy token) as a Bearer token.
+// - When TenantID is set, every request carries it in X-Scope-OrgID, the tenant header Mimir, Loki, and
+//   Tempo use to pick the tenant; a value set on the request itself takes precedence.
+// - Grafana Cloud doesn't publish fixed API limits, so the adapter caps requests locally at
+//   GrafanaDefaultMaxRequestsPerSecond (adjustable with SetRateLimitDefaultsForType("rest", ...)) and
+//   returns a synthetic 429 once the cap is reached. Server 429s are rate limit errors, and the SDK
+//   honors their Retry-After.
+// - List endpoints paginate in different ways. GrafanaNextPage handles the page-number endpoints
+//   (/api/search with limit/page, and the */search endpoints returning page, perPage, and totalCount);
+//   cursor endpoints can use PaginateOptions.NextURLExtractor, e.g. with resilientbridge.JSONNextURL.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	GrafanaDefaultMaxRequestsPerSecond = 10
+	GrafanaDefaultWindowSecs           = 1
+)
+
+type GrafanaCloudAdapter struct {
+	Token    string
+	StackURL string // e.g. "https://mystack.grafana.net"
+	TenantID string // Sent as X-Scope-OrgID when set
+
+	mu                sync.Mutex
+	requestTimestamps []int64 // Unix milliseconds
+	maxRequests       int
+	windowSecs        int64
+}
+
+// NewGrafanaCloudAdapter creates a GrafanaCloudAdapter for the stack at stackURL. Set TenantID on the
+// returned adapter to send the X-Scope-OrgID tenant header.
+func NewGrafanaCloudAdapter(token, stackURL string) *GrafanaCloudAdapter {
+	return &GrafanaCloudAdapter{
+		Token:       token,
+		StackURL:    stackURL,
+		maxRequests: GrafanaDefaultMaxRequestsPerSecond,
+		windowSecs:  GrafanaDefaultWindowSecs,
+	}
+}
+
+// SetRateLimitDefaultsForType overrides the local request cap for "rest" requests; zero values keep
+// the defaults.
+func (g *GrafanaCloudAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	if requestType != "rest" {
+		return
+	}
+	if maxRequests == 0 {
+		maxRequests = GrafanaDefaultMaxRequestsPerSecond
+	}
+	if windowSecs == 0 {
+		windowSecs = GrafanaDefaultWindowSecs
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.maxRequests = maxRequests
+	g.windowSecs = windowSecs
+}
+
+// IdentifyRequestType returns "rest" for all Grafana Cloud requests.
+func (g *GrafanaCloudAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the Grafana Cloud adapter supports.
+func (g *GrafanaCloudAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (g *GrafanaCloudAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return g.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (g *GrafanaCloudAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && g.isRateLimited() {
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{},
+			Data:       []byte(`{"message":"Grafana Cloud request cap reached"}`),
+		}, nil
+	}
+
+	client := &http.Client{}
+	fullURL := req.URL(g.StackURL)
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	if g.TenantID != "" {
+		httpReq.Header.Set("X-Scope-OrgID", g.TenantID)
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if resilientbridge.TrustedHost(httpReq.URL.String(), g.StackURL, req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		return nil, err
+	}
+
+	g.recordRequest()
+	return resp, err
+}
+
+// ParseRateLimitInfo reports the remaining capacity of the local per-second window.
+func (g *GrafanaCloudAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now().UnixMilli()
+	g.pruneLocked(now)
+
+	remaining := g.maxRequests - len(g.requestTimestamps)
+	if remaining < 0 {
+		remaining = 0
+	}
+	info := &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       resilientbridge.IntPtr(g.maxRequests),
+		RemainingRequests: resilientbridge.IntPtr(remaining),
+	}
+	if remaining == 0 && len(g.requestTimestamps) > 0 {
+		resetAt := g.requestTimestamps[0] + g.windowSecs*1000
+		info.ResetRequestsAt = &resetAt
+	}
+	return info, nil
+}
+
+func (g *GrafanaCloudAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// ClassifyError reports 401 as resilientbridge.ErrUnauthorized: an invalid or revoked token is not retried.
+func (g *GrafanaCloudAdapter) ClassifyError(resp *resilientbridge.NormalizedResponse) error {
+	if resp.StatusCode == 401 {
+		return resilientbridge.ErrUnauthorized
+	}
+	return nil
+}
+
+// HealthProbe returns GET /api/user, the identity behind the token.
+func (g *GrafanaCloudAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/api/user"}
+}
+
+func (g *GrafanaCloudAdapter) isRateLimited() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pruneLocked(time.Now().UnixMilli())
+	return len(g.requestTimestamps) >= g.maxRequests
+}
+
+func (g *GrafanaCloudAdapter) recordRequest() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.requestTimestamps = append(g.requestTimestamps, time.Now().UnixMilli())
+}
+
+// pruneLocked drops the timestamps outside the window ending at now. g.mu must be held.
+func (g *GrafanaCloudAdapter) pruneLocked(now int64) {
+	windowStart := now - g.windowSecs*1000
+	i := 0
+	for i < len(g.requestTimestamps) && g.requestTimestamps[i] <= windowStart {
+		i++
+	}
+	g.requestTimestamps = g.requestTimestamps[i:]
+}
+
+type grafanaSearchPage struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"perPage"`
+	TotalCount int `json:"totalCount"`
+}
+
+// GrafanaNextPage is a resilientbridge.NextPageFunc for Grafana's page-number list endpoints. For responses
+// carrying page, perPage, and totalCount (/api/org/users/search, /api/teams/search, ...) it advances "page"
+// until totalCount is reached; for bare arrays (/api/search) it advances "page" while a full page of
+// "limit" (or "perpage") items came back.
+func GrafanaNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	u, err := url.Parse(req.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	current, _ := strconv.Atoi(q.Get("page"))
+	if current < 1 {
+		current = 1
+	}
+	next := resilientbridge.NextPageRequest(req, resilientbridge.WithQueryParam(req.Endpoint, "page", strconv.Itoa(current+1)))
+
+	data := bytes.TrimSpace(resp.Data)
+	if len(data) > 0 && data[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(q.Get("limit"))
+		if size <= 0 {
+			size, _ = strconv.Atoi(q.Get("perpage"))
+		}
+		if size <= 0 || len(items) < size {
+			return nil, nil
+		}
+		return next, nil
+	}
+
+	var page grafanaSearchPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+	if page.PerPage <= 0 || page.Page*page.PerPage >= page.TotalCount {
+		return nil, nil
+	}
+	return next, nil
+}
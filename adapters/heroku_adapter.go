@@ -17,9 +17,8 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
@@ -66,47 +65,47 @@ func (h *HerokuAdapter) IdentifyRequestType(req *resilientbridge.NormalizedReque
 	return "rest"
 }
 
+// Capabilities reports the optional features the Heroku adapter supports.
+func (h *HerokuAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
 // ExecuteRequest sends the HTTP request to the Heroku API.
 // It sets the Authorization header with the Heroku API token and content type if not specified.
 // After the response is received, it records the request timestamp for rate limiting calculations.
 func (h *HerokuAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return h.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (h *HerokuAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	client := &http.Client{}
-	fullURL := "https://api.heroku.com" + req.Endpoint
+	fullURL := req.URL("https://api.heroku.com")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	httpReq.Header.Set("Authorization", "Bearer "+h.APIToken)
+	if resilientbridge.TrustedHost(httpReq.URL.String(), "api.heroku.com", req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+h.APIToken)
+	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/vnd.heroku+json; version=3")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Record the request after it completes to update internal rate limit tracking.
 	h.recordRequest()
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 // ParseRateLimitInfo calculates the current rate limit state based on the timestamps of recent requests.
@@ -150,6 +149,11 @@ func (h *HerokuAdapter) IsRateLimitError(resp *resilientbridge.NormalizedRespons
 	return resp.StatusCode == 429
 }
 
+// HealthProbe returns GET /account.
+func (h *HerokuAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/account", Headers: map[string]string{"Accept": "application/vnd.heroku+json; version=3"}}
+}
+
 // recordRequest adds the current timestamp to the requestTimestamps slice.
 // This helps track how many requests have been made in the current window.
 func (h *HerokuAdapter) recordRequest() {
@@ -2,10 +2,9 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -76,18 +75,29 @@ func (h *HuggingFaceAdapter) SetRateLimitDefaultsForType(requestType string, max
 }
 
 // IdentifyRequestType categorizes requests. HuggingFace Hub requests are often GET for reading info.
-// For simplicity, GET requests -> "read", others -> "write". Adjust as needed.
+// For simplicity, GET, HEAD, and OPTIONS requests -> "read", others -> "write". Adjust as needed.
 func (h *HuggingFaceAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
-	if strings.ToUpper(req.Method) == "GET" {
+	if resilientbridge.IsReadMethod(req.Method) {
 		return "read"
 	}
 	return "write"
 }
 
+// Capabilities reports the optional features the Hugging Face adapter supports.
+func (h *HuggingFaceAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
 // ExecuteRequest performs the HTTP request.
 func (h *HuggingFaceAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return h.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (h *HuggingFaceAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	requestType := h.IdentifyRequestType(req)
-	if h.isRateLimited(requestType) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && h.isRateLimited(requestType) {
 		// Return a simulated 429 to trigger backoff/retries
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
@@ -97,10 +107,9 @@ func (h *HuggingFaceAdapter) ExecuteRequest(req *resilientbridge.NormalizedReque
 	}
 
 	client := &http.Client{}
-	baseURL := "https://huggingface.co"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://huggingface.co")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +118,7 @@ func (h *HuggingFaceAdapter) ExecuteRequest(req *resilientbridge.NormalizedReque
 		httpReq.Header.Set(k, v)
 	}
 
-	if httpReq.Header.Get("Authorization") == "" && h.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && h.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "huggingface.co", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+h.APIToken)
 	}
 
@@ -118,27 +127,14 @@ func (h *HuggingFaceAdapter) ExecuteRequest(req *resilientbridge.NormalizedReque
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	h.recordRequest(requestType)
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 // ParseRateLimitInfo attempts to parse rate limit info from response headers.
@@ -185,6 +181,11 @@ func (h *HuggingFaceAdapter) IsRateLimitError(resp *resilientbridge.NormalizedRe
 	return resp.StatusCode == 429
 }
 
+// HealthProbe returns GET /api/whoami-v2, the account behind the token.
+func (h *HuggingFaceAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/api/whoami-v2"}
+}
+
 func (h *HuggingFaceAdapter) isRateLimited(requestType string) bool {
 	h.mu.Lock()
 	defer h.mu.Unlock()
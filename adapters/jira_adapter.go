@@ -0,0 +1,206 @@
+// jira_adapter.go
+// ---------------
+// This adapter integrates with the Jira Cloud REST API of an Atlassian site (https://<site>.atlassian.net).
+//
+// Key Points:
+// - Authentication uses HTTP basic auth with the account email and an Atlassian API token.
+// - Endpoints are relative to /rest/api/3 (e.g. "/search?jql=..."); endpoints that already start with
+//   "/rest/" (the Agile API, /rest/agile/1.0/...) are sent as-is.
+// - "Accept: application/json" is sent by default, as Atlassian requires.
+// - Atlassian reports limits via X-RateLimit-Limit / X-RateLimit-Remaining / X-RateLimit-Reset (an ISO 8601
+//   timestamp) and sends Retry-After on 429, which the SDK honors.
+// - List endpoints paginate with startAt / maxResults / total; JiraNextPage plugs that into sdk.Paginate.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+type JiraAdapter struct {
+	BaseURL  string // e.g. https://your-site.atlassian.net
+	Email    string
+	APIToken string
+}
+
+// NewJiraAdapter creates a JiraAdapter for the Atlassian site at baseURL.
+func NewJiraAdapter(baseURL, email, apiToken string) *JiraAdapter {
+	return &JiraAdapter{
+		BaseURL:  strings.TrimRight(baseURL, "/"),
+		Email:    email,
+		APIToken: apiToken,
+	}
+}
+
+// SetRateLimitDefaultsForType is a no-op: Atlassian publishes its limits through response headers.
+func (j *JiraAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+// IdentifyRequestType returns "rest" for all Jira requests.
+func (j *JiraAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the Jira adapter supports. Endpoints are paths under /rest/, so absolute URLs are not accepted.
+func (j *JiraAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsHealthProbe: true}
+}
+
+func (j *JiraAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return j.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (j *JiraAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	client := &http.Client{}
+	endpoint := req.Endpoint
+	if !strings.HasPrefix(endpoint, "/rest/") {
+		endpoint = "/rest/api/3" + endpoint
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.BaseURL(j.BaseURL)+endpoint, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && j.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), j.BaseURL, req.TrustedOverride()) {
+		httpReq.SetBasicAuth(j.Email, j.APIToken)
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	return resilientbridge.DoRoundTrip(client, httpReq)
+}
+
+// ParseRateLimitInfo reads Atlassian's X-RateLimit-* headers. X-RateLimit-Reset is an ISO 8601
+// timestamp; a plain number is accepted as Unix seconds.
+func (j *JiraAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	h := resp.Headers
+	parseInt := func(key string) *int {
+		if val, ok := h[key]; ok {
+			if i, err := strconv.Atoi(val); err == nil {
+				return resilientbridge.IntPtr(i)
+			}
+		}
+		return nil
+	}
+
+	var resetAt *int64
+	if val := h["x-ratelimit-reset"]; val != "" {
+		if t, err := time.Parse(time.RFC3339, val); err == nil {
+			ms := t.UnixMilli()
+			resetAt = &ms
+		} else if t, err := time.Parse("2006-01-02T15:04Z07:00", val); err == nil {
+			ms := t.UnixMilli()
+			resetAt = &ms
+		} else if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+			ms := secs * 1000
+			resetAt = &ms
+		}
+	}
+
+	info := &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       parseInt("x-ratelimit-limit"),
+		RemainingRequests: parseInt("x-ratelimit-remaining"),
+		ResetRequestsAt:   resetAt,
+	}
+	if info.MaxRequests == nil && info.RemainingRequests == nil && info.ResetRequestsAt == nil {
+		return nil, nil
+	}
+	return info, nil
+}
+
+func (j *JiraAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns GET /myself, the user behind the credentials.
+func (j *JiraAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/myself"}
+}
+
+// ExtractErrorMessage joins Jira's "errorMessages" array and "errors" map.
+func (j *JiraAdapter) ExtractErrorMessage(resp *resilientbridge.NormalizedResponse) string {
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.Data, &body); err != nil {
+		return ""
+	}
+	msgs := append([]string{}, body.ErrorMessages...)
+	for field, msg := range body.Errors {
+		msgs = append(msgs, field+": "+msg)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// jiraPage holds the pagination fields of Jira list responses. The items live under an endpoint-specific
+// key ("issues", "values", "worklogs", ...), so they are kept raw and counted separately.
+type jiraPage struct {
+	StartAt    int   `json:"startAt"`
+	MaxResults int   `json:"maxResults"`
+	Total      *int  `json:"total"`
+	IsLast     *bool `json:"isLast"`
+}
+
+// JiraNextPage is a resilientbridge.NextPageFunc for Jira's startAt/maxResults pagination. It advances
+// startAt by the number of items on the page and stops once startAt + items >= total, when the page
+// reports isLast, or when a page comes back empty.
+func JiraNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	var page jiraPage
+	if err := json.Unmarshal(resp.Data, &page); err != nil {
+		return nil, err
+	}
+	if page.IsLast != nil && *page.IsLast {
+		return nil, nil
+	}
+
+	count, err := jiraItemCount(resp.Data)
+	if err != nil {
+		return nil, err
+	}
+	next := page.StartAt + count
+	if count == 0 || (page.Total != nil && next >= *page.Total) {
+		return nil, nil
+	}
+	return resilientbridge.NextPageRequest(req, resilientbridge.WithQueryParam(req.Endpoint, "startAt", strconv.Itoa(next))), nil
+}
+
+// jiraItemCount returns the length of the first array field of a Jira page.
+func jiraItemCount(data []byte) (int, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0, err
+	}
+	for _, key := range []string{"values", "issues", "worklogs", "comments"} {
+		if raw, ok := fields[key]; ok {
+			var items []json.RawMessage
+			if err := json.Unmarshal(raw, &items); err == nil {
+				return len(items), nil
+			}
+		}
+	}
+	for _, raw := range fields {
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err == nil {
+			return len(items), nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,346 @@
+// kubernetes_adapter.go
+// ---------------------
+// This adapter integrates with a Kubernetes cluster's API server (core /api/v1 and every /apis group).
+//
+// Key Points:
+// - The server is given as a KubernetesConfig: its URL, a bearer token (or a token file, re-read every
+//   minute so rotated service account tokens are picked up), and TLS settings: a PEM CA bundle for the
+//   server certificate and an optional client certificate. KubernetesInClusterConfig builds one from a
+//   pod's service account. The TLS settings make up the adapter's own HTTP client;
+//   ProviderConfig.HTTPClient replaces it (proxies, custom transports, stubs in tests).
+// - Like client-go, the adapter paces requests with a client-side token bucket so automation does not
+//   flood the API server. The defaults are kubectl's (KubernetesDefaultQPS, KubernetesDefaultBurst);
+//   when the bucket is empty a synthetic 429 is returned, and RateLimitWait tells the SDK exactly when
+//   the next token is due.
+// - The server's own throttling (API Priority and Fairness, max-in-flight limits) answers 429 with
+//   Retry-After, which the SDK honors. Kubernetes sends no rate limit headers.
+// - List requests paginate with "limit" and the "metadata.continue" token; KubernetesNextPage plugs that
+//   into sdk.Paginate, and MaxPageSize asks for kubectl's 500-item chunks. A continue token expires after
+//   a few minutes (410 Gone), after which the list must be restarted.
+// - Watches and pod logs can be streamed with sdk.RequestStream.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	// KubernetesDefaultQPS and KubernetesDefaultBurst are kubectl's client-side rate limits.
+	KubernetesDefaultQPS   = 50
+	KubernetesDefaultBurst = 300
+
+	// KubernetesListChunkSize is the page size MaxPageSize asks for, as kubectl's --chunk-size does.
+	KubernetesListChunkSize = 500
+
+	kubernetesServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	kubernetesTokenFileTTL      = time.Minute
+
+	// kubernetesThrottledHeader marks the adapter's own 429s, so RateLimitWait can answer from the bucket.
+	kubernetesThrottledHeader = "x-client-side-throttled"
+)
+
+// KubernetesConfig describes how to reach and authenticate to an API server.
+type KubernetesConfig struct {
+	Host        string // API server URL, e.g. "https://10.0.0.1:6443"
+	BearerToken string
+	TokenFile   string // Read for the bearer token when BearerToken is empty, re-read every minute
+
+	CAData   []byte // PEM bundle the server certificate must chain to; nil = system roots
+	CertData []byte // PEM client certificate, for certificate authentication
+	KeyData  []byte // PEM key of CertData
+	Insecure bool   // Skip server certificate verification; for test clusters only
+
+	QPS   float64 // Client-side requests per second; 0 = KubernetesDefaultQPS
+	Burst int     // Client-side burst; 0 = KubernetesDefaultBurst
+}
+
+// KubernetesInClusterConfig returns the configuration for the cluster a pod runs in: the API server from
+// KUBERNETES_SERVICE_HOST / KUBERNETES_SERVICE_PORT, and the service account's token and CA.
+func KubernetesInClusterConfig() (KubernetesConfig, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return KubernetesConfig{}, errors.New("kubernetes: not running in a cluster (KUBERNETES_SERVICE_HOST/PORT unset)")
+	}
+	ca, err := os.ReadFile(kubernetesServiceAccountDir + "/ca.crt")
+	if err != nil {
+		return KubernetesConfig{}, fmt.Errorf("kubernetes: error reading the service account CA: %w", err)
+	}
+	return KubernetesConfig{
+		Host:      "https://" + net.JoinHostPort(host, port),
+		TokenFile: kubernetesServiceAccountDir + "/token",
+		CAData:    ca,
+	}, nil
+}
+
+type KubernetesAdapter struct {
+	Config KubernetesConfig
+
+	client *http.Client
+
+	mu        sync.Mutex
+	bucket    *resilientbridge.TokenBucketLimiter
+	fileToken string
+	readAt    time.Time
+}
+
+// NewKubernetesAdapter creates a KubernetesAdapter for the API server described by config. It fails if
+// the TLS material cannot be parsed.
+func NewKubernetesAdapter(config KubernetesConfig) (*KubernetesAdapter, error) {
+	config.Host = strings.TrimRight(config.Host, "/")
+	if config.Host == "" {
+		return nil, errors.New("kubernetes: no API server host")
+	}
+	if config.QPS <= 0 {
+		config.QPS = KubernetesDefaultQPS
+	}
+	if config.Burst <= 0 {
+		config.Burst = KubernetesDefaultBurst
+	}
+	client, err := kubernetesClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &KubernetesAdapter{
+		Config: config,
+		client: client,
+		bucket: resilientbridge.NewTokenBucketLimiter(config.QPS, config.Burst),
+	}, nil
+}
+
+// kubernetesClient returns an HTTP client verifying the server with config's CA and presenting its client
+// certificate. Without TLS settings the client has no Transport, so DefaultTransport is used.
+func kubernetesClient(config KubernetesConfig) (*http.Client, error) {
+	if config.CAData == nil && config.CertData == nil && !config.Insecure {
+		return &http.Client{}, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: config.Insecure}
+	if config.CAData != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(config.CAData) {
+			return nil, errors.New("kubernetes: CAData contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.CertData != nil {
+		cert, err := tls.X509KeyPair(config.CertData, config.KeyData)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport := resilientbridge.NewTransport()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// SetRateLimitDefaultsForType replaces the client-side bucket with maxRequests per windowSecs, bursting up
+// to maxRequests.
+func (k *KubernetesAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	if maxRequests <= 0 || windowSecs <= 0 {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.bucket = resilientbridge.NewTokenBucketLimiter(float64(maxRequests)/float64(windowSecs), maxRequests)
+}
+
+// IdentifyRequestType returns "rest" for all requests: the client-side limit is shared by all of them.
+func (k *KubernetesAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the Kubernetes adapter supports.
+func (k *KubernetesAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{
+		SupportsAbsoluteURLs: true,
+		SupportsStreaming:    true,
+		SupportsHealthProbe:  true,
+	}
+}
+
+func (k *KubernetesAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return k.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (k *KubernetesAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && !k.currentBucket().Allow() {
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{kubernetesThrottledHeader: "true"},
+			Data:       []byte(kubernetesThrottledStatus),
+		}, nil
+	}
+	httpReq, err := k.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resilientbridge.DoRoundTrip(k.client, httpReq)
+}
+
+// ExecuteStreamRequest behaves like ExecuteRequest but returns the response body unread, for watches
+// (?watch=true) and pod logs.
+func (k *KubernetesAdapter) ExecuteStreamRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.StreamResponse, error) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && !k.currentBucket().Allow() {
+		return &resilientbridge.StreamResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{kubernetesThrottledHeader: "true"},
+			Body:       io.NopCloser(strings.NewReader(kubernetesThrottledStatus)),
+		}, nil
+	}
+	httpReq, err := k.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resilientbridge.DoStreamRoundTrip(k.client, httpReq)
+}
+
+// kubernetesThrottledStatus is the Status body of the adapter's synthetic 429s.
+const kubernetesThrottledStatus = `{"kind":"Status","apiVersion":"v1","status":"Failure",` +
+	`"message":"client-side rate limit reached","reason":"TooManyRequests","code":429}`
+
+func (k *KubernetesAdapter) newHTTPRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL(k.Config.Host), bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for key, v := range req.Headers {
+		httpReq.Header.Set(key, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && resilientbridge.TrustedHost(httpReq.URL.String(), k.Config.Host, req.TrustedOverride()) {
+		token, err := k.token()
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	if len(req.Body) > 0 && httpReq.Header.Get("Content-Type") == "" {
+		contentType := "application/json"
+		if strings.EqualFold(req.Method, http.MethodPatch) {
+			contentType = "application/merge-patch+json"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	return httpReq, nil
+}
+
+// token returns the bearer token: Config.BearerToken, or the contents of Config.TokenFile, re-read once
+// kubernetesTokenFileTTL has passed.
+func (k *KubernetesAdapter) token() (string, error) {
+	if k.Config.BearerToken != "" || k.Config.TokenFile == "" {
+		return k.Config.BearerToken, nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.fileToken != "" && time.Since(k.readAt) < kubernetesTokenFileTTL {
+		return k.fileToken, nil
+	}
+	data, err := os.ReadFile(k.Config.TokenFile)
+	if err != nil {
+		if k.fileToken != "" {
+			return k.fileToken, nil // keep the last token while the file is being rotated
+		}
+		return "", fmt.Errorf("kubernetes: error reading token file: %w", err)
+	}
+	k.fileToken, k.readAt = strings.TrimSpace(string(data)), time.Now()
+	return k.fileToken, nil
+}
+
+func (k *KubernetesAdapter) currentBucket() *resilientbridge.TokenBucketLimiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.bucket
+}
+
+// ParseRateLimitInfo returns nil: the API server sends no rate limit headers.
+func (k *KubernetesAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (k *KubernetesAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// RateLimitWait returns, for the adapter's own 429s, the time until the client-side bucket has a token,
+// and otherwise the retryAfterSeconds of a 429 Status body. It is used when there is no Retry-After.
+func (k *KubernetesAdapter) RateLimitWait(resp *resilientbridge.NormalizedResponse) time.Duration {
+	if resp.Headers[kubernetesThrottledHeader] != "" {
+		if wait := k.currentBucket().NextTokenIn(); wait > 0 {
+			return wait
+		}
+		return time.Millisecond
+	}
+	var status struct {
+		Details struct {
+			RetryAfterSeconds int `json:"retryAfterSeconds"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(resp.Data, &status); err == nil && status.Details.RetryAfterSeconds > 0 {
+		return time.Duration(status.Details.RetryAfterSeconds) * time.Second
+	}
+	return 0
+}
+
+// ClassifyError reports 401 as resilientbridge.ErrUnauthorized: a rejected token is not retried.
+func (k *KubernetesAdapter) ClassifyError(resp *resilientbridge.NormalizedResponse) error {
+	if resp.StatusCode == 401 {
+		return resilientbridge.ErrUnauthorized
+	}
+	return nil
+}
+
+// HealthProbe returns GET /version, which any authenticated client may read.
+func (k *KubernetesAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/version"}
+}
+
+// MaxPageSize returns "limit" and KubernetesListChunkSize for GET requests that are not watches, for
+// sdk.Paginate with PaginateOptions.MaxPageSize.
+func (k *KubernetesAdapter) MaxPageSize(req *resilientbridge.NormalizedRequest) (string, int) {
+	if (req.Method != "" && !strings.EqualFold(req.Method, http.MethodGet)) || strings.Contains(req.Endpoint, "watch=") {
+		return "", 0
+	}
+	return "limit", KubernetesListChunkSize
+}
+
+// kubernetesList holds the pagination field of list responses.
+type kubernetesList struct {
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+}
+
+// KubernetesNextPage is a resilientbridge.NextPageFunc for list requests. It passes the response's
+// "metadata.continue" token as the next request's "continue" parameter and stops when the token is empty.
+func KubernetesNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	var list kubernetesList
+	if err := json.Unmarshal(resp.Data, &list); err != nil {
+		return nil, err
+	}
+	if list.Metadata.Continue == "" {
+		return nil, nil
+	}
+	return resilientbridge.NextPageRequest(req, resilientbridge.WithQueryParam(req.Endpoint, "continue", list.Metadata.Continue)), nil
+}
@@ -19,13 +19,20 @@
 // - get_paginated: 200 requests per minute (for listing resources)
 // - get_single_resource: 800 requests per minute
 // - default_action (other non-GET calls): 800 requests per minute
+// HEAD and OPTIONS requests are classified like GETs.
+//
+// Export/Import carry the request history across restarts (sdk.ExportRateLimitState).
+//
+// List responses report page/pages/results in the body; LinodePageCount lets sdk.PaginateParallel fetch them concurrently.
 
 package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
+	"encoding/json"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -59,9 +66,24 @@ func (l *LinodeAdapter) IdentifyRequestType(req *resilientbridge.NormalizedReque
 	return "rest"
 }
 
+// Capabilities reports the optional features the Linode adapter supports.
+func (l *LinodeAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{
+		SupportsAbsoluteURLs: true,
+		SupportsHealthProbe:  true,
+		SupportsStateExport:  true,
+	}
+}
+
 func (l *LinodeAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return l.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (l *LinodeAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	action, limit, window := l.classifyRequest(req)
-	if l.isRateLimited(action, limit, window) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && l.isRateLimited(action, limit, window) {
 		// If rate-limited, return a synthetic 429 before making the request.
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
@@ -71,10 +93,9 @@ func (l *LinodeAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (
 	}
 
 	client := &http.Client{}
-	baseURL := "https://api.linode.com/v4"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://api.linode.com/v4")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -82,35 +103,22 @@ func (l *LinodeAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && l.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && l.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.linode.com", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+l.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Record the request timestamp after a successful execution
 	l.recordRequest(action)
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 func (l *LinodeAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -148,10 +156,38 @@ func (l *LinodeAdapter) IsRateLimitError(resp *resilientbridge.NormalizedRespons
 	return resp.StatusCode == 429
 }
 
+// HealthProbe returns GET /account.
+func (l *LinodeAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/account"}
+}
+
+// ExtractErrorMessage formats Linode's {"errors":[{"field":"label","reason":"..."}]} bodies as "label: ...".
+func (l *LinodeAdapter) ExtractErrorMessage(resp *resilientbridge.NormalizedResponse) string {
+	var body struct {
+		Errors []struct {
+			Field  string `json:"field"`
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.Data, &body); err != nil {
+		return ""
+	}
+	var reasons []string
+	for _, e := range body.Errors {
+		if e.Field != "" {
+			reasons = append(reasons, e.Field+": "+e.Reason)
+		} else if e.Reason != "" {
+			reasons = append(reasons, e.Reason)
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
 // classifyRequest determines the action category and returns (action, limit, window_seconds).
 // Different endpoints and methods map to different rate limits, as documented above.
 func (l *LinodeAdapter) classifyRequest(req *resilientbridge.NormalizedRequest) (string, int, int64) {
-	method := strings.ToUpper(req.Method)
+	method := resilientbridge.NormalizeMethod(req.Method)
+	read := resilientbridge.IsReadMethod(method)
 	path := req.Endpoint
 
 	// Create a Linode: 5 req/15s
@@ -165,12 +201,12 @@ func (l *LinodeAdapter) classifyRequest(req *resilientbridge.NormalizedRequest)
 	}
 
 	// List images: GET /images = 20 req/min
-	if method == "GET" && strings.HasPrefix(path, "/images") && (path == "/images" || strings.Contains(path, "/images?")) {
+	if read && strings.HasPrefix(path, "/images") && (path == "/images" || strings.Contains(path, "/images?")) {
 		return "list_images", 20, 60
 	}
 
 	// Stats operation: GET something/stats = 50 req/min
-	if method == "GET" && strings.Contains(path, "/stats") {
+	if read && strings.Contains(path, "/stats") {
 		return "stats_operation", 50, 60
 	}
 
@@ -189,8 +225,8 @@ func (l *LinodeAdapter) classifyRequest(req *resilientbridge.NormalizedRequest)
 		return "accept_service_transfer", 2, 60
 	}
 
-	// For GET requests, distinguish between fetching a single resource (with ID) or paginated lists:
-	if method == "GET" {
+	// For reads (GET, HEAD, OPTIONS), distinguish between fetching a single resource (with ID) or paginated lists:
+	if read {
 		parts := strings.Split(path, "/")
 		if len(parts) > 2 {
 			// Check last part if numeric
@@ -240,3 +276,42 @@ func isNumeric(s string) bool {
 	_, err := strconv.Atoi(s)
 	return err == nil
 }
+
+// LinodePageCount is a resilientbridge.PageCountFunc for Linode list endpoints, which report the total
+// number of pages in the body's "pages" field. Use it with sdk.PaginateParallel.
+func LinodePageCount(resp *resilientbridge.NormalizedResponse) (int, error) {
+	var page struct {
+		Pages int `json:"pages"`
+	}
+	if err := json.Unmarshal(resp.Data, &page); err != nil {
+		return 0, err
+	}
+	return page.Pages, nil
+}
+
+// Export returns the recent request timestamps of each action category, for sdk.ExportRateLimitState.
+func (l *LinodeAdapter) Export() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return json.Marshal(l.requestHistory)
+}
+
+// Import merges a snapshot produced by Export into the adapter's request history.
+func (l *LinodeAdapter) Import(data []byte) error {
+	var history map[string][]int64
+	if err := json.Unmarshal(data, &history); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.requestHistory == nil {
+		l.requestHistory = make(map[string][]int64)
+	}
+	for action, timestamps := range history {
+		merged := append(l.requestHistory[action], timestamps...)
+		sort.Slice(merged, func(i, j int) bool { return merged[i] < merged[j] })
+		l.requestHistory[action] = merged
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// npm_adapter.go
+// --------------
+// This adapter integrates with the public npm registry (https://registry.npmjs.org), for package metadata
+// ("packuments") and tarball downloads. It complements the GitHub Packages npm example with the upstream registry.
+//
+// Key Points:
+// - The registry does not publish fixed limits or rate-limit headers; it answers 429 with Retry-After when a
+//   client is too aggressive, which the SDK honors. No local window is applied.
+// - A descriptive User-Agent is always sent (npm asks automated clients to identify themselves): UserAgent if
+//   set, otherwise ProviderConfig.UserAgent or the SDK's DefaultUserAgent.
+// - Conditional requests: successful GET responses carrying an ETag are cached in memory, and later GETs of
+//   the same endpoint send If-None-Match. A 304 is answered from the cache as a 200 with the cached body
+//   (and an "x-npm-cache: hit" header), so unchanged metadata costs no download. Requests that set their own
+//   If-None-Match bypass the cache and see the raw 304. Tarballs (immutable, potentially large) are not cached.
+// - The cache honors Vary: entries are keyed by endpoint and Accept (the registry serves abbreviated
+//   "application/vnd.npm.install-v1+json" and full JSON documents from the same URL), and an entry is only
+//   reused when the request headers named in the response's Vary match the ones it was stored with.
+//   Responses with "Vary: *" are not cached.
+// - ExecuteStreamRequest supports sdk.RequestStream for tarball downloads.
+// - NPMPackageEndpoint builds the metadata endpoint for plain and scoped package names.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// NPMDefaultUserAgent is the User-Agent sent when neither NPMAdapter.UserAgent nor
+// ProviderConfig.UserAgent is set.
+const NPMDefaultUserAgent = resilientbridge.DefaultUserAgent
+
+type NPMAdapter struct {
+	UserAgent string // Overrides ProviderConfig.UserAgent when set
+	APIToken  string // optional, for private packages
+
+	mu    sync.Mutex
+	etags map[string]npmCachedResponse // npmCacheKey -> last 200 response with an ETag
+}
+
+type npmCachedResponse struct {
+	etag    string
+	headers map[string]string
+	data    []byte
+	vary    map[string]string // request header values of the headers named in the response's Vary
+}
+
+// matches reports whether a request with header h may reuse the cached response.
+func (c npmCachedResponse) matches(h http.Header) bool {
+	for name, value := range c.vary {
+		if h.Get(name) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// NewNPMAdapter creates an NPMAdapter for the public registry.
+func NewNPMAdapter() *NPMAdapter {
+	return &NPMAdapter{
+		etags: make(map[string]npmCachedResponse),
+	}
+}
+
+// NPMPackageEndpoint returns the metadata endpoint for a package, escaping the "/" of scoped names
+// ("@scope/name" -> "/@scope%2fname").
+func NPMPackageEndpoint(name string) string {
+	if strings.HasPrefix(name, "@") {
+		return "/" + strings.Replace(name, "/", "%2f", 1)
+	}
+	return "/" + url.PathEscape(name)
+}
+
+// SetRateLimitDefaultsForType is a no-op: the registry signals throttling only via 429 + Retry-After.
+func (n *NPMAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+// IdentifyRequestType returns "rest" for all registry requests.
+func (n *NPMAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the npm adapter supports. GETs of cacheable documents are revalidated with If-None-Match.
+func (n *NPMAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{
+		SupportsAbsoluteURLs:        true,
+		SupportsConditionalRequests: true,
+		SupportsStreaming:           true,
+		SupportsHealthProbe:         true,
+	}
+}
+
+func (n *NPMAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return n.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (n *NPMAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	httpReq, err := n.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheable := n.isCacheable(req) && httpReq.Header.Get("If-None-Match") == ""
+	key := npmCacheKey(req.Endpoint, httpReq.Header)
+	var cached npmCachedResponse
+	var hasCached bool
+	if cacheable {
+		n.mu.Lock()
+		cached, hasCached = n.etags[key]
+		n.mu.Unlock()
+		hasCached = hasCached && cached.matches(httpReq.Header)
+		if hasCached {
+			httpReq.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
+	client := &http.Client{}
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil || !cacheable {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hasCached:
+		headers := make(map[string]string, len(cached.headers)+1)
+		for k, v := range cached.headers {
+			headers[k] = v
+		}
+		headers["x-npm-cache"] = "hit"
+		return &resilientbridge.NormalizedResponse{StatusCode: http.StatusOK, Headers: headers, Data: cached.data}, nil
+	case resp.StatusCode == http.StatusOK && err == nil && resp.Headers["etag"] != "":
+		vary, ok := npmVaryValues(resp.Headers["vary"], httpReq.Header)
+		if !ok {
+			break
+		}
+		n.mu.Lock()
+		n.etags[key] = npmCachedResponse{etag: resp.Headers["etag"], headers: resp.Headers, data: resp.Data, vary: vary}
+		n.mu.Unlock()
+	}
+	return resp, err
+}
+
+// ExecuteStreamRequest returns the response body unread, for tarball downloads.
+func (n *NPMAdapter) ExecuteStreamRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.StreamResponse, error) {
+	httpReq, err := n.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{}
+	return resilientbridge.DoStreamRoundTrip(client, httpReq)
+}
+
+// ParseRateLimitInfo returns nil: the registry doesn't send rate limit headers.
+func (n *NPMAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (n *NPMAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns the registry ping endpoint.
+func (n *NPMAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/-/ping"}
+}
+
+func (n *NPMAdapter) newHTTPRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL("https://registry.npmjs.org"), bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("User-Agent") == "" && n.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", n.UserAgent)
+	}
+	if httpReq.Header.Get("Authorization") == "" && n.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "registry.npmjs.org", req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+n.APIToken)
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	return httpReq, nil
+}
+
+// npmCacheKey returns the ETag cache key of a request: its endpoint and Accept header, so different
+// representations of one URL never share an entry.
+func npmCacheKey(endpoint string, h http.Header) string {
+	return endpoint + "\n" + h.Get("Accept")
+}
+
+// npmVaryValues returns the values in h of the request headers listed in a response's Vary header. ok is
+// false for "Vary: *", which makes the response uncacheable.
+func npmVaryValues(vary string, h http.Header) (values map[string]string, ok bool) {
+	values = make(map[string]string)
+	for _, name := range strings.Split(vary, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "":
+			continue
+		case "*":
+			return nil, false
+		}
+		values[http.CanonicalHeaderKey(name)] = h.Get(name)
+	}
+	return values, true
+}
+
+// isCacheable reports whether req is a metadata GET eligible for the ETag cache.
+func (n *NPMAdapter) isCacheable(req *resilientbridge.NormalizedRequest) bool {
+	return strings.ToUpper(req.Method) == "GET" && !strings.HasSuffix(req.Endpoint, ".tgz") && n.etags != nil
+}
@@ -1,24 +1,26 @@
 // openai_adapter.go
 // -----------------
-// This adapter integrates with the OpenAI API. We rely on the rate limit headers returned by the API:
+// This adapter integrates with the OpenAI API, and with OpenAI-compatible APIs (Azure OpenAI, Groq,
+// Together, self-hosted gateways, ...) through its base URL. We rely on the rate limit headers returned
+// by the API, which cover two dimensions, requests and tokens per minute:
 //
 // Headers:
-// - x-ratelimit-limit-requests: Maximum requests allowed before rate limit is hit.
-// - x-ratelimit-remaining-requests: Requests remaining in the current window.
-// - x-ratelimit-reset-requests: The time until the rate limit resets to its initial state, in a string like "1s".
-//   We must parse this duration and convert it to a future timestamp.
+// - x-ratelimit-limit-requests / x-ratelimit-limit-tokens: Maximum requests / tokens in the window.
+// - x-ratelimit-remaining-requests / x-ratelimit-remaining-tokens: Requests / tokens remaining.
+// - x-ratelimit-reset-requests / x-ratelimit-reset-tokens: The time until the limit resets to its initial
+//   state, as a duration like "1s" or "6m0s". We parse it and convert it to a future timestamp.
 //
-// Tokens are not applicable, so we ignore any token-related headers.
+// ParseRateLimitInfo reports both dimensions, so the SDK holds requests back until whichever limit is
+// exhausted has reset: a few large completions can run out of tokens long before the request limit.
 //
-// We do not preemptively block requests before hitting the limit. Instead, if we receive a 429 response, we return
-// an error so the SDK can handle retries. ParseRateLimitInfo returns the rate limit info derived from the headers.
+// We do not preemptively block requests in the adapter. A 429 is returned to the SDK like any other
+// response, so it is retried after the Retry-After header OpenAI sends.
 
 package adapters
 
 import (
 	"bytes"
-	"errors"
-	"io"
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -31,10 +33,13 @@ import (
 const (
 	OpenAIDefaultMaxRequests = 60
 	OpenAIDefaultWindowSecs  = 60
+
+	OpenAIDefaultBaseURL = "https://api.openai.com"
 )
 
 type OpenAIAdapter struct {
-	APIKey string
+	APIKey  string
+	BaseURL string // API root that endpoints such as "/v1/models" are joined to; "" = OpenAIDefaultBaseURL
 
 	mu sync.Mutex
 
@@ -42,10 +47,12 @@ type OpenAIAdapter struct {
 	restWindowSecs  int64
 }
 
-// NewOpenAIAdapter creates a new adapter with default limits.
-func NewOpenAIAdapter(apiKey string) *OpenAIAdapter {
+// NewOpenAIAdapter creates a new adapter with default limits for the API at baseURL, or OpenAI's own
+// API when baseURL is empty.
+func NewOpenAIAdapter(apiKey, baseURL string) *OpenAIAdapter {
 	return &OpenAIAdapter{
 		APIKey:          apiKey,
+		BaseURL:         baseURL,
 		restMaxRequests: OpenAIDefaultMaxRequests,
 		restWindowSecs:  OpenAIDefaultWindowSecs,
 	}
@@ -73,13 +80,27 @@ func (o *OpenAIAdapter) IdentifyRequestType(req *resilientbridge.NormalizedReque
 	return "rest"
 }
 
-// ExecuteRequest sends the request to OpenAI. If OpenAI returns 429, we return an error
-// so that the SDK can handle retries. We do not do synthetic 429 before sending.
+// Capabilities reports the optional features the OpenAI adapter supports.
+func (o *OpenAIAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+// ExecuteRequest sends the request to the API. We do not do synthetic 429 before sending.
 func (o *OpenAIAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return o.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (o *OpenAIAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	client := &http.Client{}
-	fullURL := "https://api.openai.com" + req.Endpoint
+	baseURL := strings.TrimRight(o.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = OpenAIDefaultBaseURL
+	}
+	fullURL := req.URL(baseURL)
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -88,86 +109,48 @@ func (o *OpenAIAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && o.APIKey != "" {
+	if httpReq.Header.Get("Authorization") == "" && o.APIKey != "" && resilientbridge.TrustedHost(httpReq.URL.String(), baseURL, req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+o.APIKey)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	response := &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}
-
-	// If actual 429 from OpenAI, return error.
-	if resp.StatusCode == 429 {
-		return response, errors.New("openai: rate limit exceeded (429)")
-	}
-
-	return response, nil
+	return resilientbridge.DoRoundTrip(client, httpReq)
 }
 
-// ParseRateLimitInfo uses the x-ratelimit-* headers to determine the current rate limit status.
-// We rely solely on these headers instead of internal timestamps.
+// ParseRateLimitInfo uses the x-ratelimit-* headers to determine the current rate limit status of both
+// the request and the token limit. We rely solely on these headers instead of internal timestamps.
 //
 // Example headers:
 // x-ratelimit-limit-requests: "60"
 // x-ratelimit-remaining-requests: "59"
 // x-ratelimit-reset-requests: "1s"
+// x-ratelimit-limit-tokens: "150000"
+// x-ratelimit-remaining-tokens: "149984"
+// x-ratelimit-reset-tokens: "6m0s"
 //
-// We'll parse the integer values and the duration.
-// If reset is "1s", we'll convert it into a future Unix timestamp in milliseconds.
+// We'll parse the integer values and the durations, converting each reset into a future Unix timestamp
+// in milliseconds. A dimension with missing or unparsable headers is left unset; if neither parses, we
+// return nil. Better to have no info than wrong info.
 func (o *OpenAIAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
-	h := resp.Headers
-	limitStr, limitOK := h["x-ratelimit-limit-requests"]
-	remainingStr, remainingOK := h["x-ratelimit-remaining-requests"]
-	resetStr, resetOK := h["x-ratelimit-reset-requests"]
-
-	if !limitOK || !remainingOK || !resetOK {
-		// If headers are not present, return nil. We can't derive info without them.
-		return nil, nil
-	}
+	now := time.Now()
+	info := &resilientbridge.NormalizedRateLimitInfo{}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		return nil, nil
+	if limit, remaining, reset, ok := parseOpenAILimit(resp.Headers, "requests", now); ok {
+		info.MaxRequests = resilientbridge.IntPtr(limit)
+		info.RemainingRequests = resilientbridge.IntPtr(remaining)
+		info.ResetRequestsAt = &reset
 	}
-	remaining, err := strconv.Atoi(remainingStr)
-	if err != nil {
-		return nil, nil
+	if limit, remaining, reset, ok := parseOpenAILimit(resp.Headers, "tokens", now); ok {
+		info.MaxTokens = resilientbridge.IntPtr(limit)
+		info.RemainingTokens = resilientbridge.IntPtr(remaining)
+		info.ResetTokensAt = &reset
 	}
 
-	// Parse the reset duration string, e.g. "1s"
-	dur, err := time.ParseDuration(resetStr)
-	if err != nil {
-		// If we can't parse, just return nil. Better to have no info than wrong info.
+	if info.MaxRequests == nil && info.MaxTokens == nil {
 		return nil, nil
 	}
-	// Convert to a future reset timestamp in ms
-	resetMs := time.Now().Add(dur).UnixMilli()
-
-	info := &resilientbridge.NormalizedRateLimitInfo{
-		MaxRequests:       resilientbridge.IntPtr(limit),
-		RemainingRequests: resilientbridge.IntPtr(remaining),
-		ResetRequestsAt:   &resetMs,
-	}
-
 	return info, nil
 }
 
@@ -175,3 +158,27 @@ func (o *OpenAIAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedRespo
 func (o *OpenAIAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
 	return resp.StatusCode == 429
 }
+
+// HealthProbe returns the model listing.
+func (o *OpenAIAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/v1/models"}
+}
+
+// parseOpenAILimit reads the limit, remaining count, and reset time (Unix ms) of one dimension
+// ("requests" or "tokens") from the x-ratelimit-* headers.
+func parseOpenAILimit(h map[string]string, dimension string, now time.Time) (int, int, int64, bool) {
+	limit, err := strconv.Atoi(h["x-ratelimit-limit-"+dimension])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	remaining, err := strconv.Atoi(h["x-ratelimit-remaining-"+dimension])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	// The reset is a duration string, e.g. "1s", "6m0s", or "20ms"
+	dur, err := time.ParseDuration(h["x-ratelimit-reset-"+dimension])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return limit, remaining, now.Add(dur).UnixMilli(), true
+}
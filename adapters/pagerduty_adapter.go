@@ -0,0 +1,208 @@
+// pagerduty_adapter.go
+// --------------------
+// This adapter integrates with the PagerDuty REST API (https://api.pagerduty.com).
+//
+// Key Points:
+// - Authentication uses PagerDuty's "Authorization: Token token=<key>" scheme.
+// - PagerDuty requires "Accept: application/vnd.pagerduty+json;version=2"; it is injected unless the
+//   request sets its own Accept header.
+// - Rate limits are reported via ratelimit-limit / ratelimit-remaining / ratelimit-reset, where reset is
+//   the number of seconds until the window resets. On 429, PagerDuty sends Retry-After, which the SDK honors.
+// - The adapter keeps a local rolling window (960 requests per minute by default) so bursts are throttled
+//   before PagerDuty rejects them.
+// - List endpoints use offset/limit pagination with a "more" flag; PagerDutyNextPage plugs that into
+//   sdk.Paginate.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	PagerDutyDefaultMaxRequests = 960
+	PagerDutyDefaultWindowSecs  = 60
+)
+
+type PagerDutyAdapter struct {
+	APIToken string
+
+	mu                sync.Mutex
+	requestTimestamps []int64
+
+	restMaxRequests int
+	restWindowSecs  int64
+}
+
+// NewPagerDutyAdapter creates a PagerDutyAdapter with a REST API key.
+func NewPagerDutyAdapter(apiToken string) *PagerDutyAdapter {
+	return &PagerDutyAdapter{
+		APIToken:        apiToken,
+		restMaxRequests: PagerDutyDefaultMaxRequests,
+		restWindowSecs:  PagerDutyDefaultWindowSecs,
+	}
+}
+
+// SetRateLimitDefaultsForType sets the local rolling window. PagerDuty only has REST requests.
+func (p *PagerDutyAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if requestType == "rest" {
+		if maxRequests == 0 {
+			maxRequests = PagerDutyDefaultMaxRequests
+		}
+		if windowSecs == 0 {
+			windowSecs = PagerDutyDefaultWindowSecs
+		}
+		p.restMaxRequests = maxRequests
+		p.restWindowSecs = windowSecs
+	}
+}
+
+// IdentifyRequestType returns "rest" since PagerDuty has no GraphQL API.
+func (p *PagerDutyAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the PagerDuty adapter supports.
+func (p *PagerDutyAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (p *PagerDutyAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return p.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (p *PagerDutyAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && p.isRateLimited() {
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{},
+			Data:       []byte(`{"error":"PagerDuty rate limit reached"}`),
+		}, nil
+	}
+
+	client := &http.Client{}
+	fullURL := req.URL("https://api.pagerduty.com")
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && p.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.pagerduty.com", req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Token token="+p.APIToken)
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		return nil, err
+	}
+
+	p.recordRequest()
+
+	return resp, err
+}
+
+// ParseRateLimitInfo reads PagerDuty's ratelimit-* headers. ratelimit-reset is relative (seconds).
+func (p *PagerDutyAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	h := resp.Headers
+	parseInt := func(key string) *int {
+		if val, ok := h[key]; ok {
+			if i, err := strconv.Atoi(val); err == nil {
+				return resilientbridge.IntPtr(i)
+			}
+		}
+		return nil
+	}
+
+	var resetAt *int64
+	if secs := parseInt("ratelimit-reset"); secs != nil {
+		ms := time.Now().Add(time.Duration(*secs) * time.Second).UnixMilli()
+		resetAt = &ms
+	}
+
+	info := &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       parseInt("ratelimit-limit"),
+		RemainingRequests: parseInt("ratelimit-remaining"),
+		ResetRequestsAt:   resetAt,
+	}
+	if info.MaxRequests == nil && info.RemainingRequests == nil && info.ResetRequestsAt == nil {
+		return nil, nil
+	}
+	return info, nil
+}
+
+func (p *PagerDutyAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns the account abilities listing.
+func (p *PagerDutyAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/abilities"}
+}
+
+// pagerDutyPage holds the pagination fields of a PagerDuty list response.
+type pagerDutyPage struct {
+	Offset int  `json:"offset"`
+	Limit  int  `json:"limit"`
+	More   bool `json:"more"`
+}
+
+// PagerDutyNextPage is a resilientbridge.NextPageFunc for PagerDuty's offset/limit pagination.
+// It advances offset by the page's limit and stops once the response reports more=false.
+func PagerDutyNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	var page pagerDutyPage
+	if err := json.Unmarshal(resp.Data, &page); err != nil {
+		return nil, err
+	}
+	if !page.More || page.Limit <= 0 {
+		return nil, nil
+	}
+
+	endpoint := resilientbridge.WithQueryParam(req.Endpoint, "offset", strconv.Itoa(page.Offset+page.Limit))
+	endpoint = resilientbridge.WithQueryParam(endpoint, "limit", strconv.Itoa(page.Limit))
+	return resilientbridge.NextPageRequest(req, endpoint), nil
+}
+
+func (p *PagerDutyAdapter) isRateLimited() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now().Unix()
+	windowStart := now - p.restWindowSecs
+	var newTimestamps []int64
+	for _, ts := range p.requestTimestamps {
+		if ts >= windowStart {
+			newTimestamps = append(newTimestamps, ts)
+		}
+	}
+	p.requestTimestamps = newTimestamps
+
+	return len(newTimestamps) >= p.restMaxRequests
+}
+
+func (p *PagerDutyAdapter) recordRequest() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requestTimestamps = append(p.requestTimestamps, time.Now().Unix())
+}
@@ -0,0 +1,119 @@
+// quay_adapter.go
+// ---------------
+// This adapter integrates with the Quay container registry API (https://quay.io/api/v1, or a self-hosted
+// Red Hat Quay instance).
+//
+// Key Points:
+// - Authentication uses an OAuth application token or robot token as "Authorization: Bearer <token>".
+// - Quay does not send rate limit headers; it answers 429 when a client is too aggressive, and the SDK
+//   retries with backoff (or Retry-After, if present).
+// - Quay paginates in the response body: repository listings return a "next_page" cursor, and tag
+//   listings return "page" with a "has_additional" flag. QuayNextPage plugs both into sdk.Paginate.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const QuayDefaultHost = "quay.io"
+
+type QuayAdapter struct {
+	APIToken string
+	Host     string // quay.io or a self-hosted Quay hostname
+}
+
+// NewQuayAdapter creates a QuayAdapter. An empty host selects QuayDefaultHost.
+func NewQuayAdapter(apiToken, host string) *QuayAdapter {
+	if host == "" {
+		host = QuayDefaultHost
+	}
+	return &QuayAdapter{APIToken: apiToken, Host: host}
+}
+
+// SetRateLimitDefaultsForType is a no-op: Quay signals throttling only via 429.
+func (q *QuayAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+// IdentifyRequestType returns "rest" for all Quay API requests.
+func (q *QuayAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the Quay adapter supports. Endpoints are paths under /api/, so absolute URLs are not accepted.
+func (q *QuayAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{}
+}
+
+func (q *QuayAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return q.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig. Endpoints are relative to /api/v1 (e.g. "/repository?namespace=org").
+func (q *QuayAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	client := &http.Client{}
+	endpoint := req.Endpoint
+	if !strings.HasPrefix(endpoint, "/api/") {
+		endpoint = "/api/v1" + endpoint
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.BaseURL("https://"+q.Host)+endpoint, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && q.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), q.Host, req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+q.APIToken)
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	return resilientbridge.DoRoundTrip(client, httpReq)
+}
+
+// ParseRateLimitInfo returns nil: Quay doesn't send rate limit headers.
+func (q *QuayAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (q *QuayAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// quayPage holds the pagination fields of Quay list responses.
+type quayPage struct {
+	NextPage      string `json:"next_page"`
+	Page          int    `json:"page"`
+	HasAdditional bool   `json:"has_additional"`
+}
+
+// QuayNextPage is a resilientbridge.NextPageFunc for Quay list endpoints. It follows the "next_page"
+// cursor when present, and otherwise advances "page" while "has_additional" is true.
+func QuayNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	var page quayPage
+	if err := json.Unmarshal(resp.Data, &page); err != nil {
+		return nil, err
+	}
+	switch {
+	case page.NextPage != "":
+		return resilientbridge.NextPageRequest(req, resilientbridge.WithQueryParam(req.Endpoint, "next_page", page.NextPage)), nil
+	case page.HasAdditional:
+		current := page.Page
+		if current < 1 {
+			current = 1
+		}
+		return resilientbridge.NextPageRequest(req, resilientbridge.WithQueryParam(req.Endpoint, "page", strconv.Itoa(current+1))), nil
+	}
+	return nil, nil
+}
@@ -2,7 +2,7 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -66,9 +66,20 @@ func (r *RailwayAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequ
 	return "rest"
 }
 
+// Capabilities reports the optional features the Railway adapter supports.
+func (r *RailwayAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsGraphQL: true}
+}
+
 func (r *RailwayAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return r.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (r *RailwayAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	category := r.IdentifyRequestType(req)
-	if r.isRateLimited(category) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && r.isRateLimited(category) {
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
 			Headers:    map[string]string{},
@@ -77,10 +88,9 @@ func (r *RailwayAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest)
 	}
 
 	client := &http.Client{}
-	baseURL := "https://backboard.railway.app"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://backboard.railway.app")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -88,34 +98,21 @@ func (r *RailwayAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest)
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && r.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && r.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "backboard.railway.app", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+r.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	r.recordRequest(category)
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 func (r *RailwayAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -2,7 +2,7 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -69,58 +69,12 @@ func NewRenderAdapter(apiToken string) *RenderAdapter {
 func (r *RenderAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if maxRequests == 0 || windowSecs == 0 {
-		switch requestType {
-		case "services_create_update":
-			if maxRequests == 0 {
-				maxRequests = RenderServicesCreateUpdateMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderServicesCreateUpdateSecs
-			}
-		case "services_deploy":
-			if maxRequests == 0 {
-				maxRequests = RenderServicesDeployMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderServicesDeploySecs
-			}
-		case "deploy_hooks":
-			if maxRequests == 0 {
-				maxRequests = RenderDeployHooksMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderDeployHooksSecs
-			}
-		case "jobs":
-			if maxRequests == 0 {
-				maxRequests = RenderJobsMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderJobsSecs
-			}
-		case "other_write":
-			if maxRequests == 0 {
-				maxRequests = RenderOtherWriteMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderOtherWriteSecs
-			}
-		case "get":
-			if maxRequests == 0 {
-				maxRequests = RenderGetMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderGetSecs
-			}
-		default:
-			if maxRequests == 0 {
-				maxRequests = RenderOtherWriteMax
-			}
-			if windowSecs == 0 {
-				windowSecs = RenderOtherWriteSecs
-			}
-		}
+	defMax, defWindow := renderCategoryDefaults(requestType)
+	if maxRequests == 0 {
+		maxRequests = defMax
+	}
+	if windowSecs == 0 {
+		windowSecs = defWindow
 	}
 	r.categories[requestType] = struct {
 		maxReq     int
@@ -128,14 +82,43 @@ func (r *RenderAdapter) SetRateLimitDefaultsForType(requestType string, maxReque
 	}{maxRequests, windowSecs}
 }
 
+// renderCategoryDefaults returns the documented limit of a request category; unknown categories get the
+// other_write limit.
+func renderCategoryDefaults(category string) (int, int64) {
+	switch category {
+	case "services_create_update":
+		return RenderServicesCreateUpdateMax, RenderServicesCreateUpdateSecs
+	case "services_deploy":
+		return RenderServicesDeployMax, RenderServicesDeploySecs
+	case "deploy_hooks":
+		return RenderDeployHooksMax, RenderDeployHooksSecs
+	case "jobs":
+		return RenderJobsMax, RenderJobsSecs
+	case "get":
+		return RenderGetMax, RenderGetSecs
+	}
+	return RenderOtherWriteMax, RenderOtherWriteSecs
+}
+
 // IdentifyRequestType: Render does not mention GraphQL. Assume all are "rest".
 func (r *RenderAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
 	return "rest"
 }
 
+// Capabilities reports the optional features the Render adapter supports.
+func (r *RenderAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
 func (r *RenderAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return r.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (r *RenderAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	category := r.classifyRequest(req)
-	if r.isRateLimited(category) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && r.isRateLimited(category) {
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
 			Headers:    map[string]string{},
@@ -144,43 +127,30 @@ func (r *RenderAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (
 	}
 
 	client := &http.Client{}
-	fullURL := "https://api.render.com" + req.Endpoint
+	fullURL := req.URL("https://api.render.com")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && r.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && r.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.render.com", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+r.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	r.recordRequest(category)
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 func (r *RenderAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -216,9 +186,14 @@ func (r *RenderAdapter) IsRateLimitError(resp *resilientbridge.NormalizedRespons
 	return resp.StatusCode == 429
 }
 
+// HealthProbe returns a one-item service listing.
+func (r *RenderAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/v1/services?limit=1"}
+}
+
 func (r *RenderAdapter) classifyRequest(req *resilientbridge.NormalizedRequest) string {
 	endpoint := req.Endpoint
-	method := strings.ToUpper(req.Method)
+	method := resilientbridge.NormalizeMethod(req.Method)
 
 	if method == "POST" && strings.HasPrefix(endpoint, "/v1/services") &&
 		!renderServicesDeployPattern.MatchString(endpoint) &&
@@ -260,7 +235,7 @@ func (r *RenderAdapter) classifyRequest(req *resilientbridge.NormalizedRequest)
 		return "other_write"
 	}
 
-	if method == "GET" {
+	if resilientbridge.IsReadMethod(method) {
 		return "get"
 	}
 
@@ -273,11 +248,8 @@ func (r *RenderAdapter) isRateLimited(category string) bool {
 
 	cat, ok := r.categories[category]
 	if !ok {
-		// default to other_write if unknown
-		cat = struct {
-			maxReq     int
-			windowSecs int64
-		}{RenderOtherWriteMax, RenderOtherWriteSecs}
+		// Categories without an override get their documented limit
+		cat.maxReq, cat.windowSecs = renderCategoryDefaults(category)
 		r.categories[category] = cat
 	}
 
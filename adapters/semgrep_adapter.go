@@ -3,9 +3,8 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
-	"strings"
 
 	resilientbridge "github.com/opengovern/resilient-bridge"
 )
@@ -30,12 +29,22 @@ func (s *SemgrepAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequ
 	return "rest"
 }
 
+// Capabilities reports the optional features the Semgrep adapter supports.
+func (s *SemgrepAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
 func (s *SemgrepAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return s.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (s *SemgrepAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	client := &http.Client{}
-	baseURL := "https://semgrep.dev/api/v1"
-	fullURL := baseURL + req.Endpoint
+	fullURL := req.URL("https://semgrep.dev/api/v1")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -43,32 +52,14 @@ func (s *SemgrepAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest)
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	if httpReq.Header.Get("Authorization") == "" && s.APIToken != "" {
+	if httpReq.Header.Get("Authorization") == "" && s.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "semgrep.dev", req.TrustedOverride()) {
 		httpReq.Header.Set("Authorization", "Bearer "+s.APIToken)
 	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resilientbridge.DoRoundTrip(client, httpReq)
 }
 
 func (s *SemgrepAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
@@ -79,3 +70,8 @@ func (s *SemgrepAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResp
 func (s *SemgrepAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
 	return resp.StatusCode == 429
 }
+
+// HealthProbe returns GET /me, the identity behind the token.
+func (s *SemgrepAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/me"}
+}
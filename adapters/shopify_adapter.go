@@ -0,0 +1,182 @@
+// shopify_adapter.go
+// ------------------
+// This adapter integrates with the Shopify Admin REST API (https://{shop}.myshopify.com/admin/api/{version}).
+//
+// Key Points:
+// - Authentication uses the X-Shopify-Access-Token header.
+// - Shopify rate limits REST calls with a leaky bucket and reports its state on every response as
+//   X-Shopify-Shop-Api-Call-Limit: "used/size" (e.g. "32/40"). RemainingRequests is size - used.
+// - The adapter mirrors the bucket locally with a resilientbridge.TokenBucketLimiter that leaks at
+//   size/20 requests per second (2/s for the standard 40-request bucket, 20/s for Plus' 400), synced from
+//   the header after each response. When the local bucket is empty, a synthetic 429 with Retry-After is
+//   returned instead of overflowing Shopify's bucket.
+// - On a real 429 Shopify sends Retry-After, which the SDK honors.
+// - REST list endpoints paginate via the Link header, so sdk.Paginate's default LinkNextPage applies.
+//   Endpoints are relative to the versioned base, e.g. "/products.json?limit=250"; LinkNextPage's
+//   absolute next links are mapped back to that form.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	ShopifyDefaultAPIVersion = "2024-10"
+	ShopifyDefaultBucketSize = 40 // standard plans; Shopify Plus uses 400
+)
+
+type ShopifyAdapter struct {
+	Shop       string // shop name, e.g. "my-store" for my-store.myshopify.com
+	APIToken   string
+	APIVersion string
+
+	mu     sync.Mutex
+	bucket *resilientbridge.TokenBucketLimiter
+}
+
+// NewShopifyAdapter creates a ShopifyAdapter for the given shop using ShopifyDefaultAPIVersion.
+func NewShopifyAdapter(shop, apiToken string) *ShopifyAdapter {
+	shop = strings.TrimSuffix(shop, ".myshopify.com")
+	return &ShopifyAdapter{
+		Shop:       shop,
+		APIToken:   apiToken,
+		APIVersion: ShopifyDefaultAPIVersion,
+		bucket:     newShopifyBucket(ShopifyDefaultBucketSize),
+	}
+}
+
+// newShopifyBucket returns a full local bucket of the given size, leaking at size/20 per second.
+func newShopifyBucket(size int) *resilientbridge.TokenBucketLimiter {
+	return resilientbridge.NewTokenBucketLimiter(float64(size)/20, size)
+}
+
+// SetRateLimitDefaultsForType sets the local bucket size; the leak rate follows Shopify's size/20 ratio.
+func (s *ShopifyAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	if maxRequests <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bucket = newShopifyBucket(maxRequests)
+}
+
+// IdentifyRequestType returns "rest"; the GraphQL Admin API uses cost-based limits not handled here.
+func (s *ShopifyAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the Shopify adapter supports. Endpoints are paths under /admin/, so absolute URLs are not accepted.
+func (s *ShopifyAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{}
+}
+
+func (s *ShopifyAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return s.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (s *ShopifyAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	if !resilientbridge.RateLimitingDisabled(ctx) && !s.currentBucket().Allow() {
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{"retry-after": "1"},
+			Data:       []byte(`{"errors":"Shopify rate limit reached"}`),
+		}, nil
+	}
+
+	client := &http.Client{}
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, s.fullURL(req), bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("X-Shopify-Access-Token") == "" && s.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), s.Shop+".myshopify.com", req.TrustedOverride()) {
+		httpReq.Header.Set("X-Shopify-Access-Token", s.APIToken)
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		return nil, err
+	}
+
+	s.syncBucket(resp)
+
+	return resp, err
+}
+
+func (s *ShopifyAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	used, size, ok := parseShopifyCallLimit(resp.Headers["x-shopify-shop-api-call-limit"])
+	if !ok {
+		return nil, nil
+	}
+	return &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       resilientbridge.IntPtr(size),
+		RemainingRequests: resilientbridge.IntPtr(size - used),
+	}, nil
+}
+
+func (s *ShopifyAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// fullURL maps an endpoint to the shop's versioned Admin API URL. Endpoints that already include the
+// "/admin/api/{version}" prefix (as in Link header next URLs) are used as-is.
+func (s *ShopifyAdapter) fullURL(req *resilientbridge.NormalizedRequest) string {
+	endpoint := req.Endpoint
+	host := req.BaseURL("https://" + s.Shop + ".myshopify.com")
+	if strings.HasPrefix(endpoint, "/admin/") {
+		return host + endpoint
+	}
+	return host + "/admin/api/" + s.APIVersion + endpoint
+}
+
+func (s *ShopifyAdapter) currentBucket() *resilientbridge.TokenBucketLimiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bucket
+}
+
+// syncBucket aligns the local bucket with the call limit Shopify reported, resizing it if the shop's
+// plan uses a different bucket size.
+func (s *ShopifyAdapter) syncBucket(resp *resilientbridge.NormalizedResponse) {
+	used, size, ok := parseShopifyCallLimit(resp.Headers["x-shopify-shop-api-call-limit"])
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	if s.bucket.Burst() != size {
+		s.bucket = newShopifyBucket(size)
+	}
+	bucket := s.bucket
+	s.mu.Unlock()
+
+	bucket.Sync(size - used)
+}
+
+// parseShopifyCallLimit parses a "used/size" call limit header value.
+func parseShopifyCallLimit(val string) (used, size int, ok bool) {
+	parts := strings.SplitN(val, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	used, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+	size, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err1 != nil || err2 != nil || size <= 0 {
+		return 0, 0, false
+	}
+	return used, size, true
+}
@@ -17,9 +17,8 @@ package adapters
 
 import (
 	"bytes"
-	"io"
+	"context"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
@@ -66,47 +65,47 @@ func (t *TailScaleAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRe
 	return "rest"
 }
 
+// Capabilities reports the optional features the Tailscale adapter supports.
+func (t *TailScaleAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true}
+}
+
 // ExecuteRequest sends the HTTP request to the TailScale API.
 // It sets the Authorization header with the TailScale API token and content type if not specified.
 // After the response is received, it records the request timestamp for rate limiting calculations.
 func (t *TailScaleAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return t.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (t *TailScaleAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
 	client := &http.Client{}
-	fullURL := "https://api.tailscale.com/api" + req.Endpoint
+	fullURL := req.URL("https://api.tailscale.com/api")
 
-	httpReq, err := http.NewRequest(req.Method, fullURL, bytes.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
 	for k, v := range req.Headers {
 		httpReq.Header.Set(k, v)
 	}
-	httpReq.Header.Set("Authorization", "Bearer "+t.APIToken)
+	if resilientbridge.TrustedHost(httpReq.URL.String(), "api.tailscale.com", req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+t.APIToken)
+	}
 	if httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	// Record the request after it completes to update internal rate limit tracking.
 	t.recordRequest()
 
-	data, _ := io.ReadAll(resp.Body)
-	headers := make(map[string]string)
-	for k, vals := range resp.Header {
-		if len(vals) > 0 {
-			headers[strings.ToLower(k)] = vals[0]
-		}
-	}
-
-	return &resilientbridge.NormalizedResponse{
-		StatusCode: resp.StatusCode,
-		Headers:    headers,
-		Data:       data,
-	}, nil
+	return resp, err
 }
 
 // ParseRateLimitInfo calculates the current rate limit state based on the timestamps of recent requests.
@@ -0,0 +1,160 @@
+// token_refresher.go
+// ------------------
+// This file provides BackgroundTokenSource, an opt-in oauth2.TokenSource that renews tokens in the
+// background a margin before they expire, so no request has to wait for a token exchange on its path.
+// Wrap the adapter's token source with it when the adapter is created:
+//
+//	ts := adapters.NewBackgroundTokenSource(creds.TokenSource, adapters.DefaultTokenRefreshMargin)
+//	gcp := adapters.NewGCPAdapter(ts)
+//	defer gcp.Close()
+//
+// Lifecycle: NewBackgroundTokenSource starts one goroutine, which holds the wrapped source and the
+// current token and fetches the first token right away. It runs until Close (the GCP and Azure adapters'
+// Close call it), and keeps the wrapped source reachable until then: a BackgroundTokenSource that is
+// dropped without Close leaks its goroutine. Token stays usable after Close, fetching synchronously
+// whenever the cached token is no longer valid.
+//
+// A failed refresh is retried with exponential backoff (from TokenRefreshMinBackoff up to
+// TokenRefreshMaxBackoff) while the previous token is kept; requests only fetch synchronously once
+// that token has actually expired. The same backoff applies when the source answers with a token that is
+// already within the margin, as sources with their own cache (oauth2.ReuseTokenSource) do until shortly
+// before expiry; wrap the uncached source where there is one. Calls to the wrapped source never overlap,
+// so sources that aren't safe for concurrent use can be wrapped as well.
+
+package adapters
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	DefaultTokenRefreshMargin = 5 * time.Minute
+
+	TokenRefreshMinBackoff = time.Second
+	TokenRefreshMaxBackoff = time.Minute
+)
+
+// BackgroundTokenSource caches the token of a wrapped source and renews it Margin before expiry.
+type BackgroundTokenSource struct {
+	src    oauth2.TokenSource
+	margin time.Duration
+
+	fetchMu sync.Mutex // serializes calls to src
+	mu      sync.Mutex
+	token   *oauth2.Token
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBackgroundTokenSource wraps src and starts refreshing its tokens margin before they expire. A
+// margin <= 0 uses DefaultTokenRefreshMargin. Call Close to stop the refresher.
+func NewBackgroundTokenSource(src oauth2.TokenSource, margin time.Duration) *BackgroundTokenSource {
+	if margin <= 0 {
+		margin = DefaultTokenRefreshMargin
+	}
+	b := &BackgroundTokenSource{
+		src:    src,
+		margin: margin,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Token returns the cached token while it is valid, and otherwise fetches one from the wrapped source.
+func (b *BackgroundTokenSource) Token() (*oauth2.Token, error) {
+	b.mu.Lock()
+	token := b.token
+	b.mu.Unlock()
+	if token.Valid() {
+		return token, nil
+	}
+	return b.fetch(false)
+}
+
+// Close stops the background refresher and waits for it to exit. It is safe to call more than once.
+func (b *BackgroundTokenSource) Close() error {
+	b.closeOnce.Do(func() { close(b.stop) })
+	<-b.done
+	return nil
+}
+
+// fetch gets a token from the wrapped source and caches it. Unless force is set, a token cached by a
+// concurrent fetch while waiting for fetchMu is returned instead.
+func (b *BackgroundTokenSource) fetch(force bool) (*oauth2.Token, error) {
+	b.fetchMu.Lock()
+	defer b.fetchMu.Unlock()
+
+	if !force {
+		b.mu.Lock()
+		token := b.token
+		b.mu.Unlock()
+		if token.Valid() {
+			return token, nil
+		}
+	}
+
+	token, err := b.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	b.token = token
+	b.mu.Unlock()
+	return token, nil
+}
+
+// reuseTokenSource caches the tokens of src, unless src is a BackgroundTokenSource, which caches them
+// itself and must stay reachable for the adapter's Close.
+func reuseTokenSource(src oauth2.TokenSource) oauth2.TokenSource {
+	if b, ok := src.(*BackgroundTokenSource); ok {
+		return b
+	}
+	return oauth2.ReuseTokenSource(nil, src)
+}
+
+// run refreshes the token margin before each expiry until Close. Tokens without an expiry are never
+// refreshed.
+func (b *BackgroundTokenSource) run() {
+	defer close(b.done)
+
+	backoff := TokenRefreshMinBackoff
+	wait := time.Duration(0)
+	first := true
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-b.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// The first fetch may find a token already fetched by a caller of Token
+		token, err := b.fetch(!first)
+		first = false
+		if err == nil && token.Expiry.IsZero() {
+			<-b.stop
+			return
+		}
+		if err == nil {
+			wait = time.Until(token.Expiry) - b.margin
+		}
+		if err != nil || wait <= 0 {
+			// Failed, or the source handed back a token that is already inside the margin (e.g. one it
+			// caches itself): try again later
+			wait = backoff
+			if backoff *= 2; backoff > TokenRefreshMaxBackoff {
+				backoff = TokenRefreshMaxBackoff
+			}
+			continue
+		}
+		backoff = TokenRefreshMinBackoff
+	}
+}
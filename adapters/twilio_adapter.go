@@ -0,0 +1,189 @@
+// twilio_adapter.go
+// -----------------
+// This adapter integrates with the Twilio REST API (https://api.twilio.com).
+//
+// Key Points:
+// - Authentication uses HTTP basic auth with the account SID and auth token (or an API key SID and secret).
+// - Twilio throttles by concurrency rather than by request windows: each account may only have a limited
+//   number of requests in flight, and overload is answered with 429 (error 20429) and Retry-After, which
+//   the SDK honors. The adapter caps its own in-flight requests at MaxConcurrency (100 by default) and
+//   makes further requests wait for a free slot instead of pushing the account into 429s.
+// - Endpoints are paths on api.twilio.com (AccountEndpoint builds the common /2010-04-01/Accounts/... ones).
+//   Full https URLs on a twilio.com host are also accepted, for the newer per-product APIs
+//   (messaging.twilio.com, ...) and their absolute pagination links.
+// - List endpoints paginate with "next_page_uri" (an absolute path) or, on the newer APIs, "meta.next_page_url"
+//   (a full URL); TwilioNextPage plugs both into sdk.Paginate.
+// - Write requests carrying a body default to application/x-www-form-urlencoded, as Twilio expects.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	TwilioDefaultMaxConcurrency = 100
+
+	twilioAPIBase    = "https://api.twilio.com"
+	twilioAPIVersion = "2010-04-01"
+)
+
+type TwilioAdapter struct {
+	AccountSID string
+	AuthToken  string
+
+	// MaxConcurrency caps this adapter's requests in flight; 0 means TwilioDefaultMaxConcurrency.
+	MaxConcurrency int
+
+	mu    sync.Mutex
+	slots chan struct{}
+}
+
+// NewTwilioAdapter creates a TwilioAdapter authenticating as accountSID with authToken.
+func NewTwilioAdapter(accountSID, authToken string) *TwilioAdapter {
+	return &TwilioAdapter{
+		AccountSID:     accountSID,
+		AuthToken:      authToken,
+		MaxConcurrency: TwilioDefaultMaxConcurrency,
+	}
+}
+
+// AccountEndpoint returns the endpoint of resource (e.g. "Messages") under the adapter's account:
+// "/2010-04-01/Accounts/<sid>/Messages.json".
+func (t *TwilioAdapter) AccountEndpoint(resource string) string {
+	return "/" + twilioAPIVersion + "/Accounts/" + url.PathEscape(t.AccountSID) + "/" + strings.TrimPrefix(resource, "/") + ".json"
+}
+
+// SetRateLimitDefaultsForType is a no-op: Twilio limits concurrency, not requests per window.
+func (t *TwilioAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+// IdentifyRequestType returns "rest" for all Twilio API requests.
+func (t *TwilioAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// Capabilities reports the optional features the Twilio adapter supports. Absolute URLs are accepted for Twilio hosts only.
+func (t *TwilioAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (t *TwilioAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return t.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig. It waits for a concurrency slot before sending.
+func (t *TwilioAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	httpReq, err := t.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := t.concurrencySlots()
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	client := &http.Client{}
+	return resilientbridge.DoRoundTrip(client, httpReq)
+}
+
+// ParseRateLimitInfo returns nil: Twilio doesn't send rate limit headers.
+func (t *TwilioAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (t *TwilioAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns GET on the adapter's account resource.
+func (t *TwilioAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/" + twilioAPIVersion + "/Accounts/" + url.PathEscape(t.AccountSID) + ".json"}
+}
+
+// concurrencySlots returns the semaphore bounding in-flight requests, sized from MaxConcurrency.
+func (t *TwilioAdapter) concurrencySlots() chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	max := t.MaxConcurrency
+	if max <= 0 {
+		max = TwilioDefaultMaxConcurrency
+	}
+	if t.slots == nil || cap(t.slots) != max {
+		t.slots = make(chan struct{}, max)
+	}
+	return t.slots
+}
+
+func (t *TwilioAdapter) newHTTPRequest(ctx context.Context, req *resilientbridge.NormalizedRequest) (*http.Request, error) {
+	fullURL := req.BaseURL(twilioAPIBase) + req.Endpoint
+	if isTwilioURL(req.Endpoint) {
+		fullURL = req.Endpoint
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if httpReq.Header.Get("Authorization") == "" && t.AccountSID != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "twilio.com", req.TrustedOverride()) {
+		httpReq.SetBasicAuth(t.AccountSID, t.AuthToken)
+	}
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	if len(req.Body) > 0 && httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return httpReq, nil
+}
+
+// isTwilioURL reports whether endpoint is a full https URL on a twilio.com host.
+func isTwilioURL(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	host := u.Hostname()
+	return host == "twilio.com" || strings.HasSuffix(host, ".twilio.com")
+}
+
+// twilioPage holds the pagination fields of Twilio list responses, in both API generations.
+type twilioPage struct {
+	NextPageURI string `json:"next_page_uri"`
+	Meta        struct {
+		NextPageURL string `json:"next_page_url"`
+	} `json:"meta"`
+}
+
+// TwilioNextPage is a resilientbridge.NextPageFunc for Twilio list endpoints. It follows
+// "next_page_uri" (2010-04-01 API) or "meta.next_page_url" (newer APIs), stopping when both are empty.
+func TwilioNextPage(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRequest, error) {
+	var page twilioPage
+	if err := json.Unmarshal(resp.Data, &page); err != nil {
+		return nil, err
+	}
+	switch {
+	case page.NextPageURI != "":
+		return resilientbridge.NextPageRequest(req, page.NextPageURI), nil
+	case page.Meta.NextPageURL != "":
+		return resilientbridge.NextPageRequest(req, page.Meta.NextPageURL), nil
+	}
+	return nil, nil
+}
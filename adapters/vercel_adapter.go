@@ -0,0 +1,225 @@
+// vercel_adapter.go
+// -----------------
+// This adapter integrates with the Vercel REST API (https://api.vercel.com).
+//
+// Key Points:
+// - Authentication uses "Authorization: Bearer <token>".
+// - If TeamID is set, "teamId=<id>" is appended to every request that doesn't already carry one,
+//   so the same adapter can target a team's resources.
+// - Vercel reports limits via X-RateLimit-Limit / X-RateLimit-Remaining / X-RateLimit-Reset (Unix seconds)
+//   and sends Retry-After on 429, which the SDK honors.
+// - Deployment creation (POST /vN/deployments) has much tighter limits than other endpoints, so it is
+//   tracked as its own "deployments" request type with a separate local window.
+
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+const (
+	VercelDefaultRestMaxRequests = 120
+	VercelDefaultRestWindowSecs  = 60 // 120/min
+
+	VercelDefaultDeploymentsMaxRequests = 100
+	VercelDefaultDeploymentsWindowSecs  = 3600 // 100/hour
+)
+
+var vercelDeploymentsCreatePattern = regexp.MustCompile(`^/v\d+/deployments/?(\?|$)`)
+
+type VercelAdapter struct {
+	APIToken string
+	TeamID   string
+
+	mu sync.Mutex
+	// Maps request type -> slice of timestamps
+	requestHistory map[string][]int64
+
+	// Maps request type -> (maxRequests, windowSecs)
+	limits map[string]struct {
+		maxReq     int
+		windowSecs int64
+	}
+}
+
+func NewVercelAdapter(apiToken string) *VercelAdapter {
+	return &VercelAdapter{
+		APIToken:       apiToken,
+		requestHistory: make(map[string][]int64),
+		limits: make(map[string]struct {
+			maxReq     int
+			windowSecs int64
+		}),
+	}
+}
+
+func (v *VercelAdapter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	defMax, defWindow := VercelDefaultRestMaxRequests, int64(VercelDefaultRestWindowSecs)
+	if requestType == "deployments" {
+		defMax, defWindow = VercelDefaultDeploymentsMaxRequests, VercelDefaultDeploymentsWindowSecs
+	}
+	if maxRequests == 0 {
+		maxRequests = defMax
+	}
+	if windowSecs == 0 {
+		windowSecs = defWindow
+	}
+	v.limits[requestType] = struct {
+		maxReq     int
+		windowSecs int64
+	}{maxRequests, windowSecs}
+}
+
+// IdentifyRequestType returns "deployments" for deployment creation and "rest" for everything else.
+func (v *VercelAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	if strings.ToUpper(req.Method) == "POST" && vercelDeploymentsCreatePattern.MatchString(req.Endpoint) {
+		return "deployments"
+	}
+	return "rest"
+}
+
+// Capabilities reports the optional features the Vercel adapter supports.
+func (v *VercelAdapter) Capabilities() resilientbridge.AdapterCapabilities {
+	return resilientbridge.AdapterCapabilities{SupportsAbsoluteURLs: true, SupportsHealthProbe: true}
+}
+
+func (v *VercelAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return v.ExecuteRequestWithContext(context.Background(), req)
+}
+
+// ExecuteRequestWithContext is ExecuteRequest with a caller-supplied context, which the SDK uses to
+// pass along the provider's ProviderConfig.
+func (v *VercelAdapter) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	requestType := v.IdentifyRequestType(req)
+	if !resilientbridge.RateLimitingDisabled(ctx) && v.isRateLimited(requestType) {
+		return &resilientbridge.NormalizedResponse{
+			StatusCode: 429,
+			Headers:    map[string]string{},
+			Data:       []byte(`{"error":"Vercel rate limit reached"}`),
+		}, nil
+	}
+
+	client := &http.Client{}
+	fullURL := v.withTeamID(req.URL("https://api.vercel.com"))
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, val := range req.Headers {
+		httpReq.Header.Set(k, val)
+	}
+	if httpReq.Header.Get("Authorization") == "" && v.APIToken != "" && resilientbridge.TrustedHost(httpReq.URL.String(), "api.vercel.com", req.TrustedOverride()) {
+		httpReq.Header.Set("Authorization", "Bearer "+v.APIToken)
+	}
+	if httpReq.Header.Get("Content-Type") == "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := resilientbridge.DoRoundTrip(client, httpReq)
+	if resp == nil {
+		return nil, err
+	}
+
+	v.recordRequest(requestType)
+
+	return resp, err
+}
+
+func (v *VercelAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	h := resp.Headers
+	parseInt := func(key string) *int {
+		if val, ok := h[key]; ok {
+			if i, err := strconv.Atoi(val); err == nil {
+				return resilientbridge.IntPtr(i)
+			}
+		}
+		return nil
+	}
+
+	parseReset := func(key string) *int64 {
+		if val, ok := h[key]; ok {
+			if ts, err := strconv.ParseInt(val, 10, 64); err == nil {
+				ms := ts * 1000
+				return &ms
+			}
+		}
+		return nil
+	}
+
+	info := &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       parseInt("x-ratelimit-limit"),
+		RemainingRequests: parseInt("x-ratelimit-remaining"),
+		ResetRequestsAt:   parseReset("x-ratelimit-reset"),
+	}
+	if info.MaxRequests == nil && info.RemainingRequests == nil && info.ResetRequestsAt == nil {
+		return nil, nil
+	}
+	return info, nil
+}
+
+func (v *VercelAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+// HealthProbe returns GET /v2/user, the authenticated user.
+func (v *VercelAdapter) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/v2/user"}
+}
+
+// withTeamID appends teamId to endpoint when TeamID is set and the endpoint doesn't specify one.
+func (v *VercelAdapter) withTeamID(endpoint string) string {
+	if v.TeamID == "" || strings.Contains(endpoint, "teamId=") {
+		return endpoint
+	}
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return endpoint + sep + "teamId=" + v.TeamID
+}
+
+func (v *VercelAdapter) isRateLimited(requestType string) bool {
+	v.mu.Lock()
+	limit, ok := v.limits[requestType]
+	v.mu.Unlock()
+	if !ok {
+		v.SetRateLimitDefaultsForType(requestType, 0, 0)
+		v.mu.Lock()
+		limit = v.limits[requestType]
+		v.mu.Unlock()
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now().Unix()
+	windowStart := now - limit.windowSecs
+	var newTimestamps []int64
+	for _, ts := range v.requestHistory[requestType] {
+		if ts >= windowStart {
+			newTimestamps = append(newTimestamps, ts)
+		}
+	}
+	v.requestHistory[requestType] = newTimestamps
+
+	return len(newTimestamps) >= limit.maxReq
+}
+
+func (v *VercelAdapter) recordRequest(requestType string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.requestHistory[requestType] = append(v.requestHistory[requestType], time.Now().Unix())
+}
@@ -0,0 +1,85 @@
+// capabilities.go
+// ---------------
+// This file defines AdapterCapabilities and the optional CapabilityReporter interface, through which an
+// adapter advertises which of the SDK's optional features it supports, and sdk.Capabilities, which
+// callers use to ask before relying on one.
+//
+// Capabilities tied to an interface (StreamingAdapter, HealthProber, RateLimitStateExporter) are detected
+// from the adapter's methods, so they are known even for adapters that do not implement
+// CapabilityReporter; a reporter can turn them off (e.g. a wrapper that embeds a streaming adapter but
+// must not stream), not on. The others can only be reported:
+//   - SupportsAbsoluteURLs: endpoints may be full URLs (next links, upload hosts), sent as is.
+//   - SupportsConditionalRequests: the adapter handles ETag / If-None-Match revalidation itself.
+//   - SupportsGraphQL: the provider has a GraphQL API the adapter rate limits as such.
+//
+// The SDK fails fast, with an error matching ErrUnsupportedCapability, when a request needs a capability
+// the adapter lacks: RequestStream against an adapter without streaming, and absolute endpoints sent to an
+// adapter that reports SupportsAbsoluteURLs as false (instead of appending the URL to its base URL).
+//
+// Third-party adapters should implement CapabilityReporter; without it, the reportable capabilities are
+// unknown and reported as false, and absolute endpoints are passed to the adapter unchecked.
+package resilientbridge
+
+import "fmt"
+
+// AdapterCapabilities lists the optional features an adapter supports.
+type AdapterCapabilities struct {
+	SupportsStreaming           bool // Implements StreamingAdapter (sdk.RequestStream)
+	SupportsConditionalRequests bool // Revalidates cached responses with If-None-Match
+	SupportsAbsoluteURLs        bool // Sends absolute http(s) endpoints as is
+	SupportsGraphQL             bool // Identifies and rate limits GraphQL requests
+	SupportsHealthProbe         bool // Implements HealthProber (sdk.HealthCheck)
+	SupportsStateExport         bool // Implements RateLimitStateExporter (sdk.ExportRateLimitState)
+}
+
+// CapabilityReporter is implemented by adapters that advertise their capabilities.
+type CapabilityReporter interface {
+	Capabilities() AdapterCapabilities
+}
+
+// CapabilitiesOf returns the capabilities of adapter: those it reports, with the interface-backed ones
+// limited to the interfaces it actually implements.
+func CapabilitiesOf(adapter ProviderAdapter) AdapterCapabilities {
+	_, streams := adapter.(StreamingAdapter)
+	_, probes := adapter.(HealthProber)
+	_, exports := adapter.(RateLimitStateExporter)
+
+	reporter, ok := adapter.(CapabilityReporter)
+	if !ok {
+		return AdapterCapabilities{SupportsStreaming: streams, SupportsHealthProbe: probes, SupportsStateExport: exports}
+	}
+	caps := reporter.Capabilities()
+	caps.SupportsStreaming = caps.SupportsStreaming && streams
+	caps.SupportsHealthProbe = caps.SupportsHealthProbe && probes
+	caps.SupportsStateExport = caps.SupportsStateExport && exports
+	return caps
+}
+
+// Capabilities returns the capabilities of the adapter registered as providerName.
+func (sdk *ResilientBridge) Capabilities(providerName string) (AdapterCapabilities, error) {
+	sdk.mu.Lock()
+	adapter, ok := sdk.providers[providerName]
+	sdk.mu.Unlock()
+	if !ok {
+		return AdapterCapabilities{}, fmt.Errorf("provider %q not registered", providerName)
+	}
+	return CapabilitiesOf(adapter), nil
+}
+
+// checkAbsoluteURL refuses absolute endpoints for adapters that report they cannot send them. It is part
+// of validateRequest.
+func checkAbsoluteURL(providerName string, adapter ProviderAdapter, req *NormalizedRequest) error {
+	if !isAbsoluteURL(req.Endpoint) {
+		return nil
+	}
+	if _, reports := adapter.(CapabilityReporter); !reports || CapabilitiesOf(adapter).SupportsAbsoluteURLs {
+		return nil
+	}
+	return &RequestError{
+		Provider: providerName,
+		Method:   req.Method,
+		Endpoint: req.Endpoint,
+		Reason:   "adapter does not accept absolute URLs",
+		Err:      ErrUnsupportedCapability,
+	}
+}
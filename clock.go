@@ -0,0 +1,146 @@
+// clock.go
+// --------
+// This file defines the Clock abstraction the SDK uses for reading the current time and waiting.
+// Routing waits through a Clock (instead of calling time.Sleep directly) lets the retry loop abort
+// promptly when a request's context is cancelled, and allows tests to substitute a controllable clock.
+//
+// ProviderConfig.Clock replaces the clock for one provider: the SDK's rate limit waits, backoff, retry
+// budget, token buckets, and EndpointLimits windows all read it, and adapters can reach it through
+// ClockFromContext. FakeClock is a Clock that only moves when told to, so code built on the SDK can test
+// its throttling without real sleeps. Give the mock adapter the same clock for its windows:
+//
+//	clock := resilientbridge.NewFakeClock(time.Now())
+//	sdk.RegisterProvider("mock", &mock.MockAdapter{Clock: clock}, &resilientbridge.ProviderConfig{
+//		MaxRequestsOverride: resilientbridge.IntPtr(2),
+//		Clock:               clock,
+//	})
+//	// ...use up the window, then start a request that must wait for it...
+//	clock.BlockUntil(1)              // the request is now waiting on the clock
+//	clock.Advance(61 * time.Second)  // past the window: the request proceeds at once
+//
+// Per-attempt RequestTimeouts are deliberately left on real time, since they guard real network calls.
+package resilientbridge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// sleepContext waits for d on clock, returning ctx.Err() early if ctx is cancelled first.
+func sleepContext(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	select {
+	case <-clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ClockFromContext returns the Clock of the ProviderConfig the SDK attached to ctx, or the real clock
+// when there is none. Adapters keeping local windows use it so a configured FakeClock drives them too.
+func ClockFromContext(ctx context.Context) Clock {
+	if config := ProviderConfigFromContext(ctx); config != nil && config.Clock != nil {
+		return config.Clock
+	}
+	return realClock{}
+}
+
+// clockFor returns config.Clock, or the SDK's clock when it is nil.
+func (sdk *ResilientBridge) clockFor(config *ProviderConfig) Clock {
+	if config != nil && config.Clock != nil {
+		return config.Clock
+	}
+	return sdk.clock
+}
+
+// FakeClock is a Clock whose time only moves with Advance. It is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFakeClock returns a FakeClock reading start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the time once the clock has been advanced by d. A d <= 0
+// fires immediately.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves the clock forward by d and fires every After whose time has come.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// Waiters returns the number of After calls still waiting for the clock to advance. Waiters abandoned
+// by a cancelled request are counted until the clock passes their time.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n After calls are waiting, i.e. until the code under test has
+// reached its waits, so the following Advance is not lost.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
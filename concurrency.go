@@ -0,0 +1,77 @@
+// concurrency.go
+// --------------
+// This file implements ProviderConfig.MaxConcurrency, a per-provider cap on requests in flight, and the
+// context-carried throttle notifications callers can use to explain stalls.
+//
+// Rate limits bound how many requests are sent per window, but not how many are sent at once; a tool that
+// fans out across many goroutines can still trip a provider's secondary (abuse) limits. With MaxConcurrency
+// set, every attempt made through the SDK holds one of the provider's slots while it is on the wire. Slots
+// are not held while the SDK waits for backoff or rate limit windows, so a throttled goroutine does not
+// block others from using the remaining quota.
+//
+// WithThrottleNotify attaches a callback to a context; the SDK calls it each time it delays a request made
+// with that context because of a local or provider rate limit, so long-running tools can log why they stalled.
+package resilientbridge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ThrottleFunc is called when the SDK delays a request; wait is how long it is about to sleep.
+type ThrottleFunc func(provider string, callType string, wait time.Duration)
+
+type throttleNotifyKey struct{}
+
+// WithThrottleNotify returns a copy of ctx whose requests report SDK-side rate limit waits to fn.
+func WithThrottleNotify(ctx context.Context, fn ThrottleFunc) context.Context {
+	return context.WithValue(ctx, throttleNotifyKey{}, fn)
+}
+
+// notifyThrottle calls the ThrottleFunc attached to ctx, if any.
+func notifyThrottle(ctx context.Context, provider string, callType string, wait time.Duration) {
+	if fn, _ := ctx.Value(throttleNotifyKey{}).(ThrottleFunc); fn != nil && wait > 0 {
+		fn(provider, callType, wait)
+	}
+}
+
+// slotRegistry holds the MaxConcurrency slots of every provider. It is shared by an SDK and its clones,
+// so requests made through any of them count against the same cap.
+type slotRegistry struct {
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newSlotRegistry() *slotRegistry {
+	return &slotRegistry{slots: make(map[string]chan struct{})}
+}
+
+// get returns the slots of providerName, (re)creating them when maxConcurrency changed.
+func (r *slotRegistry) get(providerName string, maxConcurrency int) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slots, ok := r.slots[providerName]
+	if !ok || cap(slots) != maxConcurrency {
+		slots = make(chan struct{}, maxConcurrency)
+		r.slots[providerName] = slots
+	}
+	return slots
+}
+
+// acquireSlot blocks until one of the provider's maxConcurrency slots is free, or ctx is done. It returns
+// a function that releases the slot. A maxConcurrency of zero or less means no limit.
+func (sdk *ResilientBridge) acquireSlot(ctx context.Context, providerName string, maxConcurrency int) (func(), error) {
+	if maxConcurrency <= 0 {
+		return func() {}, nil
+	}
+
+	slots := sdk.slots.get(providerName, maxConcurrency)
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -2,13 +2,84 @@
 // ----------
 // This file defines the ProviderConfig structure, which allows per-provider customization
 // of behavior such as using provider-defined limits or overrides, setting max retries,
-// and base backoff duration.
+// and base backoff duration. A zero BaseBackoff means DefaultBaseBackoff; use NoBackoff to retry
+// immediately. Backoff doubles per attempt up to MaxBackoff (DefaultMaxBackoff when zero), and each wait
+// is drawn at random from zero up to that value so concurrent callers spread their retries.
 //
 // Fields can override default rate limits (MaxRequestsOverride, WindowSecsOverride),
-// and also handle GraphQL-specific overrides if needed.
+// and also handle GraphQL-specific overrides if needed. MaxResponseBytes caps how much of a
+// response body the SDK will buffer.
+//
+// RateLimitAlgorithm selects between the default rolling-window behavior and token-bucket pacing.
+// With LimiterTokenBucket, TokenBucketRate (tokens/sec) and TokenBucketBurst set the bucket; if the rate
+// is zero it is derived from MaxRequestsOverride / WindowSecsOverride.
+//
+// MaxRetries bounds the number of retries and MaxRetryElapsed the total time they may take; whichever
+// is reached first ends the request, bounding an interactive caller's worst-case latency even when a
+// provider sends long Retry-After values.
+//
+// RequestTimeout bounds each attempt separately from the caller's context, so a hung connection fails
+// one attempt (retried as a network error) instead of the whole request (see request_timeout.go).
+//
+// RetryPolicy gates retries of 5xx and network failures by request; the default never resends a POST or
+// PATCH unless it is marked idempotent (see retry_policy.go).
+//
+// Retry bundles all of the above in a RetryConfig; when set, the individual fields are ignored (see
+// retry_config.go and DefaultRetryConfig).
+//
+// RetryableErrorClassifier overrides the status code handling per attempt: it can retry, fail, or accept
+// any response or network error the adapter did not already classify as terminal or rate limited (see
+// retry_classifier.go for the precedence).
+//
+// RetryOnDecodeError retries 2xx responses with an empty or truncated body, for requests sent with
+// sdk.RequestJSON (see decode_retry.go).
+//
+// ResponseValidator applies success criteria of the caller's to 2xx responses, for APIs answering 200 with
+// an error payload (see response_validator.go).
+//
+// DefaultHeaders are merged into every request, with the request's own headers taking precedence. Since
+// adapters only add their credentials when no Authorization header is present, the order is: request
+// headers, then DefaultHeaders, then the adapter's token.
+//
+// UserAgent identifies the client to the provider; requests without a User-Agent header (after
+// DefaultHeaders) get it, or DefaultUserAgent ("resilient-bridge/<Version>") when empty.
+//
+// RequestSigner signs every request after the adapter has built it, for schemes such as AWS SigV4 or
+// EdgeGrid (see signer.go for how it orders with request-level Authorization headers).
+//
+// HTTPClient replaces the client adapters send requests with, e.g. for a proxy or custom TLS settings.
+// When nil, requests share DefaultTransport's connection pool (see transport.go).
+//
+// WindowMode selects whether EndpointLimits use sliding windows or fixed windows aligned to the provider's
+// reported reset time (see window_mode.go).
+//
+// MaxConcurrency caps how many requests to the provider may be on the wire at once (see concurrency.go).
+//
+// Clock replaces the wall clock for the provider's rate limiting, backoff, and waits, e.g. with a
+// FakeClock in tests (see clock.go).
+//
+// The optional On* callbacks let callers log or react to the request lifecycle without implementing
+// a full interface. They are invoked synchronously from the calling goroutine and must be safe for
+// concurrent use when the SDK is shared across goroutines. Attempts are numbered from 1. The request
+// passed to them carries its Tags, so callbacks can attribute metrics to an operation or tenant.
 package resilientbridge
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultBaseBackoff is the exponential backoff base used when BaseBackoff is left at zero.
+	DefaultBaseBackoff = time.Second
+
+	// DefaultMaxBackoff caps exponential backoff when MaxBackoff is left at zero.
+	DefaultMaxBackoff = 30 * time.Second
+
+	// NoBackoff disables exponential backoff between retries when set as BaseBackoff.
+	// Retry-After values sent by the provider are still honored.
+	NoBackoff time.Duration = -1
+)
 
 // ProviderConfig allows per-provider customization of rate limits, retries, and other settings.
 type ProviderConfig struct {
@@ -21,5 +92,47 @@ type ProviderConfig struct {
 
 	MaxTokensOverride *int          // If token-based rate limits apply
 	MaxRetries        int           // Max number of retries on failure
-	BaseBackoff       time.Duration // Initial backoff duration for exponential backoff
+	BaseBackoff       time.Duration // Initial backoff duration for exponential backoff; 0 = DefaultBaseBackoff
+	MaxBackoff        time.Duration // Ceiling on a single exponential backoff wait; 0 = DefaultMaxBackoff
+	MaxRetryAfter     time.Duration // Cap on waits requested via Retry-After; 0 means no cap
+	MaxRetryElapsed   time.Duration // Give up once the next retry would end this long after the first attempt; 0 means no budget
+	RequestTimeout    time.Duration // Deadline for each individual attempt, retried on expiry; 0 means none
+	RetryPolicy       RetryPolicy   // Which failed requests may be retried after a 5xx or network error; nil = DefaultRetryPolicy
+	Retry             *RetryConfig  // All retry settings at once; when set, the seven fields above are ignored
+
+	RetryableErrorClassifier RetryableErrorClassifier // Decides retry, fail, or success per attempt after rate limits; nil = status codes only
+	RetryOnDecodeError       bool                     // Retry 2xx responses to RequestJSON whose body is empty or not valid JSON
+	ResponseValidator        ResponseValidator        // Accepts, retries, or fails each buffered 2xx response by its body (not RequestStream); nil = status codes only
+
+	MaxResponseBytes int64 // Max response body size in bytes; 0 means unlimited
+
+	RateLimitAlgorithm LimiterAlgorithm // LimiterWindow (default) or LimiterTokenBucket
+	TokenBucketRate    float64          // Refill rate in requests per second for LimiterTokenBucket
+	TokenBucketBurst   int              // Bucket capacity for LimiterTokenBucket; defaults to 1
+
+	RateLimitBehavior RateLimitBehavior // RateLimitBlock (default), RateLimitFailFast, or RateLimitBlockWithTimeout(d)
+
+	// DisableRateLimiting skips all client-side throttling, in the SDK and in the adapter (no synthetic
+	// 429s), for tests or providers fronted by a proxy that limits on its own. Real 429s are still parsed
+	// and retried.
+	DisableRateLimiting bool
+
+	EndpointLimits []EndpointLimit // Extra rolling-window limits for matching endpoints; first match wins
+	WindowMode     WindowMode      // How EndpointLimits windows advance: WindowSliding (default) or WindowFixedReset
+
+	MaxConcurrency int // Max requests in flight to this provider; 0 means unlimited
+
+	Clock Clock // Time source for rate limiting, backoff, and waits; nil = the real clock
+
+	DefaultHeaders map[string]string // Sent with every request unless the request sets the same header
+	UserAgent      string            // User-Agent for requests that set none; "" = DefaultUserAgent
+	DebugDump      bool              // Print each request and response, with secrets masked (also enabled by RESILIENTBRIDGE_DEBUG=1)
+	HTTPClient     *http.Client      // Sends this provider's requests instead of the adapter's client; nil = adapter client over DefaultTransport
+	RequestSigner  RequestSigner     // Authenticates each request just before it is sent, after the adapter's credentials; nil = none
+
+	OnRequest     func(req *NormalizedRequest)                                                            // Before each attempt is sent
+	OnResponse    func(req *NormalizedRequest, resp *NormalizedResponse, attempt int)                     // After each attempt that returned a response
+	OnRetry       func(req *NormalizedRequest, resp *NormalizedResponse, attempt int, wait time.Duration) // Before waiting to retry; resp is nil on network errors
+	OnRateLimited func(provider string, resetAt *int64)                                                   // On a 429 (real or synthetic) or a preemptive wait; resetAt is Unix ms if known
+	OnDeprecation func(provider, endpoint, message string, sunset *time.Time)                             // Once per endpoint the adapter reports as deprecated; sunset is nil if not announced
 }
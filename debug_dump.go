@@ -0,0 +1,168 @@
+// debug_dump.go
+// -------------
+// This file implements the request dump mode enabled by ProviderConfig.DebugDump or by setting the
+// environment variable RESILIENTBRIDGE_DEBUG=1. The shared round-trip helpers then print every outgoing
+// request (method, URL, headers, start of the body) and its response (status, headers, start of the
+// body), which shows exactly what a provider rejected when an error body alone isn't enough.
+//
+// Dumps are meant to be pasted into bug reports, so secrets are masked before printing:
+//   - credential headers (Authorization, Cookie, X-Api-Key, and any header whose name mentions a token,
+//     secret, key, password, or signature) keep only their scheme, e.g. "Bearer [REDACTED]";
+//   - URL user info and secret-looking query parameters (token, access_token, sig, ...) are masked;
+//   - bodies are cut to DebugDumpBodyBytes, and bearer/basic credentials, well-known token formats, and
+//     JSON fields such as "password" or "client_secret" are masked.
+package resilientbridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DebugDumpBodyBytes is how much of a request or response body a dump shows.
+const DebugDumpBodyBytes = 2048
+
+const redacted = "[REDACTED]"
+
+// envDebugDump enables dumps for every provider when RESILIENTBRIDGE_DEBUG=1.
+var envDebugDump = os.Getenv("RESILIENTBRIDGE_DEBUG") == "1"
+
+var (
+	// secretNameRe matches header and query parameter names whose values are credentials.
+	secretNameRe = regexp.MustCompile(`(?i)(auth|token|secret|password|passwd|api[-_]?key|^key$|signature|^sig$|cookie|credential)`)
+
+	// secretTextRes mask credentials inside free text (bodies).
+	secretTextRes = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`(?i)\b(bearer|basic|token)\s+[A-Za-z0-9._~+/=-]{8,}`), "$1 " + redacted},
+		{regexp.MustCompile(`\b(gh[pousr]_|github_pat_|xox[abposr]-|sk-|glpat-)[A-Za-z0-9_-]{8,}`), redacted},
+		{regexp.MustCompile(`(?i)("[^"]*(token|secret|password|passwd|api_?key|credential)[^"]*"\s*:\s*)"[^"]*"`), `$1"` + redacted + `"`},
+	}
+)
+
+// dumpEnabled reports whether req's provider (carried by its context) should be dumped.
+func dumpEnabled(httpReq *http.Request) bool {
+	if envDebugDump {
+		return true
+	}
+	config := ProviderConfigFromContext(httpReq.Context())
+	return config != nil && config.DebugDump
+}
+
+// dumpRequest prints the request line, headers, and the start of the body of httpReq.
+func dumpRequest(httpReq *http.Request) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[DEBUG] > %s %s\n", httpReq.Method, redactURL(httpReq.URL))
+	writeHeaders(&b, "[DEBUG] > ", httpReq.Header)
+	if httpReq.GetBody != nil {
+		if body, err := httpReq.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, DebugDumpBodyBytes+1))
+			body.Close()
+			writeBody(&b, "[DEBUG] > ", data)
+		}
+	}
+	fmt.Print(b.String())
+}
+
+// dumpResponse prints the status, headers, and the start of the body of a response to httpReq. data is
+// nil for streamed responses, whose body is left for the caller.
+func dumpResponse(httpReq *http.Request, resp *http.Response, data []byte, err error) {
+	var b strings.Builder
+	if resp == nil {
+		// *url.Error repeats the raw URL, so print only the underlying cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		fmt.Fprintf(&b, "[DEBUG] < %s %s failed: %v\n", httpReq.Method, redactURL(httpReq.URL), err)
+		fmt.Print(b.String())
+		return
+	}
+	fmt.Fprintf(&b, "[DEBUG] < %s (%s %s)\n", resp.Status, httpReq.Method, redactURL(httpReq.URL))
+	writeHeaders(&b, "[DEBUG] < ", resp.Header)
+	switch {
+	case data != nil:
+		writeBody(&b, "[DEBUG] < ", data)
+	case err != nil:
+		fmt.Fprintf(&b, "[DEBUG] < (body not read: %v)\n", err)
+	default:
+		fmt.Fprintf(&b, "[DEBUG] < (streamed body not shown)\n")
+	}
+	fmt.Print(b.String())
+}
+
+func writeHeaders(b *strings.Builder, prefix string, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range h[name] {
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, name, redactHeader(name, v))
+		}
+	}
+}
+
+func writeBody(b *strings.Builder, prefix string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	truncated := len(data) > DebugDumpBodyBytes
+	if truncated {
+		data = data[:DebugDumpBodyBytes]
+	}
+	fmt.Fprintf(b, "%s\n%s\n", prefix, redactText(string(bytes.TrimRight(data, "\n"))))
+	if truncated {
+		fmt.Fprintf(b, "%s(body truncated to %d bytes)\n", prefix, DebugDumpBodyBytes)
+	}
+}
+
+// redactHeader masks the value of credential headers, keeping an authentication scheme if present.
+func redactHeader(name, value string) string {
+	if !secretNameRe.MatchString(name) {
+		return redactText(value)
+	}
+	if scheme, _, ok := strings.Cut(value, " "); ok && len(scheme) < 16 {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// redactURL returns u with its user info password and secret-looking query parameters masked.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	masked := *u
+	if masked.User != nil {
+		masked.User = url.UserPassword(masked.User.Username(), "REDACTED")
+	}
+	if masked.RawQuery != "" {
+		q := masked.Query()
+		for key := range q {
+			if secretNameRe.MatchString(key) {
+				q.Set(key, "REDACTED")
+			}
+		}
+		masked.RawQuery = q.Encode()
+	}
+	return masked.String()
+}
+
+// redactText masks credentials embedded in free text.
+func redactText(s string) string {
+	for _, r := range secretTextRes {
+		s = r.re.ReplaceAllString(s, r.repl)
+	}
+	return s
+}
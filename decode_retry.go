@@ -0,0 +1,49 @@
+// decode_retry.go
+// ---------------
+// This file implements ProviderConfig.RetryOnDecodeError. Providers occasionally answer a 2xx with an
+// empty or truncated body (a proxy hiccup, a connection reset mid-stream), which would otherwise surface
+// as a confusing JSON syntax error from the caller's decode step.
+//
+// Only requests sent through sdk.RequestJSON are checked, since the raw Request cannot know whether the
+// caller expects JSON. Those requests mark their context with withDecodeRetry, and the executor treats a
+// 2xx whose body is not valid JSON (or is empty, on a 200) as a failed attempt: it is retried with the
+// usual backoff, subject to MaxRetries, MaxRetryElapsed, and RetryPolicy (the provider may have acted on
+// the request already). Once retries run out, the error wraps ErrMalformedBody. Decoding errors that a
+// retry cannot fix, such as a valid body of the wrong shape, are returned by RequestJSON as before.
+package resilientbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type decodeRetryKey struct{}
+
+// withDecodeRetry marks ctx so the executor retries attempts whose body is not valid JSON.
+func withDecodeRetry(ctx context.Context, config *ProviderConfig) context.Context {
+	if config == nil || !config.RetryOnDecodeError {
+		return ctx
+	}
+	return context.WithValue(ctx, decodeRetryKey{}, true)
+}
+
+// malformedBody returns an error wrapping ErrMalformedBody when ctx was marked by withDecodeRetry and
+// resp is a 2xx whose body cannot be decoded, and nil otherwise.
+func malformedBody(ctx context.Context, resp *NormalizedResponse) error {
+	if retry, _ := ctx.Value(decodeRetryKey{}).(bool); !retry || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil
+	}
+	body := bytes.TrimSpace(resp.Data)
+	if len(body) == 0 {
+		if resp.StatusCode != http.StatusOK {
+			return nil // 201, 202, and 204 may legitimately come without a body
+		}
+		return resp.decodeError(ErrMalformedBody)
+	}
+	if !json.Valid(body) {
+		return resp.decodeError(ErrMalformedBody)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// deprecation.go
+// --------------
+// This file surfaces provider deprecation notices. Adapters implementing DeprecationDetector recognize
+// their provider's signals (for GitHub: the Deprecation, Sunset, and Warning headers); the SDK attaches
+// the notice to NormalizedResponse.Deprecation and calls ProviderConfig.OnDeprecation, so long-lived
+// integrations notice an endpoint is going away before it breaks.
+//
+// OnDeprecation (and the debug log line) fire at most once per provider, method, and endpoint path
+// (query strings are ignored), however often the endpoint is called.
+package resilientbridge
+
+import (
+	"strings"
+	"time"
+)
+
+// Deprecation describes a deprecation notice attached to a response.
+type Deprecation struct {
+	Message string     // Human-readable notice, e.g. the text of a Warning header
+	Sunset  *time.Time // When the endpoint stops working, if announced
+	Link    string     // Documentation about the deprecation, if provided
+}
+
+// checkDeprecation asks the adapter whether resp carries a deprecation notice, attaches it to resp,
+// and reports it the first time it is seen for the request's endpoint.
+func (sdk *ResilientBridge) checkDeprecation(config *ProviderConfig, providerName string, req *NormalizedRequest, resp *NormalizedResponse, adapter ProviderAdapter) {
+	detector, ok := adapter.(DeprecationDetector)
+	if !ok || req == nil {
+		return
+	}
+	notice := detector.DeprecationNotice(resp)
+	if notice == nil {
+		return
+	}
+	resp.Deprecation = notice
+
+	endpoint, _, _ := strings.Cut(req.Endpoint, "?")
+	method := strings.ToUpper(req.Method)
+	key := providerName + " " + method + " " + endpoint
+	sdk.mu.Lock()
+	seen := sdk.deprecated[key]
+	sdk.deprecated[key] = true
+	sdk.mu.Unlock()
+	if seen {
+		return
+	}
+
+	sdk.debugf("Provider %s: %s %s is deprecated: %s\n", providerName, method, endpoint, notice.Message)
+	if config.OnDeprecation != nil {
+		config.OnDeprecation(providerName, endpoint, notice.Message, notice.Sunset)
+	}
+}
@@ -0,0 +1,98 @@
+// do_http.go
+// ----------
+// This file implements sdk.DoHTTP, which sends a standard *http.Request through a provider's rate
+// limiting, retries, and backoff and returns a standard *http.Response, so existing net/http code can
+// gain resilience by replacing client.Do(req) with sdk.DoHTTP("github", req).
+//
+// The request is converted to a NormalizedRequest: its URL becomes an absolute endpoint (adapters that
+// cannot send absolute URLs fail with ErrUnsupportedCapability; a URL without a host is sent as a path on
+// the adapter's base URL), its headers are passed on with multiple values joined by commas, and its
+// context is honored as by RequestWithContext. The adapter still adds its own credentials.
+//
+// Both bodies are buffered:
+//   - The request body is read into memory up front and closed, since a retry must send it again and
+//     an io.Reader can only be read once. Very large uploads should not go through DoHTTP.
+//   - The response body is the buffered NormalizedResponse.Data (subject to MaxResponseBytes), wrapped
+//     in a reader; use RequestStream for downloads that should not be held in memory.
+//
+// Response headers are those of NormalizedResponse: each name keeps its first value, except Link.
+//
+// As with http.Client.Do, error statuses are not errors: a 4xx, or a 5xx once retries are exhausted, is
+// returned as a response. Failures the SDK reports itself (a rate limit it gave up waiting for,
+// ErrUnauthorized, a RetryBudgetError, network errors) come back as errors without a response.
+package resilientbridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DoHTTP sends r to providerName through the SDK and returns the provider's response as an *http.Response
+// whose body is already buffered. It closes r.Body. See do_http.go for how requests and responses are
+// converted.
+func (sdk *ResilientBridge) DoHTTP(providerName string, r *http.Request) (*http.Response, error) {
+	req, err := normalizeHTTPRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sdk.RequestWithContext(r.Context(), providerName, req)
+	if err != nil {
+		var httpErr *HTTPError
+		if resp == nil || !errors.As(err, &httpErr) {
+			return nil, err
+		}
+	}
+	return resp.httpResponse(r), nil
+}
+
+// normalizeHTTPRequest converts r into a NormalizedRequest, reading and closing its body.
+func normalizeHTTPRequest(r *http.Request) (*NormalizedRequest, error) {
+	if r.URL == nil {
+		return nil, errors.New("http request has no URL")
+	}
+	req := &NormalizedRequest{Method: r.Method, Endpoint: r.URL.String()}
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+	if r.URL.Host == "" {
+		req.Endpoint = r.URL.RequestURI()
+	}
+	if len(r.Header) > 0 {
+		req.Headers = make(map[string]string, len(r.Header))
+		for name, values := range r.Header {
+			req.Headers[name] = strings.Join(values, ", ")
+		}
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		req.Body = body
+	}
+	return req, nil
+}
+
+// httpResponse converts r into an *http.Response answering req.
+func (r *NormalizedResponse) httpResponse(req *http.Request) *http.Response {
+	header := make(http.Header, len(r.Headers))
+	for name, value := range r.Headers {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(r.Data)),
+		ContentLength: int64(len(r.Data)),
+		Request:       req,
+	}
+}
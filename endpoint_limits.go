@@ -0,0 +1,139 @@
+// endpoint_limits.go
+// ------------------
+// This file implements ProviderConfig.EndpointLimits: user-defined rolling-window limits for endpoints
+// whose provider-side caps are stricter than the blanket per-call-type limits (e.g., GitHub's /search or
+// the Actions API). It generalizes the per-category windows some adapters (Render, Linode) hard-code so
+// any provider can carve out custom buckets through configuration alone.
+//
+// Limits are matched against NormalizedRequest.Endpoint (and Method, if the limit sets one) in order, and
+// the first match wins. Each matching EndpointLimit gets its own window per provider, consulted by the SDK
+// before the request reaches the adapter; requests that match no limit are only subject to the adapter's
+// own limits. An attempt takes its slot before the SDK's other rate limit checks and gives it back if one
+// of them ends the attempt unsent (an empty token bucket, a known rate limit, a cancelled context). Windows are keyed by the limit's method and pattern rather than its position in the list,
+// so reordering or inserting limits, or importing a snapshot exported under a different list, keeps each
+// window with its rule.
+//
+// ProviderConfig.WindowMode selects between sliding windows (default) and fixed windows aligned to the
+// provider's reported reset time (see window_mode.go).
+package resilientbridge
+
+import (
+	"regexp"
+	"time"
+)
+
+// EndpointLimit caps requests to endpoints matching Pattern at Max per WindowSecs seconds.
+type EndpointLimit struct {
+	Pattern    *regexp.Regexp
+	Method     string // Only requests with this method count against the limit; "" matches every method
+	Max        int
+	WindowSecs int64
+}
+
+// key names the window of limit for provider; limits with the same method and pattern share a window.
+func (limit EndpointLimit) key(provider string) string {
+	method := "*"
+	if limit.Method != "" {
+		method = NormalizeMethod(limit.Method)
+	}
+	return provider + ":endpoint:" + method + " " + limit.Pattern.String()
+}
+
+// endpointWindow holds the send times of recent requests for one EndpointLimit.
+type endpointWindow struct {
+	times []time.Time
+}
+
+// matchEndpointLimit returns the index of the first limit matching method and endpoint, or -1.
+func matchEndpointLimit(limits []EndpointLimit, method, endpoint string) int {
+	for i, limit := range limits {
+		if limit.Method != "" && NormalizeMethod(limit.Method) != NormalizeMethod(method) {
+			continue
+		}
+		if limit.Pattern != nil && limit.Max > 0 && limit.WindowSecs > 0 && limit.Pattern.MatchString(endpoint) {
+			return i
+		}
+	}
+	return -1
+}
+
+// reserveEndpoint records a method request to endpoint at now if the matching EndpointLimit has room
+// and returns 0. Otherwise nothing is recorded and it returns how long until a slot frees up. With
+// WindowFixedReset, windows are aligned to the reset time reported for provider and callType.
+func (r *RateLimiter) reserveEndpoint(provider string, callType string, method string, endpoint string, limits []EndpointLimit, mode WindowMode, now time.Time) time.Duration {
+	idx := matchEndpointLimit(limits, method, endpoint)
+	if idx < 0 {
+		return 0
+	}
+	limit := limits[idx]
+	window := time.Duration(limit.WindowSecs) * time.Second
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := limit.key(provider)
+	w, ok := r.endpointWindows[key]
+	if !ok {
+		w = &endpointWindow{}
+		r.endpointWindows[key] = w
+	}
+
+	if mode == WindowFixedReset {
+		var resetAt *int64
+		if info := r.providerLimits[provider+":"+callType]; info != nil {
+			resetAt = info.ResetRequestsAt
+		}
+		start := fixedWindowStart(now, window, resetAt)
+		kept := w.times[:0]
+		for _, t := range w.times {
+			if !t.Before(start) {
+				kept = append(kept, t)
+			}
+		}
+		w.times = kept
+
+		if len(w.times) < limit.Max {
+			w.times = append(w.times, now)
+			return 0
+		}
+		return start.Add(window).Sub(now)
+	}
+
+	cutoff := now.Add(-window)
+	kept := w.times[:0]
+	for _, t := range w.times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	w.times = kept
+
+	if len(w.times) < limit.Max {
+		w.times = append(w.times, now)
+		return 0
+	}
+	return w.times[0].Add(window).Sub(now)
+}
+
+// releaseEndpoint removes the request reserveEndpoint recorded at at, for attempts that end before they
+// are sent (an empty token bucket, a known rate limit, a cancelled context).
+func (r *RateLimiter) releaseEndpoint(provider string, method string, endpoint string, limits []EndpointLimit, at time.Time) {
+	idx := matchEndpointLimit(limits, method, endpoint)
+	if idx < 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.endpointWindows[limits[idx].key(provider)]
+	if !ok {
+		return
+	}
+	for i := len(w.times) - 1; i >= 0; i-- {
+		if w.times[i].Equal(at) {
+			w.times = append(w.times[:i], w.times[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// errors.go
+// ---------
+// This file defines the error values the SDK returns for failures that callers may want to detect
+// with errors.Is / errors.As, independently of which provider produced them.
+package resilientbridge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// ErrResponseTooLarge is returned when a response body exceeds ProviderConfig.MaxResponseBytes.
+	ErrResponseTooLarge = errors.New("response body exceeds configured MaxResponseBytes")
+
+	// ErrUnauthorized is returned when a provider rejects the request's credentials
+	// (missing, revoked, or expired token). Such requests are never retried.
+	ErrUnauthorized = errors.New("unauthorized: provider rejected the credentials")
+
+	// ErrRetryBudgetExceeded matches a *RetryBudgetError: the request gave up because its next retry
+	// would have exceeded ProviderConfig.MaxRetryElapsed.
+	ErrRetryBudgetExceeded = errors.New("retry budget exceeded")
+
+	// ErrRequestTimeout is returned when an attempt exceeds ProviderConfig.RequestTimeout. Such attempts
+	// are retried like network errors; it is returned once retries run out.
+	ErrRequestTimeout = errors.New("request attempt timed out")
+
+	// ErrNoHealthProbe is reported by sdk.HealthCheck for providers whose adapter does not implement HealthProber.
+	ErrNoHealthProbe = errors.New("adapter does not implement a health probe")
+
+	// ErrUnsupportedCapability is returned when a request needs a capability the provider's adapter does
+	// not have (see AdapterCapabilities), such as RequestStream against an adapter that cannot stream.
+	ErrUnsupportedCapability = errors.New("capability not supported by the adapter")
+
+	// ErrMalformedBody is returned by sdk.RequestJSON with ProviderConfig.RetryOnDecodeError when a 2xx
+	// response body is still empty or not valid JSON once retries run out.
+	ErrMalformedBody = errors.New("empty or malformed JSON response body")
+
+	// ErrUnsuccessfulResponse is wrapped by the errors of the built-in ResponseValidators: the provider
+	// answered 2xx but reported a failure in the body.
+	ErrUnsuccessfulResponse = errors.New("provider reported failure in a successful response")
+
+	// ErrInvalidRequest matches a *RequestError: the request was malformed and was not sent.
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+// HTTPError is returned when a provider answers with an error status (>= 400) that the SDK does not
+// retry, or that persists once retries are exhausted. Body keeps the raw response payload.
+type HTTPError struct {
+	Provider   string
+	StatusCode int
+	Body       []byte
+
+	// message is the adapter-extracted message, if the adapter implements ErrorMessageExtractor.
+	message string
+}
+
+func (e *HTTPError) Error() string {
+	if msg := e.Message(); msg != "" {
+		return fmt.Sprintf("%s: HTTP %d: %s", e.Provider, e.StatusCode, msg)
+	}
+	return fmt.Sprintf("%s: HTTP %d", e.Provider, e.StatusCode)
+}
+
+// Message returns a human-readable message extracted from the error body. Adapter-specific
+// extraction takes precedence; otherwise the common JSON error shapes are recognized
+// (see ExtractErrorMessage).
+func (e *HTTPError) Message() string {
+	if e.message != "" {
+		return e.message
+	}
+	return ExtractErrorMessage(e.Body)
+}
+
+// RateLimitError is returned when a provider is rate limited and the SDK will not wait any longer,
+// either because of ProviderConfig.RateLimitBehavior or because MaxRetries was reached.
+type RateLimitError struct {
+	Provider string
+
+	// RetryAfter is how long the caller should wait before trying again, and ResetAt the corresponding
+	// point in time. Both are zero when the provider gave no indication.
+	RetryAfter time.Duration
+	ResetAt    time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s: rate limit exceeded, retry in %v", e.Provider, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s: rate limit exceeded", e.Provider)
+}
+
+// RetryBudgetError is returned when a request stops retrying because its next attempt would end past
+// ProviderConfig.MaxRetryElapsed. Err is the last failure (a *RateLimitError, an *HTTPError, or a network
+// error), so errors.As still finds it; errors.Is(err, ErrRetryBudgetExceeded) matches the budget itself.
+type RetryBudgetError struct {
+	Provider string
+	Attempts int           // Attempts made before giving up
+	Elapsed  time.Duration // Time since the first attempt
+	Err      error
+}
+
+func (e *RetryBudgetError) Error() string {
+	return fmt.Sprintf("%s: gave up after %d attempts in %v (retry budget exceeded): %v", e.Provider, e.Attempts, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+func (e *RetryBudgetError) Unwrap() error { return e.Err }
+
+func (e *RetryBudgetError) Is(target error) bool { return target == ErrRetryBudgetExceeded }
+
+// RequestError is returned, before anything is sent, for a NormalizedRequest that cannot be sent as is:
+// an invalid method or endpoint (see request_validation.go). Err is the underlying sentinel, if any, such
+// as ErrUnsupportedCapability.
+type RequestError struct {
+	Provider string
+	Method   string
+	Endpoint string
+	Reason   string // What is wrong, e.g. "method is empty"
+	Err      error
+}
+
+func (e *RequestError) Error() string {
+	msg := fmt.Sprintf("%s: invalid request (method %q, endpoint %q): %s", e.Provider, e.Method, e.Endpoint, e.Reason)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *RequestError) Unwrap() error { return e.Err }
+
+func (e *RequestError) Is(target error) bool { return target == ErrInvalidRequest }
+
+// newRateLimitError builds a RateLimitError for a limit expected to reset after wait (0 if unknown).
+func newRateLimitError(provider string, now time.Time, wait time.Duration) *RateLimitError {
+	rlErr := &RateLimitError{Provider: provider}
+	if wait > 0 {
+		rlErr.RetryAfter = wait
+		rlErr.ResetAt = now.Add(wait)
+	}
+	return rlErr
+}
+
+// newHTTPError builds an HTTPError for resp, using the adapter's ErrorMessageExtractor when available.
+func newHTTPError(provider string, resp *NormalizedResponse, adapter ProviderAdapter) *HTTPError {
+	httpErr := &HTTPError{Provider: provider, StatusCode: resp.StatusCode, Body: resp.Data}
+	if extractor, ok := adapter.(ErrorMessageExtractor); ok {
+		httpErr.message = extractor.ExtractErrorMessage(resp)
+	}
+	return httpErr
+}
+
+// maxErrorPreview bounds how much of a non-JSON body ExtractErrorMessage returns, and how much of the
+// body a NormalizedResponse.JSON error quotes.
+const maxErrorPreview = 200
+
+// previewText returns s cut to at most maxErrorPreview bytes, followed by "..." when cut. The cut backs up
+// to the start of a rune, so a multi-byte character is never split.
+func previewText(s string) string {
+	if len(s) <= maxErrorPreview {
+		return s
+	}
+	n := maxErrorPreview
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+// ExtractErrorMessage pulls a readable message out of common JSON error bodies:
+//
+//	{"message": "..."}                       (GitHub, many REST APIs)
+//	{"error": "..."} / {"error": {"message": "..."}}
+//	{"errors": [{"message": "..."}]}          (Cloudflare, GraphQL)
+//	{"errors": [{"reason": "..."}]}           (Linode)
+//	{"errors": ["..."]}
+//	{"detail": "..."}                         (RFC 7807 problem details)
+//
+// Non-JSON bodies are returned trimmed and truncated. An empty body yields "".
+func ExtractErrorMessage(data []byte) string {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return ""
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return previewText(trimmed)
+	}
+
+	var parts []string
+	if msg := jsonMessage(body["message"]); msg != "" {
+		parts = append(parts, msg)
+	}
+	if msg := jsonMessage(body["error"]); msg != "" {
+		parts = append(parts, msg)
+	}
+	var errs []json.RawMessage
+	if err := json.Unmarshal(body["errors"], &errs); err == nil {
+		for _, e := range errs {
+			if msg := jsonMessage(e); msg != "" {
+				parts = append(parts, msg)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		if msg := jsonMessage(body["detail"]); msg != "" {
+			parts = append(parts, msg)
+		}
+	}
+	if len(parts) == 0 {
+		return trimmed
+	}
+	return strings.Join(parts, "; ")
+}
+
+// jsonMessage returns raw as a string if it is a JSON string, or the "message"/"reason"/"detail"
+// field if it is an object.
+func jsonMessage(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str
+	}
+	var obj struct {
+		Message string `json:"message"`
+		Reason  string `json:"reason"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		switch {
+		case obj.Message != "":
+			return obj.Message
+		case obj.Reason != "":
+			return obj.Reason
+		case obj.Detail != "":
+			return obj.Detail
+		}
+	}
+	return ""
+}
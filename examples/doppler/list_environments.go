@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -45,18 +44,9 @@ func main() {
 		Headers:  map[string]string{"accept": "application/json"},
 	}
 
-	resp, err := sdk.Request("doppler", req)
-	if err != nil {
-		log.Fatalf("Error listing environments: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		log.Fatalf("Error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
 	var envResp DopplerEnvironmentsResponse
-	if err := json.Unmarshal(resp.Data, &envResp); err != nil {
-		log.Fatalf("Error parsing environments response: %v", err)
+	if _, err := sdk.RequestJSON("doppler", req, &envResp); err != nil {
+		log.Fatalf("Error listing environments: %v", err)
 	}
 
 	fmt.Println("Environments:")
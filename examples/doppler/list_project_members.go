@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -49,18 +48,9 @@ func main() {
 		Headers:  map[string]string{"accept": "application/json"},
 	}
 
-	resp, err := sdk.Request("doppler", req)
-	if err != nil {
-		log.Fatalf("Error listing project members: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		log.Fatalf("Error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
 	var membersResp DopplerProjectMembersResponse
-	if err := json.Unmarshal(resp.Data, &membersResp); err != nil {
-		log.Fatalf("Error parsing project members response: %v", err)
+	if _, err := sdk.RequestJSON("doppler", req, &membersResp); err != nil {
+		log.Fatalf("Error listing project members: %v", err)
 	}
 
 	fmt.Printf("Page: %d / %d\n", membersResp.Page, membersResp.TotalPages)
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -35,18 +34,9 @@ func main() {
 		Headers:  map[string]string{"accept": "application/json"},
 	}
 
-	resp, err := sdk.Request("doppler", req)
-	if err != nil {
-		log.Fatalf("Error listing project roles: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		log.Fatalf("Error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
 	var rolesResp DopplerProjectRolesResponse
-	if err := json.Unmarshal(resp.Data, &rolesResp); err != nil {
-		log.Fatalf("Error parsing project roles response: %v", err)
+	if _, err := sdk.RequestJSON("doppler", req, &rolesResp); err != nil {
+		log.Fatalf("Error listing project roles: %v", err)
 	}
 
 	fmt.Println("Project Roles:")
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -42,18 +41,9 @@ func main() {
 		Headers:  map[string]string{"accept": "application/json"},
 	}
 
-	resp, err := sdk.Request("doppler", req)
-	if err != nil {
-		log.Fatalf("Error listing projects: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		log.Fatalf("Error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
 	var projectsResp DopplerProjectsResponse
-	if err := json.Unmarshal(resp.Data, &projectsResp); err != nil {
-		log.Fatalf("Error parsing projects response: %v", err)
+	if _, err := sdk.RequestJSON("doppler", req, &projectsResp); err != nil {
+		log.Fatalf("Error listing projects: %v", err)
 	}
 
 	fmt.Printf("Page: %d / %d\n", projectsResp.Page, projectsResp.TotalPages)
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -42,18 +41,9 @@ func main() {
 		Headers:  map[string]string{"accept": "application/json"},
 	}
 
-	resp, err := sdk.Request("gitguardian", req)
-	if err != nil {
-		log.Fatalf("Error requesting incidents: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		log.Fatalf("Error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
 	var incidents []Incident
-	if err := json.Unmarshal(resp.Data, &incidents); err != nil {
-		log.Fatalf("Error parsing response: %v", err)
+	if _, err := sdk.RequestJSON("gitguardian", req, &incidents); err != nil {
+		log.Fatalf("Error requesting incidents: %v", err)
 	}
 
 	fmt.Printf("Fetched %d incidents.\n", len(incidents))
@@ -1,17 +1,16 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"net/url"
 	"os"
-	"regexp"
 	"strings"
 
 	resilientbridge "github.com/opengovern/resilient-bridge"
 	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
 )
 
 func main() {
@@ -34,22 +33,23 @@ func main() {
 
 	// Initialize resilient-bridge
 	sdk := resilientbridge.NewResilientBridge()
-	sdk.RegisterProvider("github", adapters.NewGitHubAdapter(apiToken), &resilientbridge.ProviderConfig{
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter(apiToken), &resilientbridge.ProviderConfig{
 		UseProviderLimits: true,
 		MaxRetries:        3,
 		BaseBackoff:       0,
 	})
 
-	// Count commits (as before)
-	commitsCount, err := countCommits(sdk, owner, repoName)
+	// Archived, disabled, and empty repositories count as 0
+	// Count commits
+	commitsCount, err := github.CountCommits(sdk, owner, repoName, nil)
 	if err != nil {
 		log.Printf("Error counting commits: %v", err)
 	} else {
 		fmt.Printf("Total commits in %s/%s: %d\n", owner, repoName, commitsCount)
 	}
 
-	// Count issues
-	issuesCount, err := countIssues(sdk, owner, repoName)
+	// Count issues (pull requests are counted separately, below)
+	issuesCount, err := github.CountIssues(sdk, owner, repoName, nil)
 	if err != nil {
 		log.Printf("Error counting issues: %v", err)
 	} else {
@@ -57,7 +57,7 @@ func main() {
 	}
 
 	// Count branches
-	branchesCount, err := countBranches(sdk, owner, repoName)
+	branchesCount, err := github.CountBranches(sdk, owner, repoName, nil)
 	if err != nil {
 		log.Printf("Error counting branches: %v", err)
 	} else {
@@ -65,7 +65,7 @@ func main() {
 	}
 
 	// Count pull requests
-	prCount, err := countPullRequests(sdk, owner, repoName)
+	prCount, err := github.CountPullRequests(sdk, owner, repoName, nil)
 	if err != nil {
 		log.Printf("Error counting PRs: %v", err)
 	} else {
@@ -73,99 +73,6 @@ func main() {
 	}
 }
 
-func countCommits(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	// per_page=1 so last page number = total commits
-	endpoint := fmt.Sprintf("/repos/%s/%s/commits?sha=main&per_page=1", owner, repoName)
-	return countItemsFromEndpoint(sdk, endpoint)
-}
-
-func countIssues(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	// per_page=1 so last page number = total issues
-	// state=all to count all issues (including open and closed)
-	endpoint := fmt.Sprintf("/repos/%s/%s/issues?state=all&per_page=1", owner, repoName)
-	return countItemsFromEndpoint(sdk, endpoint)
-}
-
-func countBranches(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	// per_page=1 so last page number = total branches
-	endpoint := fmt.Sprintf("/repos/%s/%s/branches?per_page=1", owner, repoName)
-	return countItemsFromEndpoint(sdk, endpoint)
-}
-
-func countPullRequests(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	// per_page=1 so last page number = total PRs
-	// state=all to count all PRs (open, closed, merged)
-	endpoint := fmt.Sprintf("/repos/%s/%s/pulls?state=all&per_page=1", owner, repoName)
-	return countItemsFromEndpoint(sdk, endpoint)
-}
-
-func countItemsFromEndpoint(sdk *resilientbridge.ResilientBridge, endpoint string) (int, error) {
-	req := &resilientbridge.NormalizedRequest{
-		Method:   "GET",
-		Endpoint: endpoint,
-		Headers:  map[string]string{"Accept": "application/vnd.github+json"},
-	}
-
-	resp, err := sdk.Request("github", req)
-	if err != nil {
-		return 0, fmt.Errorf("error fetching data: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		return 0, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
-	// Find Link header (case-insensitive)
-	var linkHeader string
-	for k, v := range resp.Headers {
-		if strings.ToLower(k) == "link" {
-			linkHeader = v
-			break
-		}
-	}
-
-	if linkHeader == "" {
-		// No Link header: possibly a single page of results.
-		// Check if we got any item by looking at resp.Data.
-		// If endpoint returns a JSON array, check length > 2 means not empty array "[]".
-		if len(resp.Data) > 2 {
-			// Parse the array and ensure it's at least one item.
-			var items []interface{}
-			if err := json.Unmarshal(resp.Data, &items); err != nil {
-				// If parsing fails, assume at least one item since len(resp.Data)>2
-				return 1, nil
-			}
-			return len(items), nil
-		}
-		return 0, nil
-	}
-
-	// Parse last page
-	lastPage, err := parseLastPage(linkHeader)
-	if err != nil {
-		return 0, fmt.Errorf("could not parse last page: %w", err)
-	}
-
-	// per_page=1, so lastPage = total items
-	return lastPage, nil
-}
-
-// parseLastPage extracts the page number from the `rel="last"` link in the Link header.
-func parseLastPage(linkHeader string) (int, error) {
-	re := regexp.MustCompile(`page=(\d+)>; rel="last"`)
-	matches := re.FindStringSubmatch(linkHeader)
-	if len(matches) < 2 {
-		// If no last page found, possibly only one page of results.
-		return 1, nil
-	}
-	var lastPage int
-	_, err := fmt.Sscanf(matches[1], "%d", &lastPage)
-	if err != nil {
-		return 0, err
-	}
-	return lastPage, nil
-}
-
 // parseRepoURL extracts the owner and repo name from a GitHub URL.
 // Expected format: https://github.com/<owner>/<repo>
 func parseRepoURL(repoURL string) (string, string, error) {
@@ -12,6 +12,7 @@ import (
 
 	resilientbridge "github.com/opengovern/resilient-bridge"
 	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
 )
 
 func main() {
@@ -45,7 +46,7 @@ func main() {
 		log.Fatalf("Error parsing repo URL: %v", err)
 	}
 
-	active, err := checkRepositoryActive(sdk, owner, repo)
+	active, err := github.IsRepoActive(sdk, owner, repo)
 	if err != nil {
 		log.Fatalf("Error checking repository: %v", err)
 	}
@@ -183,39 +184,6 @@ func runNumberMatches(runNum int, criteria []runNumberCriterion) bool {
 	return false
 }
 
-// checkRepositoryActive returns false if the repository is archived or disabled, true otherwise.
-func checkRepositoryActive(sdk *resilientbridge.ResilientBridge, owner, repo string) (bool, error) {
-	req := &resilientbridge.NormalizedRequest{
-		Method:   "GET",
-		Endpoint: fmt.Sprintf("/repos/%s/%s", owner, repo),
-		Headers:  map[string]string{"Accept": "application/vnd.github+json"},
-	}
-
-	resp, err := sdk.Request("github", req)
-	if err != nil {
-		return false, fmt.Errorf("error checking repository: %w", err)
-	}
-	if resp.StatusCode == 404 {
-		// Repo not found, treat as inactive
-		return false, nil
-	} else if resp.StatusCode >= 400 {
-		return false, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
-	var repoInfo struct {
-		Archived bool `json:"archived"`
-		Disabled bool `json:"disabled"`
-	}
-	if err := json.Unmarshal(resp.Data, &repoInfo); err != nil {
-		return false, fmt.Errorf("error decoding repository info: %w", err)
-	}
-
-	if repoInfo.Archived || repoInfo.Disabled {
-		return false, nil
-	}
-	return true, nil
-}
-
 type simpleActor struct {
 	Login  string `json:"login"`
 	ID     int    `json:"id"`
@@ -5,16 +5,16 @@ import (
 	"flag"
 	"fmt"
 	"log"
-	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
-	"github.com/google/go-containerregistry/pkg/v1/remote"
 	resilientbridge "github.com/opengovern/resilient-bridge"
 	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+	"github.com/opengovern/resilient-bridge/utils"
 )
 
 // -------------------------------------------------------------------
@@ -36,37 +36,10 @@ type Package struct {
 	Owner       Owner  `json:"owner"`
 }
 
-type ContainerMetadata struct {
-	Container struct {
-		Tags []string `json:"tags"`
-	} `json:"container"`
-}
-
-type PackageVersion struct {
-	ID             int               `json:"id"`
-	Name           string            `json:"name"`
-	PackageHTMLURL string            `json:"package_html_url"`
-	CreatedAt      string            `json:"created_at"`
-	UpdatedAt      string            `json:"updated_at"`
-	HTMLURL        string            `json:"html_url"`
-	Metadata       ContainerMetadata `json:"metadata"`
-}
-
-// OutputVersion now includes a list of additional package URIs
-type OutputVersion struct {
-	ID                    int               `json:"id"`
-	Digest                string            `json:"digest"`
-	PackageURI            string            `json:"package_uri"`
-	AdditionalPackageURIs []string          `json:"additional_package_uris,omitempty"`
-	PackageHTMLURL        string            `json:"package_html_url"`
-	CreatedAt             string            `json:"created_at"`
-	UpdatedAt             string            `json:"updated_at"`
-	HTMLURL               string            `json:"html_url"`
-	Name                  string            `json:"name"`
-	MediaType             string            `json:"media_type"`
-	TotalSize             int64             `json:"total_size"`
-	Metadata              ContainerMetadata `json:"metadata"`
-	Manifest              interface{}       `json:"manifest"`
+// VersionOutput is a version as printed, with the per-platform sizes of multi-arch images.
+type VersionOutput struct {
+	github.OutputVersion
+	PlatformSizes map[string]int64 `json:"platform_sizes,omitempty"`
 }
 
 // -------------------------------------------------------------------
@@ -87,6 +60,7 @@ func main() {
 	maxVersionsFlag := flag.Int("max_versions", 1, "Maximum number of versions to retrieve (0 = no limit)")
 	startTimeFlag := flag.String("start_time", "", "Filter results updated after this time (RFC3339)")
 	endTimeFlag := flag.String("end_time", "", "Filter results updated before this time (RFC3339)")
+	manifestsFlag := flag.Bool("manifests", false, "Also fetch each image's manifest from ghcr.io (media type, size)")
 	flag.Parse()
 
 	if *orgFlag == "" {
@@ -128,9 +102,11 @@ func main() {
 	packages = filterPackagesByTime(packages)
 
 	for _, p := range packages {
-		packageName := p.Name
-		// Fetch all versions for each package with pagination
-		versions := fetchVersions(sdk, org, "container", packageName)
+		// Digests and tags come from the package API; versions sharing a digest are merged
+		versions, err := github.ResolvePackageVersionDigests(sdk, org, p.Name)
+		if err != nil {
+			log.Fatalf("Error resolving versions of %s: %v", p.Name, err)
+		}
 		versions = filterVersionsByTime(versions)
 
 		// Apply user-specified limit
@@ -138,12 +114,29 @@ func main() {
 			versions = versions[:*maxVersionsFlag]
 		}
 
-		// For each version, gather output
 		for _, v := range versions {
-			results := getVersionOutput(apiToken, org, packageName, v)
-			for _, ov := range results {
-				printJSON(ov)
+			out := VersionOutput{OutputVersion: v}
+			if *manifestsFlag && v.Manifest == nil {
+				manifest, err := github.FetchContainerManifest(sdk, org, p.Name, v.Digest, apiToken)
+				if err != nil {
+					log.Printf("Error fetching manifest for %s: %v (skipping)", v.PackageURI, err)
+					continue
+				}
+				out.MediaType, out.TotalSize, out.Manifest = manifest.MediaType, manifest.TotalSize, manifest.Raw
+			}
+			if *manifestsFlag && isIndex(out.MediaType) {
+				// An index has no layers of its own; size its platforms' images instead
+				image := github.ContainerRegistryHost + "/" + strings.ToLower(org) + "/" + strings.ToLower(p.Name)
+				ref, err := name.ParseReference(image + "@" + v.Digest)
+				if err == nil {
+					auth := &authn.Basic{Username: "token", Password: apiToken}
+					out.TotalSize, out.PlatformSizes, err = utils.ComputeImageSize(ref, auth)
+				}
+				if err != nil {
+					log.Printf("Error computing the size of %s: %v", v.PackageURI, err)
+				}
 			}
+			printJSON(out)
 		}
 	}
 }
@@ -193,47 +186,6 @@ func fetchPackages(sdk *resilientbridge.ResilientBridge, org, packageType string
 	return allPackages
 }
 
-func fetchVersions(sdk *resilientbridge.ResilientBridge, org, packageType, packageName string) []PackageVersion {
-	packageNameEncoded := url.PathEscape(packageName)
-	var allVersions []PackageVersion
-	page := 1
-	perPage := 100
-
-	for {
-		versionsReq := &resilientbridge.NormalizedRequest{
-			Method: "GET",
-			Endpoint: fmt.Sprintf(
-				"/orgs/%s/packages/%s/%s/versions?per_page=%d&page=%d",
-				org, packageType, packageNameEncoded, perPage, page,
-			),
-			Headers: map[string]string{"Accept": "application/vnd.github+json"},
-		}
-
-		versionsResp, err := sdk.Request("github", versionsReq)
-		if err != nil {
-			log.Fatalf("Error listing package versions: %v", err)
-		}
-		if versionsResp.StatusCode >= 400 {
-			log.Fatalf("HTTP error %d: %s", versionsResp.StatusCode, string(versionsResp.Data))
-		}
-
-		var versions []PackageVersion
-		if err := json.Unmarshal(versionsResp.Data, &versions); err != nil {
-			log.Fatalf("Error parsing package versions response: %v", err)
-		}
-		if len(versions) == 0 {
-			break
-		}
-
-		allVersions = append(allVersions, versions...)
-		if len(versions) < perPage {
-			break
-		}
-		page++
-	}
-	return allVersions
-}
-
 func filterPackagesByTime(pkgs []Package) []Package {
 	if startTime == nil && endTime == nil {
 		return pkgs
@@ -251,16 +203,13 @@ func filterPackagesByTime(pkgs []Package) []Package {
 	return filtered
 }
 
-func filterVersionsByTime(vers []PackageVersion) []PackageVersion {
+func filterVersionsByTime(vers []github.OutputVersion) []github.OutputVersion {
 	if startTime == nil && endTime == nil {
 		return vers
 	}
-	var filtered []PackageVersion
+	var filtered []github.OutputVersion
 	for _, v := range vers {
-		t, err := time.Parse(time.RFC3339, v.UpdatedAt)
-		if err != nil {
-			continue
-		}
+		t := v.UpdatedAt
 		if (startTime == nil || t.After(*startTime)) && (endTime == nil || t.Before(*endTime)) {
 			filtered = append(filtered, v)
 		}
@@ -268,6 +217,11 @@ func filterVersionsByTime(vers []PackageVersion) []PackageVersion {
 	return filtered
 }
 
+// isIndex reports whether mediaType is an OCI image index or a Docker manifest list.
+func isIndex(mediaType string) bool {
+	return strings.HasSuffix(mediaType, ".index.v1+json") || strings.HasSuffix(mediaType, ".manifest.list.v2+json")
+}
+
 func printJSON(obj interface{}) {
 	outBytes, err := json.Marshal(obj)
 	if err != nil {
@@ -275,106 +229,3 @@ func printJSON(obj interface{}) {
 	}
 	fmt.Println(string(outBytes))
 }
-
-// -------------------------------------------------------------------
-// Core logic: Deduplicate (id,digest) and store extra tags
-// -------------------------------------------------------------------
-
-func getVersionOutput(apiToken, org, packageName string, version PackageVersion) []OutputVersion {
-	authOption := remote.WithAuth(&authn.Basic{
-		Username: "github",
-		Password: apiToken,
-	})
-
-	// We store one OutputVersion per (ID, realDigest).
-	// additional_package_uris will hold subsequent tags with same (id, digest).
-	dedup := make(map[string]*OutputVersion)
-
-	for _, tag := range version.Metadata.Container.Tags {
-		imageRef := fmt.Sprintf("ghcr.io/%s/%s:%s",
-			org,
-			strings.ToLower(packageName),
-			strings.ToLower(tag),
-		)
-		ref, err := name.ParseReference(imageRef)
-		if err != nil {
-			log.Printf("Error parsing reference %s: %v (skipping)", imageRef, err)
-			continue
-		}
-
-		desc, err := remote.Get(ref, authOption)
-		if err != nil {
-			log.Printf("Error fetching manifest for %s: %v (skipping)", imageRef, err)
-			continue
-		}
-
-		actualDigest := desc.Descriptor.Digest.String()
-		// Combine version.ID + the real registry digest for dedup
-		dedupKey := fmt.Sprintf("%d|%s", version.ID, actualDigest)
-
-		// If we have an existing OutputVersion with same (id,digest), just add to AdditionalPackageURIs
-		if existing, ok := dedup[dedupKey]; ok {
-			existing.AdditionalPackageURIs = append(existing.AdditionalPackageURIs, imageRef)
-			continue
-		}
-
-		// Otherwise, parse the manifest to find total size, etc.
-		var manifestStruct struct {
-			SchemaVersion int    `json:"schemaVersion"`
-			MediaType     string `json:"mediaType"`
-			Config        struct {
-				Size      int64  `json:"size"`
-				Digest    string `json:"digest"`
-				MediaType string `json:"mediaType"`
-			} `json:"config"`
-			Layers []struct {
-				Size      int64  `json:"size"`
-				Digest    string `json:"digest"`
-				MediaType string `json:"mediaType"`
-			} `json:"layers"`
-		}
-		if err := json.Unmarshal(desc.Manifest, &manifestStruct); err != nil {
-			log.Printf("Error unmarshaling manifest JSON for %s: %v", imageRef, err)
-			continue
-		}
-
-		totalSize := manifestStruct.Config.Size
-		for _, layer := range manifestStruct.Layers {
-			totalSize += layer.Size
-		}
-
-		// Parse entire manifest into an interface{} for output
-		var manifestInterface interface{}
-		if err := json.Unmarshal(desc.Manifest, &manifestInterface); err != nil {
-			log.Printf("Error unmarshaling manifest for output: %v", err)
-			continue
-		}
-
-		// Create new OutputVersion record, storing the FIRST tag as package_uri
-		ov := &OutputVersion{
-			ID:                    version.ID,
-			Digest:                actualDigest,
-			PackageURI:            imageRef,   // "First tag" for this digest
-			AdditionalPackageURIs: []string{}, // Will append more if found
-			PackageHTMLURL:        version.PackageHTMLURL,
-			CreatedAt:             version.CreatedAt,
-			UpdatedAt:             version.UpdatedAt,
-			HTMLURL:               version.HTMLURL,
-			Name:                  imageRef, // or set to something else
-			MediaType:             string(desc.Descriptor.MediaType),
-			TotalSize:             totalSize,
-			Metadata:              version.Metadata,
-			Manifest:              manifestInterface,
-		}
-
-		dedup[dedupKey] = ov
-	}
-
-	// Convert map values to a slice in stable order (not guaranteed here).
-	// If you need stable output, you can store them in insertion order.
-	var results []OutputVersion
-	for _, output := range dedup {
-		results = append(results, *output)
-	}
-	return results
-}
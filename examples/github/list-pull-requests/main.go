@@ -4,57 +4,71 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"net/url"
 	"os"
 	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
 )
 
 func main() {
 	repoFlag := flag.String("repo", "", "Repository in the format https://github.com/<owner>/<repo>")
-	branchFlag := flag.String("branch", "", "Branch name (e.g. main)")
-	maxRunsFlag := flag.Int("maxruns", 50, "Maximum number of workflow runs to fetch (default 50)")
+	stateFlag := flag.String("state", "open", "Pull request state: open, closed, or all")
+	baseFlag := flag.String("base", "", "Only pull requests into this branch (e.g. main)")
+	sortFlag := flag.String("sort", "created", "Sort by created, updated, popularity, or long-running")
+	directionFlag := flag.String("direction", "desc", "Sort direction: asc or desc")
+	maxPRsFlag := flag.Int("maxprs", 50, "Maximum number of pull requests to fetch (default 50)")
 	flag.Parse()
 
 	if *repoFlag == "" {
 		log.Fatal("You must provide a -repo parameter, e.g. -repo=https://github.com/apache/cloudstack")
 	}
-	if *branchFlag == "" {
-		log.Fatal("You must provide a -branch parameter, e.g. -branch=main")
-	}
 
 	owner, repo, err := parseRepoURL(*repoFlag)
 	if err != nil {
 		log.Fatalf("Error parsing repo URL: %v", err)
 	}
 
-	active, err := checkRepositoryActive(owner, repo)
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter(os.Getenv("CR_PAT")), &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		MaxRetries:        3,
+	})
+
+	active, err := github.IsRepoActive(sdk, owner, repo)
 	if err != nil {
 		log.Fatalf("Error checking repository: %v", err)
 	}
 
 	if !active {
-		// Repository is archived or disabled, return no runs
+		// Repository is archived or disabled, return no pull requests
 		return
 	}
 
-	maxRuns := *maxRunsFlag
-	if maxRuns <= 0 {
-		maxRuns = 50
+	maxPRs := *maxPRsFlag
+	if maxPRs <= 0 {
+		maxPRs = 50
 	}
 
-	runs, err := fetchWorkflowRuns(owner, repo, *branchFlag, maxRuns)
+	pulls, err := github.ListPullRequests(sdk, owner, repo, &github.ListPullRequestsOptions{
+		State:     *stateFlag,
+		Base:      *baseFlag,
+		Sort:      *sortFlag,
+		Direction: *directionFlag,
+		Max:       maxPRs,
+	})
 	if err != nil {
-		log.Fatalf("Error fetching workflow runs: %v", err)
+		log.Fatalf("Error fetching pull requests: %v", err)
 	}
 
-	for _, run := range runs {
-		// Print each run as a JSON object
-		data, err := json.Marshal(run)
+	for _, pr := range pulls {
+		// Print each pull request as a JSON object
+		data, err := json.Marshal(pr)
 		if err != nil {
-			log.Printf("Error marshaling run: %v", err)
+			log.Printf("Error marshaling pull request #%d: %v", pr.Number, err)
 			continue
 		}
 		os.Stdout.Write(data)
@@ -78,130 +92,3 @@ func parseRepoURL(repoURL string) (string, string, error) {
 	repo := parts[1]
 	return owner, repo, nil
 }
-
-type repoInfo struct {
-	Archived bool `json:"archived"`
-	Disabled bool `json:"disabled"`
-}
-
-// checkRepositoryActive returns false if the repository is archived or disabled, true otherwise.
-func checkRepositoryActive(owner, repo string) (bool, error) {
-	u := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
-	req, err := http.NewRequest("GET", u, nil)
-	if err != nil {
-		return false, fmt.Errorf("error creating request to check repo: %w", err)
-	}
-	req.Header.Set("Accept", "application/vnd.github+json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return false, fmt.Errorf("error checking repository: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 404 {
-		// Repo not found, treat as inactive
-		return false, nil
-	} else if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return false, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
-	}
-
-	var info repoInfo
-	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
-		return false, fmt.Errorf("error decoding repository info: %w", err)
-	}
-
-	// If archived or disabled, mark as inactive
-	if info.Archived || info.Disabled {
-		return false, nil
-	}
-	return true, nil
-}
-
-type workflowRun struct {
-	ID            int    `json:"id"`
-	Name          string `json:"name"`
-	HeadBranch    string `json:"head_branch"`
-	HeadSHA       string `json:"head_sha"`
-	Status        string `json:"status"`
-	Conclusion    string `json:"conclusion"`
-	URL           string `json:"url"`
-	HTMLURL       string `json:"html_url"`
-	WorkflowID    int    `json:"workflow_id"`
-	RunNumber     int    `json:"run_number"`
-	Event         string `json:"event"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
-	RunAttempt    int    `json:"run_attempt"`
-	RunStartedAt  string `json:"run_started_at"`
-	JobsURL       string `json:"jobs_url"`
-	LogsURL       string `json:"logs_url"`
-	CheckSuiteURL string `json:"check_suite_url"`
-	ArtifactsURL  string `json:"artifacts_url"`
-}
-
-// response structure for listing workflow runs
-type workflowRunsResponse struct {
-	TotalCount   int           `json:"total_count"`
-	WorkflowRuns []workflowRun `json:"workflow_runs"`
-}
-
-// fetchWorkflowRuns returns up to maxRuns workflow runs filtered by branch
-func fetchWorkflowRuns(owner, repo, branch string, maxRuns int) ([]workflowRun, error) {
-	var allRuns []workflowRun
-	perPage := 100
-	page := 1
-	client := &http.Client{}
-
-	for len(allRuns) < maxRuns {
-		remaining := maxRuns - len(allRuns)
-		if remaining < perPage {
-			perPage = remaining
-		}
-
-		u := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/runs?branch=%s&per_page=%d&page=%d",
-			owner, repo, url.QueryEscape(branch), perPage, page)
-
-		req, err := http.NewRequest("GET", u, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error creating request: %w", err)
-		}
-		req.Header.Set("Accept", "application/vnd.github+json")
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("error fetching workflow runs: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode >= 400 {
-			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
-		}
-
-		var runsResp workflowRunsResponse
-		err = json.NewDecoder(resp.Body).Decode(&runsResp)
-		if err != nil {
-			return nil, fmt.Errorf("error decoding workflow runs: %w", err)
-		}
-
-		if len(runsResp.WorkflowRuns) == 0 {
-			// No more runs
-			break
-		}
-
-		allRuns = append(allRuns, runsResp.WorkflowRuns...)
-		if len(allRuns) >= maxRuns {
-			break
-		}
-		page++
-	}
-
-	if len(allRuns) > maxRuns {
-		allRuns = allRuns[:maxRuns]
-	}
-
-	return allRuns, nil
-}
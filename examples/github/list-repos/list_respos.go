@@ -6,11 +6,11 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"regexp"
 	"strings"
 
 	resilientbridge "github.com/opengovern/resilient-bridge"
 	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
 )
 
 // MAX_REPO limits how many repositories we retrieve in "list" mode.
@@ -216,72 +216,14 @@ type FinalRepoDetail struct {
 }
 
 // ---------------------------------------------------------------------------
-// 1. GetRepoList: Lists repositories for an organization up to MAX_REPO,
-//                 returning them as a slice of FinalRepoDetail (partial info).
+// 1. GetRepoList: Lists repositories for an organization up to MAX_REPO.
 // ---------------------------------------------------------------------------
 
-func GetRepoList(orgName string) ([]FinalRepoDetail, error) {
+func GetRepoList(orgName string) ([]github.RepoDetail, error) {
 	if orgName == "" {
 		return nil, fmt.Errorf("orgName must be provided")
 	}
-
-	apiToken := os.Getenv("GITHUB_API_TOKEN")
-	if apiToken == "" {
-		log.Println("GITHUB_API_TOKEN not set; you may only be able to access public repos")
-	}
-
-	sdk := resilientbridge.NewResilientBridge()
-	sdk.RegisterProvider("github", adapters.NewGitHubAdapter(apiToken), &resilientbridge.ProviderConfig{
-		UseProviderLimits: true,
-		MaxRetries:        3,
-		BaseBackoff:       0,
-	})
-
-	var allRepos []FinalRepoDetail
-	page := 1
-	for len(allRepos) < MAX_REPO {
-		remaining := MAX_REPO - len(allRepos)
-		perPage := 100
-		if remaining < perPage {
-			perPage = remaining
-		}
-
-		endpoint := fmt.Sprintf("/orgs/%s/repos?per_page=%d&page=%d", orgName, perPage, page)
-		req := &resilientbridge.NormalizedRequest{
-			Method:   "GET",
-			Endpoint: endpoint,
-			Headers:  map[string]string{"Accept": "application/vnd.github+json"},
-		}
-
-		resp, err := sdk.Request("github", req)
-		if err != nil {
-			return nil, fmt.Errorf("error fetching org repos: %w", err)
-		}
-		if resp.StatusCode >= 400 {
-			return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(resp.Data))
-		}
-
-		var partialList []RepoDetail
-		if err := json.Unmarshal(resp.Data, &partialList); err != nil {
-			return nil, fmt.Errorf("error decoding repos list: %w", err)
-		}
-		if len(partialList) == 0 {
-			break
-		}
-
-		// Transform each partial RepoDetail to FinalRepoDetail (most fields empty).
-		for _, repo := range partialList {
-			finalRepo := util_transformToFinalRepoDetail(&repo)
-			allRepos = append(allRepos, *finalRepo)
-		}
-
-		if len(allRepos) >= MAX_REPO {
-			break
-		}
-		page++
-	}
-
-	return allRepos, nil
+	return github.ListOrgRepos(newSDK(), orgName, github.ListReposOptions{Max: MAX_REPO})
 }
 
 // ---------------------------------------------------------------------------
@@ -289,22 +231,12 @@ func GetRepoList(orgName string) ([]FinalRepoDetail, error) {
 //                   and returns JSON string.
 // ---------------------------------------------------------------------------
 
-func GetRepository(orgName, repoName string) (string, error) {
+func GetRepository(orgName, repoName string, metrics *github.RepoMetrics) (string, error) {
 	if orgName == "" || repoName == "" {
 		return "", fmt.Errorf("both orgName and repoName must be provided")
 	}
 
-	apiToken := os.Getenv("GITHUB_API_TOKEN")
-	if apiToken == "" {
-		log.Println("GITHUB_API_TOKEN not set; you may only be able to access public repos")
-	}
-
-	sdk := resilientbridge.NewResilientBridge()
-	sdk.RegisterProvider("github", adapters.NewGitHubAdapter(apiToken), &resilientbridge.ProviderConfig{
-		UseProviderLimits: true,
-		MaxRetries:        3,
-		BaseBackoff:       0,
-	})
+	sdk := newSDK()
 
 	repoDetail, err := util_fetchRepoDetails(sdk, orgName, repoName)
 	if err != nil {
@@ -321,10 +253,20 @@ func GetRepository(orgName, repoName string) (string, error) {
 		finalDetail.Language = nil
 	}
 
-	// Enrich metrics
-	if err := util_enrichRepoMetrics(sdk, orgName, repoName, finalDetail); err != nil {
-		log.Printf("Error enriching repo metrics for %s/%s: %v", orgName, repoName, err)
+	// Enrich metrics, unless the caller already counted them for a whole batch
+	if metrics == nil {
+		repo := &github.RepoDetail{Repository: github.Repository{Name: repoName, Owner: github.User{Login: orgName}}}
+		for _, err := range github.EnrichMetrics(sdk, []*github.RepoDetail{repo}, 0) {
+			log.Printf("Error enriching repo metrics: %v", err)
+		}
+		metrics = repo.Metrics
 	}
+	finalDetail.Metrics.Commits = metrics.Commits
+	finalDetail.Metrics.Issues = metrics.Issues
+	finalDetail.Metrics.Branches = metrics.Branches
+	finalDetail.Metrics.PullRequests = metrics.PullRequests
+	finalDetail.Metrics.Releases = metrics.Releases
+	finalDetail.Metrics.Tags = metrics.Tags
 
 	data, err := json.MarshalIndent(finalDetail, "", "  ")
 	if err != nil {
@@ -360,9 +302,17 @@ func main() {
 			log.Fatalf("Error fetching organization repositories: %v", err)
 		}
 
+		// Count every repository's commits, issues, etc. concurrently up front
+		repos := make([]*github.RepoDetail, len(allRepos))
+		for i := range allRepos {
+			repos[i] = &allRepos[i]
+		}
+		for _, err := range github.EnrichMetrics(newSDK(), repos, 8) {
+			log.Printf("Error enriching repo metrics: %v", err)
+		}
+
 		for _, repo := range allRepos {
-			// The "Name" field in FinalRepoDetail is the actual repo name
-			jsonData, err := GetRepository(owner, repo.Name)
+			jsonData, err := GetRepository(owner, repo.Name, repo.Metrics)
 			if err != nil {
 				log.Printf("Error fetching details for %s/%s: %v", owner, repo.Name, err)
 				continue
@@ -371,7 +321,7 @@ func main() {
 		}
 	} else {
 		// If owner + repo is provided, just fetch that single repo.
-		jsonData, err := GetRepository(owner, repoName)
+		jsonData, err := GetRepository(owner, repoName, nil)
 		if err != nil {
 			log.Fatalf("Error fetching repository details: %v", err)
 		}
@@ -381,6 +331,22 @@ func main() {
 
 // ---- HELPER FUNCTIONS (all now prefixed with util_) -----------------------
 
+// newSDK returns an SDK with the GitHub adapter registered, authenticated by GITHUB_API_TOKEN.
+func newSDK() *resilientbridge.ResilientBridge {
+	apiToken := os.Getenv("GITHUB_API_TOKEN")
+	if apiToken == "" {
+		log.Println("GITHUB_API_TOKEN not set; you may only be able to access public repos")
+	}
+
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter(apiToken), &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		MaxRetries:        3,
+		BaseBackoff:       0,
+	})
+	return sdk
+}
+
 // Creates a consistent FinalRepoDetail from a RepoDetail, ensuring missing
 // fields become nil or an empty fallback (like an empty slice for `topics`).
 func util_transformToFinalRepoDetail(detail *RepoDetail) *FinalRepoDetail {
@@ -541,148 +507,6 @@ func util_fetchLanguages(sdk *resilientbridge.ResilientBridge, owner, repo strin
 	return langs, nil
 }
 
-func util_enrichRepoMetrics(sdk *resilientbridge.ResilientBridge, owner, repoName string, finalDetail *FinalRepoDetail) error {
-	var dbObj map[string]string
-	if finalDetail.DefaultBranchRef != nil {
-		if err := json.Unmarshal(finalDetail.DefaultBranchRef, &dbObj); err != nil {
-			return err
-		}
-	}
-	defaultBranch := dbObj["name"]
-	if defaultBranch == "" {
-		defaultBranch = "main"
-	}
-
-	commitsCount, err := util_countCommits(sdk, owner, repoName, defaultBranch)
-	if err != nil {
-		return fmt.Errorf("counting commits: %w", err)
-	}
-	finalDetail.Metrics.Commits = commitsCount
-
-	issuesCount, err := util_countIssues(sdk, owner, repoName)
-	if err != nil {
-		return fmt.Errorf("counting issues: %w", err)
-	}
-	finalDetail.Metrics.Issues = issuesCount
-
-	branchesCount, err := util_countBranches(sdk, owner, repoName)
-	if err != nil {
-		return fmt.Errorf("counting branches: %w", err)
-	}
-	finalDetail.Metrics.Branches = branchesCount
-
-	prCount, err := util_countPullRequests(sdk, owner, repoName)
-	if err != nil {
-		return fmt.Errorf("counting PRs: %w", err)
-	}
-	finalDetail.Metrics.PullRequests = prCount
-
-	releasesCount, err := util_countReleases(sdk, owner, repoName)
-	if err != nil {
-		return fmt.Errorf("counting releases: %w", err)
-	}
-	finalDetail.Metrics.Releases = releasesCount
-
-	tagsCount, err := util_countTags(sdk, owner, repoName)
-	if err != nil {
-		return fmt.Errorf("counting tags: %w", err)
-	}
-	finalDetail.Metrics.Tags = tagsCount
-
-	return nil
-}
-
-func util_countTags(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	endpoint := fmt.Sprintf("/repos/%s/%s/tags?per_page=1", owner, repoName)
-	return util_countItemsFromEndpoint(sdk, endpoint)
-}
-
-func util_countCommits(sdk *resilientbridge.ResilientBridge, owner, repoName, defaultBranch string) (int, error) {
-	endpoint := fmt.Sprintf("/repos/%s/%s/commits?sha=%s&per_page=1", owner, repoName, defaultBranch)
-	return util_countItemsFromEndpoint(sdk, endpoint)
-}
-
-func util_countIssues(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	endpoint := fmt.Sprintf("/repos/%s/%s/issues?state=all&per_page=1", owner, repoName)
-	return util_countItemsFromEndpoint(sdk, endpoint)
-}
-
-func util_countBranches(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	endpoint := fmt.Sprintf("/repos/%s/%s/branches?per_page=1", owner, repoName)
-	return util_countItemsFromEndpoint(sdk, endpoint)
-}
-
-func util_countPullRequests(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	endpoint := fmt.Sprintf("/repos/%s/%s/pulls?state=all&per_page=1", owner, repoName)
-	return util_countItemsFromEndpoint(sdk, endpoint)
-}
-
-func util_countReleases(sdk *resilientbridge.ResilientBridge, owner, repoName string) (int, error) {
-	endpoint := fmt.Sprintf("/repos/%s/%s/releases?per_page=1", owner, repoName)
-	return util_countItemsFromEndpoint(sdk, endpoint)
-}
-
-func util_countItemsFromEndpoint(sdk *resilientbridge.ResilientBridge, endpoint string) (int, error) {
-	req := &resilientbridge.NormalizedRequest{
-		Method:   "GET",
-		Endpoint: endpoint,
-		Headers:  map[string]string{"Accept": "application/vnd.github+json"},
-	}
-	resp, err := sdk.Request("github", req)
-	if err != nil {
-		return 0, fmt.Errorf("error fetching data: %w", err)
-	}
-
-	// Some repos return 409 for certain endpoints (empty or not applicable).
-	if resp.StatusCode == 409 {
-		return 0, nil
-	}
-	if resp.StatusCode >= 400 {
-		return 0, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
-	var linkHeader string
-	for k, v := range resp.Headers {
-		if strings.ToLower(k) == "link" {
-			linkHeader = v
-			break
-		}
-	}
-
-	// If there's no Link header, the count is the length of the JSON array (or 0 if empty).
-	if linkHeader == "" {
-		if len(resp.Data) > 2 {
-			var items []interface{}
-			if err := json.Unmarshal(resp.Data, &items); err != nil {
-				// If we can't unmarshal, assume there's at least one item.
-				return 1, nil
-			}
-			return len(items), nil
-		}
-		return 0, nil
-	}
-
-	lastPage, err := util_parseLastPage(linkHeader)
-	if err != nil {
-		return 0, fmt.Errorf("could not parse last page: %w", err)
-	}
-	return lastPage, nil
-}
-
-func util_parseLastPage(linkHeader string) (int, error) {
-	re := regexp.MustCompile(`page=(\d+)>; rel="last"`)
-	matches := re.FindStringSubmatch(linkHeader)
-	if len(matches) < 2 {
-		return 1, nil
-	}
-	var lastPage int
-	_, err := fmt.Sscanf(matches[1], "%d", &lastPage)
-	if err != nil {
-		return 0, err
-	}
-	return lastPage, nil
-}
-
 func util_parseScopeURL(repoURL string) (owner, repo string, err error) {
 	if !strings.HasPrefix(repoURL, "https://github.com/") {
 		return "", "", fmt.Errorf("URL must start with https://github.com/")
@@ -44,7 +44,7 @@ func main() {
 	sdk := resilientbridge.NewResilientBridge()
 	// Register OpenAI provider, optionally overriding limits if needed
 	// For demonstration, let's just use defaults (no overrides)
-	sdk.RegisterProvider("openai", &adapters.OpenAIAdapter{APIKey: apiKey}, &resilientbridge.ProviderConfig{
+	sdk.RegisterProvider("openai", adapters.NewOpenAIAdapter(apiKey, ""), &resilientbridge.ProviderConfig{
 		UseProviderLimits: true,
 		MaxRetries:        3,
 		BaseBackoff:       200 * time.Millisecond, // Adding a small base backoff for retries
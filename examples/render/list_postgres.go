@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -82,18 +81,9 @@ func main() {
 		Headers:  map[string]string{"accept": "application/json"},
 	}
 
-	resp, err := sdk.Request("render", req)
-	if err != nil {
-		log.Fatalf("Error listing Postgres instances: %v", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		log.Fatalf("Error %d: %s", resp.StatusCode, string(resp.Data))
-	}
-
 	var pgResp PostgresListResponse
-	if err := json.Unmarshal(resp.Data, &pgResp); err != nil {
-		log.Fatalf("Error parsing Postgres response: %v", err)
+	if _, err := sdk.RequestJSON("render", req, &pgResp); err != nil {
+		log.Fatalf("Error listing Postgres instances: %v", err)
 	}
 
 	fmt.Printf("Retrieved %d Postgres instances.\n", len(pgResp))
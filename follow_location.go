@@ -0,0 +1,54 @@
+// follow_location.go
+// ------------------
+// This file implements NormalizedRequest.FollowLocationStripAuth, for downloads that GitHub (artifacts,
+// run logs, release assets) and registries such as GHCR (blobs) answer with a redirect to a signed
+// storage URL. The storage host authenticates the request through the URL's signature and rejects
+// requests that also carry the provider's credentials, so the redirect must be followed without them.
+//
+// net/http drops credentials on some cross-host redirects by itself, but which ones depends on the Go
+// version (older releases kept them for subdomains), and a custom client's CheckRedirect can forward
+// them again. With FollowLocationStripAuth the SDK follows up to maxRedirects redirects on
+// the request's own client and removes Authorization and cookies from every hop whose host differs
+// from the original request's, whatever the client's CheckRedirect says. The final response is returned
+// (or, for RequestStream, streamed) as the answer to the request.
+package resilientbridge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxRedirects bounds the redirects followed for FollowLocationStripAuth, as net/http does by default.
+const maxRedirects = 10
+
+type followLocationKey struct{}
+
+// withFollowLocation marks ctx so round trips made with it follow redirects per req.FollowLocationStripAuth.
+func withFollowLocation(ctx context.Context, req *NormalizedRequest) context.Context {
+	if req == nil || !req.FollowLocationStripAuth {
+		return ctx
+	}
+	return context.WithValue(ctx, followLocationKey{}, true)
+}
+
+// followLocationStripAuth reports whether ctx was marked by withFollowLocation.
+func followLocationStripAuth(ctx context.Context) bool {
+	follow, _ := ctx.Value(followLocationKey{}).(bool)
+	return follow
+}
+
+// stripAuthCheckRedirect follows redirects, dropping credentials once the host differs from the first
+// request's.
+func stripAuthCheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		for _, name := range []string{"Authorization", "Www-Authenticate", "Cookie", "Cookie2"} {
+			req.Header.Del(name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// branches.go
+// -----------
+// This file provides ListBranches and GetBranch. An empty repository has no branches: ListBranches
+// returns none instead of an error.
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// Branch is a branch as returned by GitHub's branches API.
+type Branch struct {
+	Name      string    `json:"name"`
+	Commit    CommitRef `json:"commit"`
+	Protected bool      `json:"protected"`
+}
+
+// ListBranches returns every branch of owner/repo, following every page.
+func ListBranches(sdk *resilientbridge.ResilientBridge, owner, repo string) ([]Branch, error) {
+	req := newRequest("GET", repoEndpoint(owner, repo)+"/branches?per_page=100")
+
+	var branches []Branch
+	err := sdk.Paginate(context.Background(), ProviderName, req, nil, func(resp *resilientbridge.NormalizedResponse) error {
+		var page []Branch
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		branches = append(branches, page...)
+		return nil
+	})
+	if isEmptyRepoError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing branches of %s/%s: %w", owner, repo, err)
+	}
+	return branches, nil
+}
+
+// GetBranch returns the branch name of owner/repo.
+func GetBranch(sdk *resilientbridge.ResilientBridge, owner, repo, name string) (*Branch, error) {
+	var branch Branch
+	if err := getJSON(context.Background(), sdk, repoEndpoint(owner, repo)+"/branches/"+url.PathEscape(name), &branch); err != nil {
+		return nil, fmt.Errorf("error fetching branch %s of %s/%s: %w", name, owner, repo, err)
+	}
+	return &branch, nil
+}
@@ -0,0 +1,99 @@
+// cache.go
+// --------
+// This file provides the per-SDK caches behind IsRepoActive and DefaultBranch. Each cache is attached to
+// its SDK with sdk.Value, so it is released along with the SDK and a clone (e.g. one per tenant) starts
+// with empty caches of its own. Entries past their TTL are deleted when read, and swept from the whole
+// cache as it grows, so a long-running service only holds entries that are still fresh. ClearCaches
+// empties an SDK's caches at once.
+package github
+
+import (
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// cacheKey names a cache attached to an SDK.
+type cacheKey int
+
+const (
+	repoStatusCacheKey cacheKey = iota
+	defaultBranchCacheKey
+)
+
+// minCacheSweep is the size at which a cache is first swept for expired entries.
+const minCacheSweep = 64
+
+// ttlCache maps keys to values that expire after a TTL given on each call.
+type ttlCache[V any] struct {
+	mu      sync.Mutex
+	entries map[string]ttlEntry[V]
+	sweepAt int // Size at which set next deletes all expired entries
+}
+
+type ttlEntry[V any] struct {
+	value  V
+	stored time.Time
+}
+
+// cacheFor returns sdk's cache named key, attaching an empty one first if needed.
+func cacheFor[V any](sdk *resilientbridge.ResilientBridge, key cacheKey) *ttlCache[V] {
+	return sdk.Value(key, func() any { return &ttlCache[V]{} }).(*ttlCache[V])
+}
+
+// get returns the value stored under key less than ttl ago. An expired entry is deleted.
+func (c *ttlCache[V]) get(key string, ttl time.Duration) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if time.Since(entry.stored) >= ttl {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// set stores value under key. Once the cache has doubled since the last sweep, entries older than ttl
+// are deleted.
+func (c *ttlCache[V]) set(key string, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.entries == nil {
+		c.entries = make(map[string]ttlEntry[V])
+	}
+	c.entries[key] = ttlEntry[V]{value: value, stored: now}
+	if len(c.entries) < c.sweepAt {
+		return
+	}
+	for k, entry := range c.entries {
+		if now.Sub(entry.stored) >= ttl {
+			delete(c.entries, k)
+		}
+	}
+	c.sweepAt = 2 * len(c.entries)
+	if c.sweepAt < minCacheSweep {
+		c.sweepAt = minCacheSweep
+	}
+}
+
+// clear deletes every entry.
+func (c *ttlCache[V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.sweepAt = 0
+}
+
+// ClearCaches drops everything this package has cached for sdk: the repository statuses IsRepoActive
+// remembers and the names DefaultBranch resolved.
+func ClearCaches(sdk *resilientbridge.ResilientBridge) {
+	cacheFor[bool](sdk, repoStatusCacheKey).clear()
+	cacheFor[string](sdk, defaultBranchCacheKey).clear()
+}
@@ -0,0 +1,55 @@
+// client.go
+// ---------
+// This file provides Client, the SDK together with the settings of one GitHub deployment, for code built
+// on top of this package (such as the utils model detector) that sends its own requests. The package
+// functions take the SDK alone and always talk to api.github.com with the standard media type; a Client
+// also carries:
+//   - BaseURL, the API root of a GitHub Enterprise Server ("https://ghe.example.com/api/v3"), set on every
+//     request as NormalizedRequest.BaseURLOverride, so its limits are still tracked under ProviderName.
+//   - Accept, media types (usually Preview ones) asked for on every request.
+//
+// Requests built by a Client set no other headers: the Accept header (when Accept is empty) and the
+// User-Agent come from the provider's ProviderConfig (DefaultHeaders, UserAgent), so every caller sharing
+// the SDK sends the same ones.
+package github
+
+import (
+	"context"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// Client sends GitHub requests through SDK with the deployment settings described above.
+type Client struct {
+	SDK     *resilientbridge.ResilientBridge
+	BaseURL string   // API root of a GitHub Enterprise Server; "" for api.github.com
+	Accept  []string // Media types asked for on every request, before the standard one
+}
+
+// NewClient returns a Client for api.github.com sending its requests through sdk.
+func NewClient(sdk *resilientbridge.ResilientBridge) *Client {
+	return &Client{SDK: sdk}
+}
+
+// NewRequest returns a request for endpoint with the client's base URL and Accept media types, modified
+// by opts.
+func (c *Client) NewRequest(method, endpoint string, opts ...RequestOption) *resilientbridge.NormalizedRequest {
+	req := &resilientbridge.NormalizedRequest{
+		Method:          method,
+		Endpoint:        endpoint,
+		Headers:         map[string]string{},
+		BaseURLOverride: c.BaseURL,
+	}
+	if len(c.Accept) > 0 {
+		WithAccept(c.Accept...)(req)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// Do sends req to GitHub through the client's SDK.
+func (c *Client) Do(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return c.SDK.RequestWithContext(ctx, ProviderName, req)
+}
@@ -0,0 +1,110 @@
+// commit_detail.go
+// ----------------
+// This file provides GetCommitDetail, a commit decoded straight into the typed Commit, together with the
+// lookups that place it in its repository: the pull requests it belongs to and the branch it was made on.
+// Both are methods, so a caller that doesn't need them doesn't pay for the extra requests. So is the
+// commit's Repository, which commits obtained from a RepoDetailCache share instead of fetching it each.
+//
+// Branch resolution prefers the base branch of the commit's first associated pull request (the branch a
+// merged change landed on) and falls back to the branches whose head is the commit.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// CommitDetail is a commit of a repository, as returned by GetCommitDetail. The embedded Commit
+// includes Stats and Files.
+type CommitDetail struct {
+	Commit
+
+	Owner string `json:"-"`
+	Repo  string `json:"-"`
+
+	sdk          *resilientbridge.ResilientBridge
+	repos        *RepoDetailCache // Set by RepoDetailCache.GetCommitDetail
+	pullRequests []PullRequest
+	prsFetched   bool
+}
+
+// GetCommitDetail returns the commit sha of owner/repo, including its Stats and Files.
+func GetCommitDetail(sdk *resilientbridge.ResilientBridge, owner, repo, sha string) (*CommitDetail, error) {
+	commit, err := GetCommit(sdk, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	return &CommitDetail{Commit: *commit, Owner: owner, Repo: repo, sdk: sdk}, nil
+}
+
+// Repository returns the repository the commit belongs to, from the RepoDetailCache the commit was
+// obtained from, or else fetched (once per CommitDetail).
+func (d *CommitDetail) Repository() (*Repository, error) {
+	if d.repos == nil {
+		d.repos = NewRepoDetailCache(d.sdk)
+	}
+	return d.repos.Get(d.Owner, d.Repo)
+}
+
+// PullRequests returns the pull requests associated with the commit: the ones it was merged by, or
+// the open ones that contain it. The result is fetched once and then reused. A commit without pull
+// requests, or one GitHub can't associate (409 for an empty repository, 404), yields none.
+func (d *CommitDetail) PullRequests() ([]PullRequest, error) {
+	if d.prsFetched {
+		return d.pullRequests, nil
+	}
+	var prs []PullRequest
+	err := getJSON(context.Background(), d.sdk, repoEndpoint(d.Owner, d.Repo)+"/commits/"+d.SHA+"/pulls", &prs)
+	var httpErr *resilientbridge.HTTPError
+	if errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusConflict) {
+		prs, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching pull requests of commit %s: %w", d.SHA, err)
+	}
+	d.pullRequests, d.prsFetched = prs, true
+	return prs, nil
+}
+
+// PullRequestNumbers returns the numbers of the commit's PullRequests.
+func (d *CommitDetail) PullRequestNumbers() ([]int, error) {
+	prs, err := d.PullRequests()
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers, nil
+}
+
+// Branch returns the branch the commit belongs to: the base branch of its first pull request, or else
+// the first branch whose head is the commit. It returns "" if neither is known, e.g. for a commit that
+// is no longer the head of any branch and was never part of a pull request.
+func (d *CommitDetail) Branch() (string, error) {
+	prs, err := d.PullRequests()
+	if err != nil {
+		return "", err
+	}
+	if len(prs) > 0 && prs[0].Base.Ref != "" {
+		return prs[0].Base.Ref, nil
+	}
+
+	var branches []Branch
+	err = getJSON(context.Background(), d.sdk, repoEndpoint(d.Owner, d.Repo)+"/commits/"+d.SHA+"/branches-where-head", &branches)
+	if isEmptyRepoError(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error fetching branches of commit %s: %w", d.SHA, err)
+	}
+	if len(branches) == 0 {
+		return "", nil
+	}
+	return branches[0].Name, nil
+}
@@ -0,0 +1,182 @@
+// commits.go
+// ----------
+// This file provides ListCommits and GetCommit. The list endpoint returns each commit's message, authors,
+// parents, and signature verification; stats and changed files are only returned by the single-commit
+// endpoint, so ListCommits fetches them per commit when ListCommitsOptions.Details is set. An empty
+// repository has no commits rather than an error.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// Commit is a commit as returned by GitHub's commits API.
+type Commit struct {
+	SHA       string       `json:"sha"`
+	NodeID    string       `json:"node_id"`
+	HTMLURL   string       `json:"html_url"`
+	Commit    CommitData   `json:"commit"`
+	Author    *User        `json:"author"`    // GitHub account of the author; nil if the email isn't linked to one
+	Committer *User        `json:"committer"` // GitHub account of the committer; nil if the email isn't linked to one
+	Parents   []CommitRef  `json:"parents"`
+	Stats     *CommitStats `json:"stats,omitempty"` // Only set by GetCommit (or ListCommits with Details)
+	Files     []CommitFile `json:"files,omitempty"` // Only set by GetCommit (or ListCommits with Details)
+}
+
+// ShortSHA returns the first seven characters of the commit SHA.
+func (c *Commit) ShortSHA() string {
+	if len(c.SHA) < 7 {
+		return c.SHA
+	}
+	return c.SHA[:7]
+}
+
+// CommitData is the git-level part of a Commit.
+type CommitData struct {
+	Message      string       `json:"message"`
+	Author       GitActor     `json:"author"`
+	Committer    GitActor     `json:"committer"`
+	Tree         CommitRef    `json:"tree"`
+	CommentCount int          `json:"comment_count"`
+	Verification Verification `json:"verification"`
+}
+
+// GitActor is the name, email, and date recorded in a commit.
+type GitActor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// CommitRef references a commit or tree by SHA.
+type CommitRef struct {
+	SHA string `json:"sha"`
+	URL string `json:"url"`
+}
+
+// CommitStats counts the lines changed by a commit.
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// CommitFile is one file changed by a commit.
+type CommitFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	SHA              string `json:"sha"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+	Patch            string `json:"patch,omitempty"`
+}
+
+// Verification is the signature verification result of a commit.
+type Verification struct {
+	Verified   bool       `json:"verified"`
+	Reason     string     `json:"reason"`
+	Signature  *string    `json:"signature"`
+	Payload    *string    `json:"payload"`
+	VerifiedAt *time.Time `json:"verified_at"`
+}
+
+// ListCommitsOptions filters and bounds ListCommits. The zero value lists every commit on the
+// default branch.
+type ListCommitsOptions struct {
+	SHA    string    // Branch name or commit SHA to start listing from; defaults to the default branch
+	Path   string    // Only commits touching this file path
+	Author string    // Only commits by this GitHub login or email address
+	Since  time.Time // Only commits after this time, if set
+	Until  time.Time // Only commits before this time, if set
+
+	MaxCommits int  // Stop after this many commits; 0 means no limit
+	Details    bool // Fetch each commit with GetCommit to fill in Stats and Files
+}
+
+// errEnoughCommits stops pagination once MaxCommits commits have been collected.
+var errEnoughCommits = errors.New("enough commits")
+
+// ListCommits returns the commits of owner/repo, newest first, following every page. A nil opts uses
+// the defaults. An empty repository yields no commits and no error.
+func ListCommits(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *ListCommitsOptions) ([]Commit, error) {
+	if opts == nil {
+		opts = &ListCommitsOptions{}
+	}
+	ctx := context.Background()
+
+	perPage := 100
+	if opts.MaxCommits > 0 && opts.MaxCommits < perPage {
+		perPage = opts.MaxCommits
+	}
+	q := url.Values{}
+	q.Set("per_page", strconv.Itoa(perPage))
+	if opts.SHA != "" {
+		q.Set("sha", opts.SHA)
+	}
+	if opts.Path != "" {
+		q.Set("path", opts.Path)
+	}
+	if opts.Author != "" {
+		q.Set("author", opts.Author)
+	}
+	if !opts.Since.IsZero() {
+		q.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		q.Set("until", opts.Until.UTC().Format(time.RFC3339))
+	}
+	req := newRequest("GET", fmt.Sprintf("/repos/%s/%s/commits?%s", url.PathEscape(owner), url.PathEscape(repo), q.Encode()))
+
+	var commits []Commit
+	err := sdk.Paginate(ctx, ProviderName, req, nil, func(resp *resilientbridge.NormalizedResponse) error {
+		var page []Commit
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		commits = append(commits, page...)
+		if opts.MaxCommits > 0 && len(commits) >= opts.MaxCommits {
+			return errEnoughCommits
+		}
+		return nil
+	})
+	if isEmptyRepoError(err) {
+		return nil, nil
+	}
+	if err != nil && !errors.Is(err, errEnoughCommits) {
+		return nil, fmt.Errorf("error listing commits of %s/%s: %w", owner, repo, err)
+	}
+	if opts.MaxCommits > 0 && len(commits) > opts.MaxCommits {
+		commits = commits[:opts.MaxCommits]
+	}
+
+	if opts.Details {
+		for i := range commits {
+			detailed, err := GetCommit(sdk, owner, repo, commits[i].SHA)
+			if err != nil {
+				return nil, err
+			}
+			commits[i] = *detailed
+		}
+	}
+	return commits, nil
+}
+
+// GetCommit returns a single commit of owner/repo, including its Stats and Files. ref may be a
+// commit SHA, branch, or tag name.
+func GetCommit(sdk *resilientbridge.ResilientBridge, owner, repo, ref string) (*Commit, error) {
+	var commit Commit
+	endpoint := fmt.Sprintf("/repos/%s/%s/commits/%s", url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(ref))
+	if err := getJSON(context.Background(), sdk, endpoint, &commit); err != nil {
+		return nil, fmt.Errorf("error fetching commit %s: %w", ref, err)
+	}
+	return &commit, nil
+}
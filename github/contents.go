@@ -0,0 +1,80 @@
+// contents.go
+// -----------
+// This file provides ListContents for GitHub's contents API. The endpoint answers with a JSON array for
+// a directory and a single object for a file; ListContents returns both as a slice. An empty repository
+// has no contents: ListContents returns none instead of an error.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// Content is a file, directory, symlink, or submodule entry of GitHub's contents API.
+type Content struct {
+	Type        string `json:"type"` // "file", "dir", "symlink", or "submodule"
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	SHA         string `json:"sha"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+	HTMLURL     string `json:"html_url"`
+	DownloadURL string `json:"download_url"`
+
+	// Only set when the path is a single file
+	Content  string `json:"content,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Decoded returns the file's content, decoding GitHub's base64 encoding.
+func (c *Content) Decoded() ([]byte, error) {
+	if c.Encoding != "base64" {
+		return []byte(c.Content), nil
+	}
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(c.Content, "\n", ""))
+}
+
+// ListContents returns the entries at path in owner/repo ("" for the root) at ref (a branch, tag, or
+// commit SHA; "" for the default branch). For a file path, the result is that single file, including
+// its Content.
+func ListContents(sdk *resilientbridge.ResilientBridge, owner, repo, path, ref string) ([]Content, error) {
+	endpoint := repoEndpoint(owner, repo) + "/contents/" + escapePath(path)
+	if ref != "" {
+		endpoint += "?" + url.Values{"ref": {ref}}.Encode()
+	}
+	resp, err := sdk.RequestWithContext(context.Background(), ProviderName, newRequest("GET", endpoint))
+	if isEmptyRepoError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing contents of %s/%s at %q: %w", owner, repo, path, err)
+	}
+
+	if data := bytes.TrimSpace(resp.Data); len(data) > 0 && data[0] == '{' {
+		var file Content
+		if err := decode(resp, &file); err != nil {
+			return nil, err
+		}
+		return []Content{file}, nil
+	}
+	var entries []Content
+	if err := decode(resp, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// escapePath escapes each segment of a repository path, keeping the "/" separators.
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
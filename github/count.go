@@ -0,0 +1,284 @@
+// count.go
+// --------
+// This file provides the Count* helpers, which count the items behind a GitHub list endpoint without
+// fetching them: with per_page=1, the page number of the rel="last" link equals the item count.
+//
+// That REST method has one trap: GitHub's /issues endpoint lists pull requests as issues, so its count is
+// issues and pull requests together. CountIssues, CountPullRequests, and CountCommits therefore ask
+// GraphQL for the totalCount of the repository's issues, pullRequests, or commit history, which are
+// separate and exact. When GraphQL cannot answer the query (it reports errors, as a GitHub Enterprise
+// Server without the field does, or leaves the field out) or CountOptions.UseREST is set, they fall back
+// to the REST method, counting issues as /issues minus /pulls. Rate limits, HTTP errors (a rejected token
+// included), and network errors are returned instead: the REST requests would only run into them too.
+//
+// The repository counters share two rules. Inactive repositories (archived, disabled, or missing) count
+// as 0, unless CountOptions.SkipActiveCheck is set because the caller already knows the repository is
+// live. And the answers GitHub gives for an empty repository (see IsEmptyRepoResponse) count as 0.
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// CountOptions controls the repository Count* helpers.
+type CountOptions struct {
+	// SkipActiveCheck skips the IsRepoActive lookup.
+	SkipActiveCheck bool
+
+	// Ref is the branch, tag, or SHA whose history CountCommits counts; "" means the default branch.
+	Ref string
+
+	// UseREST counts with the REST Link method only, without trying GraphQL first.
+	UseREST bool
+}
+
+// GraphQL queries of the counts the REST method cannot give exactly, or only with two requests.
+const (
+	countIssuesQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) { issues { totalCount } }
+}`
+	countPullRequestsQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) { pullRequests { totalCount } }
+}`
+	countCommitsQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    defaultBranchRef { target { ... on Commit { history { totalCount } } } }
+  }
+}`
+	countRefCommitsQuery = `query($owner: String!, $name: String!, $ref: String!) {
+  repository(owner: $owner, name: $name) {
+    object(expression: $ref) { ... on Commit { history { totalCount } } }
+  }
+}`
+)
+
+// totalCount is a GraphQL connection's totalCount; a nil *totalCount means the field was not returned.
+type totalCount struct {
+	TotalCount int `json:"totalCount"`
+}
+
+// countedCommit is a commit with the size of its history.
+type countedCommit struct {
+	History *totalCount `json:"history"`
+}
+
+// countRepository holds the fields selected by the count queries.
+type countRepository struct {
+	Issues           *totalCount    `json:"issues"`
+	PullRequests     *totalCount    `json:"pullRequests"`
+	Object           *countedCommit `json:"object"`
+	DefaultBranchRef *struct {
+		Target *countedCommit `json:"target"`
+	} `json:"defaultBranchRef"`
+}
+
+// CountCommits returns the number of commits on the default branch of owner/repo, or reachable from
+// opts.Ref when set.
+func CountCommits(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error) {
+	var query url.Values
+	gqlQuery, vars := countCommitsQuery, map[string]any{}
+	pick := func(r *countRepository) *totalCount {
+		if r.DefaultBranchRef == nil {
+			// No default branch: the repository is empty
+			return &totalCount{}
+		}
+		if r.DefaultBranchRef.Target == nil {
+			return nil
+		}
+		return r.DefaultBranchRef.Target.History
+	}
+	if opts != nil && opts.Ref != "" {
+		query = url.Values{"sha": {opts.Ref}}
+		gqlQuery, vars["ref"] = countRefCommitsQuery, opts.Ref
+		pick = func(r *countRepository) *totalCount {
+			if r.Object == nil {
+				return nil
+			}
+			return r.Object.History
+		}
+	}
+	return countRepoGraphQL(sdk, owner, repo, opts, gqlQuery, vars, pick, func() (int, error) {
+		return countRepoREST(sdk, owner, repo, "commits", query)
+	})
+}
+
+// CountIssues returns the number of issues of owner/repo in any state, not including pull requests.
+func CountIssues(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error) {
+	pick := func(r *countRepository) *totalCount { return r.Issues }
+	return countRepoGraphQL(sdk, owner, repo, opts, countIssuesQuery, nil, pick, func() (int, error) {
+		// The issues endpoint also lists pull requests
+		all, err := countRepoREST(sdk, owner, repo, "issues", url.Values{"state": {"all"}})
+		if err != nil {
+			return 0, err
+		}
+		pulls, err := countRepoREST(sdk, owner, repo, "pulls", url.Values{"state": {"all"}})
+		if err != nil {
+			return 0, err
+		}
+		if pulls > all {
+			return 0, nil
+		}
+		return all - pulls, nil
+	})
+}
+
+// CountPullRequests returns the number of pull requests of owner/repo in any state.
+func CountPullRequests(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error) {
+	pick := func(r *countRepository) *totalCount { return r.PullRequests }
+	return countRepoGraphQL(sdk, owner, repo, opts, countPullRequestsQuery, nil, pick, func() (int, error) {
+		return countRepoREST(sdk, owner, repo, "pulls", url.Values{"state": {"all"}})
+	})
+}
+
+// CountBranches returns the number of branches of owner/repo.
+func CountBranches(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error) {
+	return countRepoResource(sdk, owner, repo, "branches", nil, opts)
+}
+
+// CountTags returns the number of tags of owner/repo.
+func CountTags(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error) {
+	return countRepoResource(sdk, owner, repo, "tags", nil, opts)
+}
+
+// CountReleases returns the number of releases of owner/repo.
+func CountReleases(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error) {
+	return countRepoResource(sdk, owner, repo, "releases", nil, opts)
+}
+
+// countRepoResource counts /repos/{owner}/{repo}/{resource}?{query}, applying the shared behavior
+// described in the file header.
+func countRepoResource(sdk *resilientbridge.ResilientBridge, owner, repo, resource string, query url.Values, opts *CountOptions) (int, error) {
+	if active, err := countable(sdk, owner, repo, opts); !active || err != nil {
+		return 0, err
+	}
+	return countRepoREST(sdk, owner, repo, resource, query)
+}
+
+// countRepoGraphQL counts an item of owner/repo with query, whose count pick finds in the result; rest
+// counts it when GraphQL reports errors for the query or pick finds nothing. The shared behavior described
+// in the file header applies.
+func countRepoGraphQL(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions, query string, vars map[string]any, pick func(r *countRepository) *totalCount, rest func() (int, error)) (int, error) {
+	if active, err := countable(sdk, owner, repo, opts); !active || err != nil {
+		return 0, err
+	}
+	if opts != nil && opts.UseREST {
+		return rest()
+	}
+
+	params := map[string]any{"owner": owner, "name": repo}
+	for k, v := range vars {
+		params[k] = v
+	}
+	var data struct {
+		Repository *countRepository `json:"repository"`
+	}
+	err := GraphQL(sdk, query, params, &data)
+	var gqlErrs GraphQLErrors
+	if err != nil && !errors.As(err, &gqlErrs) {
+		return 0, fmt.Errorf("error counting with GraphQL in %s/%s: %w", owner, repo, err)
+	}
+	if err == nil && data.Repository != nil {
+		if count := pick(data.Repository); count != nil {
+			return count.TotalCount, nil
+		}
+	}
+	return rest()
+}
+
+// countable reports whether owner/repo is to be counted: it is active, or opts.SkipActiveCheck is set.
+func countable(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (bool, error) {
+	if opts != nil && opts.SkipActiveCheck {
+		return true, nil
+	}
+	return IsRepoActive(sdk, owner, repo)
+}
+
+// countRepoREST counts /repos/{owner}/{repo}/{resource}?{query} with the Link method. Empty repositories
+// count as 0.
+func countRepoREST(sdk *resilientbridge.ResilientBridge, owner, repo, resource string, query url.Values) (int, error) {
+	endpoint := repoEndpoint(owner, repo) + "/" + resource
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	count, err := CountEndpoint(sdk, endpoint)
+	if isEmptyRepoError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error counting %s of %s/%s: %w", resource, owner, repo, err)
+	}
+	return count, nil
+}
+
+// CountEndpoint returns the number of items behind a GitHub list endpoint (e.g.
+// "/repos/apache/airflow/commits"). It issues a HEAD request first, reading the count from the Link
+// header without downloading a body; if the server rejects HEAD or omits the Link header (single-page
+// results), it falls back to CountEndpointGet.
+func CountEndpoint(sdk *resilientbridge.ResilientBridge, endpoint string) (int, error) {
+	req, err := newCountRequest("HEAD", endpoint)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := sdk.Request(ProviderName, req)
+	if err != nil {
+		var httpErr *resilientbridge.HTTPError
+		if errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusMethodNotAllowed || httpErr.StatusCode == http.StatusNotImplemented) {
+			return CountEndpointGet(sdk, endpoint)
+		}
+		return 0, err
+	}
+
+	if link := resp.Headers["link"]; link != "" {
+		return ParseLastPage(link)
+	}
+	return CountEndpointGet(sdk, endpoint)
+}
+
+// CountEndpointGet is CountEndpoint using GET only. Without a Link header the result fits on a single
+// page, so the returned array is counted directly.
+func CountEndpointGet(sdk *resilientbridge.ResilientBridge, endpoint string) (int, error) {
+	req, err := newCountRequest("GET", endpoint)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := sdk.Request(ProviderName, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if link := resp.Headers["link"]; link != "" {
+		return ParseLastPage(link)
+	}
+
+	n, err := resp.JSONArrayLen()
+	if err != nil {
+		return 0, fmt.Errorf("unexpected response for %s: %w", endpoint, err)
+	}
+	return n, nil
+}
+
+// ParseLastPage extracts the page number of the rel="last" link in a Link header, as
+// resilientbridge.LinkPageCount does for a response. A header without a rel="last" entry means there is
+// only one page.
+func ParseLastPage(linkHeader string) (int, error) {
+	return resilientbridge.LinkPageCount(&resilientbridge.NormalizedResponse{Headers: map[string]string{"link": linkHeader}})
+}
+
+// newCountRequest builds a request for endpoint with per_page forced to 1, so the
+// last page number equals the total number of items.
+func newCountRequest(method, endpoint string) (*resilientbridge.NormalizedRequest, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	q := u.Query()
+	q.Set("per_page", "1")
+	q.Del("page")
+	u.RawQuery = q.Encode()
+	return newRequest(method, u.String()), nil
+}
@@ -0,0 +1,67 @@
+// default_branch.go
+// -----------------
+// This file provides DefaultBranch, which resolves the name of a repository's default branch. Not every
+// repository uses "main" (older ones default to "master", others to "develop" or "trunk"), so the name
+// is never guessed: it comes from the repository's default_branch, or, when the REST response leaves it
+// empty, from GraphQL's defaultBranchRef. A repository with neither (one without any commits) yields
+// ErrNoDefaultBranch.
+//
+// Resolved names are cached per SDK for DefaultBranchTTL (see cache.go), so the helpers that need the branch of many
+// repositories (EnrichMetrics) look each one up once.
+package github
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// DefaultBranchTTL is how long DefaultBranch remembers a repository's default branch.
+var DefaultBranchTTL = 30 * time.Minute
+
+// ErrNoDefaultBranch is returned by DefaultBranch for repositories without a default branch.
+var ErrNoDefaultBranch = errors.New("repository has no default branch")
+
+const defaultBranchQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    defaultBranchRef { name }
+  }
+}`
+
+// DefaultBranch returns the name of owner/repo's default branch.
+func DefaultBranch(sdk *resilientbridge.ResilientBridge, owner, repo string) (string, error) {
+	key := owner + "/" + repo
+	cache := cacheFor[string](sdk, defaultBranchCacheKey)
+	if name, ok := cache.get(key, DefaultBranchTTL); ok {
+		return name, nil
+	}
+
+	r, err := GetRepository(sdk, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	name := r.DefaultBranch
+	if name == "" {
+		var data struct {
+			Repository struct {
+				DefaultBranchRef *struct {
+					Name string `json:"name"`
+				} `json:"defaultBranchRef"`
+			} `json:"repository"`
+		}
+		if err := GraphQL(sdk, defaultBranchQuery, map[string]any{"owner": owner, "name": repo}, &data); err != nil {
+			return "", fmt.Errorf("error fetching default branch of %s/%s: %w", owner, repo, err)
+		}
+		if ref := data.Repository.DefaultBranchRef; ref != nil {
+			name = ref.Name
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s/%s: %w", owner, repo, ErrNoDefaultBranch)
+	}
+
+	cache.set(key, name, DefaultBranchTTL)
+	return name, nil
+}
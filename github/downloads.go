@@ -0,0 +1,173 @@
+// downloads.go
+// ------------
+// This file provides DownloadArtifact and DownloadRunLogs, which stream a workflow artifact or a run's
+// logs (both zip archives) to an io.Writer. GitHub answers these endpoints with a 302 to a short-lived
+// signed storage URL; the requests set FollowLocationStripAuth, so the redirect is followed without the
+// GitHub token.
+//
+// The SDK retries failures before a download starts. If the connection drops mid-stream, the download
+// is resumed from the bytes already written by repeating the request with a Range header, up to
+// DownloadRetries times in a row without progress, waiting longer after each failure (multiples of the
+// provider's BaseBackoff, on its Clock). Storage that ignores Range (answering 200 instead of 206) is
+// handled by skipping the bytes already written. Cancelling ctx stops a download at once, mid-stream or
+// between attempts.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// DownloadRetries is how many consecutive interrupted attempts a download tolerates before failing.
+var DownloadRetries = 3
+
+// DownloadArtifact streams the zip archive of workflow artifact artifactID of owner/repo to w.
+func DownloadArtifact(ctx context.Context, sdk *resilientbridge.ResilientBridge, owner, repo string, artifactID int64, w io.Writer) error {
+	endpoint := fmt.Sprintf("%s/actions/artifacts/%d/zip", repoEndpoint(owner, repo), artifactID)
+	if err := Download(ctx, sdk, endpoint, w); err != nil {
+		return fmt.Errorf("error downloading artifact %d: %w", artifactID, err)
+	}
+	return nil
+}
+
+// DownloadRunLogs streams the zip archive of the logs of workflow run runID of owner/repo to w.
+func DownloadRunLogs(ctx context.Context, sdk *resilientbridge.ResilientBridge, owner, repo string, runID int64, w io.Writer) error {
+	endpoint := fmt.Sprintf("%s/actions/runs/%d/logs", repoEndpoint(owner, repo), runID)
+	if err := Download(ctx, sdk, endpoint, w); err != nil {
+		return fmt.Errorf("error downloading logs of run %d: %w", runID, err)
+	}
+	return nil
+}
+
+// Download streams the body of endpoint to w with the resumption described in the file header.
+// endpoint may be a path or a full URL as returned by the API, such as an artifact's
+// archive_download_url or a run's logs_url.
+func Download(ctx context.Context, sdk *resilientbridge.ResilientBridge, endpoint string, w io.Writer) error {
+	if u, err := url.Parse(endpoint); err == nil && u.IsAbs() && u.Host != "api.github.com" {
+		return fmt.Errorf("not a GitHub API URL: %s", endpoint)
+	}
+
+	var written int64
+	failures := 0
+	for {
+		n, err := downloadFrom(ctx, sdk, endpoint, written, w)
+		written += n
+		if err == nil {
+			return nil
+		}
+		if !resumable(err) || ctx.Err() != nil {
+			return err
+		}
+		if n > 0 {
+			failures = 0
+		}
+		failures++
+		if failures > DownloadRetries {
+			return fmt.Errorf("download interrupted after %d bytes: %w", written, err)
+		}
+		if err := downloadWait(ctx, sdk, failures); err != nil {
+			return err
+		}
+	}
+}
+
+// downloadWait waits failures times the provider's BaseBackoff before a resumed attempt, on the
+// provider's Clock, returning early with ctx.Err() if ctx is cancelled.
+func downloadWait(ctx context.Context, sdk *resilientbridge.ResilientBridge, failures int) error {
+	base := resilientbridge.DefaultBaseBackoff
+	var clock resilientbridge.Clock
+	if config := sdk.GetProviderConfig(ProviderName); config != nil {
+		switch {
+		case config.BaseBackoff < 0:
+			base = 0
+		case config.BaseBackoff > 0:
+			base = config.BaseBackoff
+		}
+		clock = config.Clock
+	}
+	wait := time.Duration(failures) * base
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	var after <-chan time.Time
+	if clock != nil {
+		after = clock.After(wait)
+	} else {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		after = timer.C
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-after:
+		return nil
+	}
+}
+
+// downloadFrom requests endpoint starting at byte offset and copies the body to w, returning the number
+// of bytes written.
+func downloadFrom(ctx context.Context, sdk *resilientbridge.ResilientBridge, endpoint string, offset int64, w io.Writer) (int64, error) {
+	req := newRequest("GET", endpoint)
+	req.FollowLocationStripAuth = true
+	if offset > 0 {
+		req.Headers["Range"] = "bytes=" + strconv.FormatInt(offset, 10) + "-"
+	}
+	stream, err := sdk.RequestStream(ctx, ProviderName, req)
+	if stream != nil {
+		defer stream.Body.Close()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if offset > 0 && stream.StatusCode != http.StatusPartialContent {
+		// Range was ignored: the body starts from the beginning again
+		if _, err := io.CopyN(io.Discard, stream.Body, offset); err != nil {
+			return 0, err
+		}
+	}
+	return io.Copy(&downloadWriter{w: w}, stream.Body)
+}
+
+// downloadWriter marks errors from the destination writer, so they are not mistaken for a broken
+// connection.
+type downloadWriter struct {
+	w io.Writer
+}
+
+type writeError struct {
+	err error
+}
+
+func (e *writeError) Error() string { return e.err.Error() }
+func (e *writeError) Unwrap() error { return e.err }
+
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	if err != nil {
+		err = &writeError{err: err}
+	}
+	return n, err
+}
+
+// resumable reports whether a failed download attempt is worth resuming: the connection broke while
+// the body was being read. Errors the SDK already gave up on (HTTP errors, rejected credentials), write
+// errors, and exceeding MaxResponseBytes are final.
+func resumable(err error) bool {
+	var httpErr *resilientbridge.HTTPError
+	var writeErr *writeError
+	switch {
+	case errors.As(err, &httpErr), errors.As(err, &writeErr), errors.Is(err, resilientbridge.ErrResponseTooLarge), errors.Is(err, context.Canceled):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+// enrich.go
+// ---------
+// This file provides EnrichMetrics, which fills in the RepoMetrics of many repositories at once. Each
+// repository needs six counts (commits, issues, pull requests, branches, tags, releases); EnrichMetrics
+// runs all of them for all repositories through a fixed number of workers instead of one after another.
+// Every count is an ordinary SDK request, so the provider's rate limits, retries, and MaxConcurrency
+// slots apply as usual: concurrency only bounds the goroutines, the SDK still paces the requests.
+//
+// Commits are counted on each repository's default branch, named explicitly (from RepoDetail's
+// DefaultBranch, or DefaultBranch when that is empty) rather than assumed to be "main".
+//
+// A failed count does not stop the batch. The failures of each repository are returned together as a
+// *RepoError, and that repository's other counts are still filled in.
+package github
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// DefaultEnrichConcurrency is the number of workers EnrichMetrics uses when neither its concurrency
+// argument nor the provider's MaxConcurrency is set.
+const DefaultEnrichConcurrency = 4
+
+// RepoMetrics are the item counts of a repository, as filled in by EnrichMetrics.
+type RepoMetrics struct {
+	Commits      int `json:"commits"` // On the default branch
+	Issues       int `json:"issues"`  // Not including pull requests
+	PullRequests int `json:"pull_requests"`
+	Branches     int `json:"branches"`
+	Tags         int `json:"tags"`
+	Releases     int `json:"releases"`
+}
+
+// RepoError is the failure of one repository in a batch operation such as EnrichMetrics.
+type RepoError struct {
+	Owner string
+	Repo  string
+	Err   error
+}
+
+func (e *RepoError) Error() string {
+	return fmt.Sprintf("%s/%s: %v", e.Owner, e.Repo, e.Err)
+}
+
+func (e *RepoError) Unwrap() error {
+	return e.Err
+}
+
+// repoCounters are the counts EnrichMetrics runs for each repository.
+var repoCounters = []struct {
+	name     string
+	count    func(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *CountOptions) (int, error)
+	field    func(m *RepoMetrics) *int
+	onBranch bool // Counted on the default branch, passed as CountOptions.Ref
+}{
+	{"commits", CountCommits, func(m *RepoMetrics) *int { return &m.Commits }, true},
+	{"issues", CountIssues, func(m *RepoMetrics) *int { return &m.Issues }, false},
+	{"pull requests", CountPullRequests, func(m *RepoMetrics) *int { return &m.PullRequests }, false},
+	{"branches", CountBranches, func(m *RepoMetrics) *int { return &m.Branches }, false},
+	{"tags", CountTags, func(m *RepoMetrics) *int { return &m.Tags }, false},
+	{"releases", CountReleases, func(m *RepoMetrics) *int { return &m.Releases }, false},
+}
+
+// repoBranch returns r's default branch, looking it up when the listing left it empty.
+func repoBranch(sdk *resilientbridge.ResilientBridge, r *RepoDetail) (string, error) {
+	if r.DefaultBranch != "" {
+		return r.DefaultBranch, nil
+	}
+	return DefaultBranch(sdk, r.Owner.Login, r.Name)
+}
+
+// EnrichMetrics counts the commits, issues, pull requests, branches, tags, and releases of every
+// repository in repos and stores them in its Metrics. Archived and disabled repositories get zero
+// counts without any requests. concurrency bounds the counts in flight; 0 uses the provider's
+// MaxConcurrency, or DefaultEnrichConcurrency. The returned errors, one per failed repository, are
+// nil when every count succeeded.
+func EnrichMetrics(sdk *resilientbridge.ResilientBridge, repos []*RepoDetail, concurrency int) []*RepoError {
+	if concurrency <= 0 {
+		if config := sdk.GetProviderConfig(ProviderName); config != nil {
+			concurrency = config.MaxConcurrency
+		}
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultEnrichConcurrency
+	}
+
+	type task struct {
+		repo    int
+		counter int
+	}
+	tasks := make(chan task)
+	failures := make([][]error, len(repos))
+	var mu sync.Mutex
+
+	for _, r := range repos {
+		r.Metrics = &RepoMetrics{}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				r, counter := repos[t.repo], repoCounters[t.counter]
+				opts := &CountOptions{SkipActiveCheck: true}
+				var err error
+				if counter.onBranch {
+					opts.Ref, err = repoBranch(sdk, r)
+					if errors.Is(err, ErrNoDefaultBranch) {
+						// No branch, no commits
+						continue
+					}
+				}
+				n := 0
+				if err == nil {
+					n, err = counter.count(sdk, r.Owner.Login, r.Name, opts)
+				}
+				if err != nil {
+					mu.Lock()
+					failures[t.repo] = append(failures[t.repo], fmt.Errorf("counting %s: %w", counter.name, err))
+					mu.Unlock()
+					continue
+				}
+				// Each task writes a different field, so no lock is needed
+				*counter.field(r.Metrics) = n
+			}
+		}()
+	}
+	for i, r := range repos {
+		if r.Archived || r.Disabled {
+			continue
+		}
+		for c := range repoCounters {
+			tasks <- task{repo: i, counter: c}
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	var errs []*RepoError
+	for i, repoErrs := range failures {
+		if len(repoErrs) > 0 {
+			errs = append(errs, &RepoError{Owner: repos[i].Owner.Login, Repo: repos[i].Name, Err: errors.Join(repoErrs...)})
+		}
+	}
+	return errs
+}
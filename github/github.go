@@ -0,0 +1,62 @@
+// github.go
+// ---------
+// Package github provides typed helpers for common GitHub API calls, built on a resilient-bridge SDK
+// with the GitHub adapter registered under ProviderName. Every call goes through the SDK, so GitHub's
+// rate limits, retries, and pagination are handled the same way as for hand-built requests.
+//
+// Typical setup:
+//
+//	sdk := resilientbridge.NewResilientBridge()
+//	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter(token), &resilientbridge.ProviderConfig{
+//		UseProviderLimits: true,
+//		MaxRetries:        3,
+//	})
+//	commits, err := github.ListCommits(sdk, "apache", "airflow", &github.ListCommitsOptions{MaxCommits: 250})
+package github
+
+import (
+	"context"
+	"fmt"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// ProviderName is the name the GitHub adapter must be registered under for this package's helpers.
+const ProviderName = "github"
+
+const acceptHeader = "application/vnd.github+json"
+
+// User is the GitHub account summary embedded in many API objects.
+type User struct {
+	Login   string `json:"login"`
+	ID      int64  `json:"id"`
+	NodeID  string `json:"node_id"`
+	HTMLURL string `json:"html_url"`
+	Type    string `json:"type"`
+}
+
+// newRequest returns a request for endpoint with the GitHub JSON media type.
+func newRequest(method, endpoint string) *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{
+		Method:   method,
+		Endpoint: endpoint,
+		Headers:  map[string]string{"Accept": acceptHeader},
+	}
+}
+
+// getJSON sends a GET for endpoint and decodes the response body into out.
+func getJSON(ctx context.Context, sdk *resilientbridge.ResilientBridge, endpoint string, out interface{}) error {
+	resp, err := sdk.RequestWithContext(ctx, ProviderName, newRequest("GET", endpoint))
+	if err != nil {
+		return err
+	}
+	return decode(resp, out)
+}
+
+// decode unmarshals a JSON response body into out.
+func decode(resp *resilientbridge.NormalizedResponse, out interface{}) error {
+	if err := resp.JSON(out); err != nil {
+		return fmt.Errorf("error decoding GitHub response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// graphql.go
+// ----------
+// This file provides GraphQL, a typed helper for GitHub's GraphQL API (POST /graphql). The GitHub adapter
+// tracks these calls as the "graphql" request type, so they are paced against the GraphQL point budget
+// separately from REST.
+//
+// GraphQL reports most failures with a 200 status and an "errors" array in the body; GraphQL turns those
+// into a GraphQLErrors value. When a response carries both data and errors (partial results), data is
+// still decoded into out before the errors are returned.
+// The exception is a rate limit (an error of type RATE_LIMITED or MAX_NODE_LIMIT_EXCEEDED): the adapter
+// reports it like a 429, so the SDK waits and retries, and GraphQL returns a *resilientbridge.RateLimitError
+// once retries run out.
+//
+// Queries are marked NormalizedRequest.Idempotent, so the SDK retries them after server errors even
+// though they are POSTs; mutations are not retried.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// GraphQLError is one entry of a GraphQL response's "errors" array.
+type GraphQLError struct {
+	Type      string        `json:"type"`
+	Message   string        `json:"message"`
+	Path      []interface{} `json:"path"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations"`
+}
+
+func (e GraphQLError) Error() string {
+	if e.Type != "" {
+		return e.Type + ": " + e.Message
+	}
+	return e.Message
+}
+
+// GraphQLErrors is returned by GraphQL when the response contains errors.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "github graphql: " + strings.Join(msgs, "; ")
+}
+
+// GraphQLRateLimit is the "rateLimit" object a query can request to learn its cost:
+//
+//	rateLimit { cost limit remaining resetAt }
+type GraphQLRateLimit struct {
+	Cost      int       `json:"cost"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// GraphQL runs query with vars and unmarshals the response's "data" into out (which may be nil).
+func GraphQL(sdk *resilientbridge.ResilientBridge, query string, vars map[string]any, out any) error {
+	_, err := GraphQLWithCost(sdk, query, vars, out)
+	return err
+}
+
+// GraphQLWithCost is GraphQL that also returns the query's rateLimit object, or nil if the query did
+// not request one.
+func GraphQLWithCost(sdk *resilientbridge.ResilientBridge, query string, vars map[string]any, out any) (*GraphQLRateLimit, error) {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding GraphQL request: %w", err)
+	}
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "POST",
+		Endpoint: "/graphql",
+		Headers:  map[string]string{"Content-Type": "application/json"},
+		Body:     body,
+
+		// Queries only read, so they stay retryable on server errors; mutations do not
+		Idempotent: !isMutation(query),
+	}
+	resp, err := sdk.RequestWithContext(context.Background(), ProviderName, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := decode(resp, &result); err != nil {
+		return nil, err
+	}
+
+	var cost struct {
+		RateLimit *GraphQLRateLimit `json:"rateLimit"`
+	}
+	if len(result.Data) > 0 && string(result.Data) != "null" {
+		if out != nil {
+			if err := json.Unmarshal(result.Data, out); err != nil {
+				return nil, fmt.Errorf("error decoding GraphQL data: %w", err)
+			}
+		}
+		// A missing or malformed rateLimit field just means no cost information
+		_ = json.Unmarshal(result.Data, &cost)
+	}
+	if len(result.Errors) > 0 {
+		return cost.RateLimit, result.Errors
+	}
+	return cost.RateLimit, nil
+}
+
+// isMutation reports whether query is a GraphQL mutation rather than a query.
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
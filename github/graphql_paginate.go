@@ -0,0 +1,87 @@
+// graphql_paginate.go
+// -------------------
+// This file provides GraphQLPaginate, which walks a GraphQL cursor connection
+// (pageInfo { hasNextPage endCursor }) page by page. Each page is a single round trip, which makes it
+// the cheaper way to read large collections than REST listings with 100 items per request.
+//
+// The query must declare an $after: String variable and pass it to the connection, and select
+// pageInfo { hasNextPage endCursor } on it. Selecting rateLimit { cost remaining resetAt } lets
+// GraphQLPaginate pause until the reset when the remaining points cannot pay for another page.
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// GraphQLPaginate runs query with vars, passing each page's connection object (found by following
+// connectionPath in "data", e.g. []string{"repository", "issues"}) to out, and fetches the next page
+// by setting the "after" variable to the connection's endCursor until hasNextPage is false or out
+// returns an error.
+func GraphQLPaginate(sdk *resilientbridge.ResilientBridge, query string, vars map[string]any, connectionPath []string, out func(page json.RawMessage) error) error {
+	pageVars := make(map[string]any, len(vars)+1)
+	for k, v := range vars {
+		pageVars[k] = v
+	}
+
+	for {
+		var data json.RawMessage
+		cost, err := GraphQLWithCost(sdk, query, pageVars, &data)
+		if err != nil {
+			return err
+		}
+		conn, err := graphQLField(data, connectionPath)
+		if err != nil {
+			return err
+		}
+
+		var page struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		}
+		if err := json.Unmarshal(conn, &page); err != nil {
+			return fmt.Errorf("error decoding GraphQL connection: %w", err)
+		}
+		if err := out(conn); err != nil {
+			return err
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return nil
+		}
+		pageVars["after"] = page.PageInfo.EndCursor
+
+		if cost != nil && cost.Remaining < cost.Cost {
+			if wait := time.Until(cost.ResetAt); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+	}
+}
+
+// graphQLField follows path through nested JSON objects in data.
+func graphQLField(data json.RawMessage, path []string) (json.RawMessage, error) {
+	current := data
+	for i, key := range path {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(current, &fields); err != nil || fields == nil {
+			return nil, fmt.Errorf("GraphQL response has no object at %s", graphQLFieldPath(path[:i]))
+		}
+		next, ok := fields[key]
+		if !ok || string(next) == "null" {
+			return nil, fmt.Errorf("GraphQL response has no field %s", graphQLFieldPath(path[:i+1]))
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// graphQLFieldPath renders path for error messages, e.g. "data.repository.issues".
+func graphQLFieldPath(path []string) string {
+	return strings.Join(append([]string{"data"}, path...), ".")
+}
@@ -0,0 +1,174 @@
+// org_repos.go
+// ------------
+// This file provides ListOrgRepos, which lists an organization's repositories with GitHub's filters
+// (type, sort, direction) and follows every page up to an optional maximum. Each repository is returned
+// as a RepoDetail: the Repository fields plus the settings, counters, and URLs the list endpoint includes.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// RepoDetail is a repository as returned by GitHub's repository list endpoints and GET /repos/{owner}/{repo}.
+// Fields only sent by the single-repository endpoint (Parent, Source, Organization, SubscribersCount,
+// NetworkCount, SecurityAndAnalysis) are empty in listings.
+type RepoDetail struct {
+	Repository
+
+	Visibility   string       `json:"visibility"` // "public", "private", or "internal"
+	Language     string       `json:"language"`   // Primary language; "" if GitHub detected none
+	Topics       []string     `json:"topics"`
+	Homepage     string       `json:"homepage"`
+	License      *License     `json:"license"`
+	Permissions  *Permissions `json:"permissions"` // The token's permissions on the repository
+	Organization *User        `json:"organization"`
+	Parent       *RepoDetail  `json:"parent"` // The repository this fork was forked from
+	Source       *RepoDetail  `json:"source"` // The root of the fork network
+	UpdatedAt    time.Time    `json:"updated_at"`
+	IsTemplate   bool         `json:"is_template"`
+	MirrorURL    string       `json:"mirror_url"`
+	Size         int          `json:"size"` // In KB; 0 for empty repositories
+
+	GitURL   string `json:"git_url"`
+	SSHURL   string `json:"ssh_url"`
+	CloneURL string `json:"clone_url"`
+	SVNURL   string `json:"svn_url"`
+
+	AllowForking   bool `json:"allow_forking"`
+	HasIssues      bool `json:"has_issues"`
+	HasProjects    bool `json:"has_projects"`
+	HasWiki        bool `json:"has_wiki"`
+	HasPages       bool `json:"has_pages"`
+	HasDownloads   bool `json:"has_downloads"`
+	HasDiscussions bool `json:"has_discussions"`
+
+	StargazersCount  int `json:"stargazers_count"`
+	WatchersCount    int `json:"watchers_count"`
+	ForksCount       int `json:"forks_count"`
+	OpenIssuesCount  int `json:"open_issues_count"` // Open issues and pull requests
+	SubscribersCount int `json:"subscribers_count"`
+	NetworkCount     int `json:"network_count"`
+
+	SecurityAndAnalysis *SecurityAndAnalysis `json:"security_and_analysis"`
+
+	Metrics *RepoMetrics `json:"metrics,omitempty"` // Set by EnrichMetrics; not part of GitHub's response
+}
+
+// License is the license GitHub detected for a repository.
+type License struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id"`
+	URL    string `json:"url"`
+	NodeID string `json:"node_id"`
+}
+
+// Permissions are the token's permissions on a repository.
+type Permissions struct {
+	Admin    bool `json:"admin"`
+	Maintain bool `json:"maintain"`
+	Push     bool `json:"push"`
+	Triage   bool `json:"triage"`
+	Pull     bool `json:"pull"`
+}
+
+// SecurityAndAnalysis holds the status ("enabled" or "disabled") of a repository's security features.
+// GitHub only sends it to tokens with admin access.
+type SecurityAndAnalysis struct {
+	AdvancedSecurity                  *FeatureStatus `json:"advanced_security"`
+	SecretScanning                    *FeatureStatus `json:"secret_scanning"`
+	SecretScanningPushProtection      *FeatureStatus `json:"secret_scanning_push_protection"`
+	SecretScanningNonProviderPatterns *FeatureStatus `json:"secret_scanning_non_provider_patterns"`
+	SecretScanningValidityChecks      *FeatureStatus `json:"secret_scanning_validity_checks"`
+	DependabotSecurityUpdates         *FeatureStatus `json:"dependabot_security_updates"`
+}
+
+// FeatureStatus is the status of one security feature.
+type FeatureStatus struct {
+	Status string `json:"status"`
+}
+
+// Enabled reports whether the feature is present and enabled.
+func (f *FeatureStatus) Enabled() bool {
+	return f != nil && f.Status == "enabled"
+}
+
+// ListReposOptions filters, orders, and bounds ListOrgRepos. The zero value lists every repository
+// in GitHub's default order (by creation date, oldest first).
+type ListReposOptions struct {
+	Type      string // "all" (default), "public", "private", "forks", "sources", or "member"
+	Sort      string // "created" (default), "updated", "pushed", or "full_name"
+	Direction string // "asc" or "desc"; GitHub defaults to "asc" for full_name and "desc" otherwise
+	Max       int    // Stop after this many repositories; 0 means no limit
+}
+
+// errEnoughRepos stops pagination once Max repositories have been collected.
+var errEnoughRepos = errors.New("enough repositories")
+
+// GetRepoDetail returns the repository owner/repo with everything GET /repos/{owner}/{repo} sends.
+func GetRepoDetail(sdk *resilientbridge.ResilientBridge, owner, repo string) (*RepoDetail, error) {
+	req := NewRequest("GET", repoEndpoint(owner, repo), WithAccept(Preview("topics")))
+	resp, err := sdk.RequestWithContext(context.Background(), ProviderName, req)
+	var r RepoDetail
+	if err == nil {
+		err = decode(resp, &r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching repository %s/%s: %w", owner, repo, err)
+	}
+	rememberRepoStatus(sdk, owner+"/"+repo, !r.Archived && !r.Disabled)
+	return &r, nil
+}
+
+// ListOrgRepos returns the repositories of org visible to the token, following every page until
+// opts.Max repositories have been collected.
+func ListOrgRepos(sdk *resilientbridge.ResilientBridge, org string, opts ListReposOptions) ([]RepoDetail, error) {
+	perPage := 100
+	if opts.Max > 0 && opts.Max < perPage {
+		perPage = opts.Max
+	}
+	q := url.Values{}
+	q.Set("per_page", strconv.Itoa(perPage))
+	if opts.Type != "" {
+		q.Set("type", opts.Type)
+	}
+	if opts.Sort != "" {
+		q.Set("sort", opts.Sort)
+	}
+	if opts.Direction != "" {
+		q.Set("direction", opts.Direction)
+	}
+	req := NewRequest("GET", "/orgs/"+url.PathEscape(org)+"/repos?"+q.Encode(), WithAccept(Preview("topics")))
+
+	var repos []RepoDetail
+	err := sdk.Paginate(context.Background(), ProviderName, req, nil, func(resp *resilientbridge.NormalizedResponse) error {
+		var page []RepoDetail
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		for _, r := range page {
+			if r.FullName != "" {
+				rememberRepoStatus(sdk, r.FullName, !r.Archived && !r.Disabled)
+			}
+		}
+		repos = append(repos, page...)
+		if opts.Max > 0 && len(repos) >= opts.Max {
+			return errEnoughRepos
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errEnoughRepos) {
+		return nil, fmt.Errorf("error listing repositories of %s: %w", org, err)
+	}
+	if opts.Max > 0 && len(repos) > opts.Max {
+		repos = repos[:opts.Max]
+	}
+	return repos, nil
+}
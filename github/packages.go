@@ -0,0 +1,239 @@
+// packages.go
+// -----------
+// This file provides ListPackageVersions and ResolvePackageVersionDigests for GitHub Packages. For
+// container packages, GitHub names each version after its manifest digest ("sha256:..."), and the
+// version's metadata lists the tags pointing at it, so ResolvePackageVersionDigests gets every digest and
+// tag from the package API alone, without pulling each tag's manifest from the registry.
+//
+// The registry (ghcr.io) is only asked when a version's name is not a digest, or when the manifest itself
+// is wanted (FetchContainerManifest). The GitHub adapter never sends the API token to ghcr.io; the
+// registry is accessed with an anonymous pull token, or one obtained with a registry token passed to
+// FetchContainerManifest.
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// ContainerRegistryHost is the registry GitHub's container packages are served from.
+const ContainerRegistryHost = "ghcr.io"
+
+// manifestAccept lists the manifest media types asked of the registry, most specific first.
+const manifestAccept = "application/vnd.oci.image.index.v1+json, application/vnd.oci.image.manifest.v1+json, " +
+	"application/vnd.docker.distribution.manifest.list.v2+json, application/vnd.docker.distribution.manifest.v2+json"
+
+// ContainerMetadata is the container-specific part of a package version's metadata.
+type ContainerMetadata struct {
+	Container struct {
+		Tags []string `json:"tags"`
+	} `json:"container"`
+}
+
+// PackageVersion is a package version as returned by GET /orgs/{org}/packages/{type}/{name}/versions.
+// For container packages, Name is the version's manifest digest.
+type PackageVersion struct {
+	ID             int64             `json:"id"`
+	Name           string            `json:"name"`
+	PackageHTMLURL string            `json:"package_html_url"`
+	HTMLURL        string            `json:"html_url"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	Metadata       ContainerMetadata `json:"metadata"`
+}
+
+// OutputVersion is one distinct image (digest) of a container package. PackageURI is its first tag (or
+// name@digest when it has none), and AdditionalPackageURIs its other tags. MediaType, TotalSize, and
+// Manifest are only set when the manifest was fetched from the registry.
+type OutputVersion struct {
+	ID                    int64             `json:"id"`
+	Digest                string            `json:"digest"`
+	PackageURI            string            `json:"package_uri"`
+	AdditionalPackageURIs []string          `json:"additional_package_uris,omitempty"`
+	PackageHTMLURL        string            `json:"package_html_url"`
+	CreatedAt             time.Time         `json:"created_at"`
+	UpdatedAt             time.Time         `json:"updated_at"`
+	HTMLURL               string            `json:"html_url"`
+	Name                  string            `json:"name"`
+	MediaType             string            `json:"media_type,omitempty"`
+	TotalSize             int64             `json:"total_size,omitempty"`
+	Metadata              ContainerMetadata `json:"metadata"`
+	Manifest              json.RawMessage   `json:"manifest,omitempty"`
+}
+
+// ContainerManifest is a manifest fetched from the container registry.
+type ContainerManifest struct {
+	Digest    string          // From Docker-Content-Digest, or computed from the body
+	MediaType string          // The manifest's media type
+	TotalSize int64           // Size of the config and layers; 0 for image indexes
+	Raw       json.RawMessage // The manifest as served
+}
+
+// ListPackageVersions returns every version of org's package packageName of type packageType
+// ("container", "npm", "maven", ...).
+func ListPackageVersions(sdk *resilientbridge.ResilientBridge, org, packageType, packageName string) ([]PackageVersion, error) {
+	endpoint := fmt.Sprintf("/orgs/%s/packages/%s/%s/versions?per_page=100",
+		url.PathEscape(org), url.PathEscape(packageType), url.PathEscape(packageName))
+
+	var versions []PackageVersion
+	err := sdk.Paginate(context.Background(), ProviderName, newRequest("GET", endpoint), nil, func(resp *resilientbridge.NormalizedResponse) error {
+		var page []PackageVersion
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		versions = append(versions, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing versions of package %s/%s: %w", org, packageName, err)
+	}
+	return versions, nil
+}
+
+// ResolvePackageVersionDigests returns the distinct images of org's container package packageName, in
+// the order GitHub lists its versions (newest first). Digests come from the version names; the registry
+// is only consulted for versions whose name is not a digest. Versions sharing a digest are merged, their
+// tags collected in AdditionalPackageURIs.
+func ResolvePackageVersionDigests(sdk *resilientbridge.ResilientBridge, org, packageName string) ([]OutputVersion, error) {
+	versions, err := ListPackageVersions(sdk, org, "container", packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	image := ContainerRegistryHost + "/" + strings.ToLower(org) + "/" + strings.ToLower(packageName)
+	var results []OutputVersion
+	byDigest := make(map[string]int) // digest -> index in results
+	for _, v := range versions {
+		digest := v.Name
+		var manifest *ContainerManifest
+		if !isDigest(digest) {
+			if manifest, err = FetchContainerManifest(sdk, org, packageName, digest, ""); err != nil {
+				return nil, err
+			}
+			digest = manifest.Digest
+		}
+
+		uris := make([]string, 0, len(v.Metadata.Container.Tags))
+		for _, tag := range v.Metadata.Container.Tags {
+			uris = append(uris, image+":"+tag)
+		}
+		if i, ok := byDigest[digest]; ok {
+			results[i].AdditionalPackageURIs = append(results[i].AdditionalPackageURIs, uris...)
+			continue
+		}
+
+		out := OutputVersion{
+			ID:             v.ID,
+			Digest:         digest,
+			PackageURI:     image + "@" + digest,
+			PackageHTMLURL: v.PackageHTMLURL,
+			CreatedAt:      v.CreatedAt,
+			UpdatedAt:      v.UpdatedAt,
+			HTMLURL:        v.HTMLURL,
+			Metadata:       v.Metadata,
+		}
+		if len(uris) > 0 {
+			out.PackageURI, out.AdditionalPackageURIs = uris[0], uris[1:]
+		}
+		out.Name = out.PackageURI
+		if manifest != nil {
+			out.MediaType, out.TotalSize, out.Manifest = manifest.MediaType, manifest.TotalSize, manifest.Raw
+		}
+		byDigest[digest] = len(results)
+		results = append(results, out)
+	}
+	return results, nil
+}
+
+// FetchContainerManifest fetches the manifest of reference (a tag or digest) of org's container package
+// packageName from ghcr.io. Public packages need no registryToken; for private ones, pass a token with
+// the read:packages scope.
+func FetchContainerManifest(sdk *resilientbridge.ResilientBridge, org, packageName, reference, registryToken string) (*ContainerManifest, error) {
+	repository := strings.ToLower(org) + "/" + strings.ToLower(packageName)
+	token, err := registryPullToken(sdk, repository, registryToken)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "https://" + ContainerRegistryHost + "/v2/" + repository + "/manifests/" + url.PathEscape(reference),
+		Headers:  map[string]string{"Accept": manifestAccept, "Authorization": "Bearer " + token},
+	}
+	resp, err := sdk.RequestWithContext(context.Background(), ProviderName, req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching manifest %s:%s: %w", repository, reference, err)
+	}
+
+	var parsed struct {
+		MediaType string `json:"mediaType"`
+		Config    struct {
+			Size int64 `json:"size"`
+		} `json:"config"`
+		Layers []struct {
+			Size int64 `json:"size"`
+		} `json:"layers"`
+	}
+	if err := decode(resp, &parsed); err != nil {
+		return nil, err
+	}
+	manifest := &ContainerManifest{
+		Digest:    resp.Headers["docker-content-digest"],
+		MediaType: parsed.MediaType,
+		TotalSize: parsed.Config.Size,
+		Raw:       json.RawMessage(resp.Data),
+	}
+	if manifest.Digest == "" {
+		sum := sha256.Sum256(resp.Data)
+		manifest.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = resp.Headers["content-type"]
+	}
+	for _, layer := range parsed.Layers {
+		manifest.TotalSize += layer.Size
+	}
+	return manifest, nil
+}
+
+// registryPullToken returns a ghcr.io bearer token allowing pulls from repository, anonymous unless
+// registryToken is set.
+func registryPullToken(sdk *resilientbridge.ResilientBridge, repository, registryToken string) (string, error) {
+	q := url.Values{}
+	q.Set("scope", "repository:"+repository+":pull")
+	q.Set("service", ContainerRegistryHost)
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "https://" + ContainerRegistryHost + "/token?" + q.Encode(),
+		Headers:  map[string]string{"Accept": "application/json"},
+	}
+	if registryToken != "" {
+		req.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte("token:"+registryToken))
+	}
+	resp, err := sdk.RequestWithContext(context.Background(), ProviderName, req)
+	if err != nil {
+		return "", fmt.Errorf("error getting a registry token for %s: %w", repository, err)
+	}
+	var body struct {
+		Token string `json:"token"`
+	}
+	if err := decode(resp, &body); err != nil {
+		return "", err
+	}
+	return body.Token, nil
+}
+
+// isDigest reports whether s looks like a content digest ("sha256:<hex>").
+func isDigest(s string) bool {
+	algorithm, hash, ok := strings.Cut(s, ":")
+	return ok && algorithm != "" && len(hash) >= 32 && strings.Trim(hash, "0123456789abcdef") == ""
+}
@@ -0,0 +1,68 @@
+// previews.go
+// -----------
+// This file provides Preview and WithAccept for GitHub's preview APIs. Preview features are unlocked by a
+// media type in the Accept header (application/vnd.github.<codename>-preview+json); without it GitHub
+// answers with the stable representation, which silently omits the preview's fields (repository topics,
+// for example, come back empty). Preview maps a feature or its codename to the media type, and
+// WithAccept sets it on a request:
+//
+//	req := github.NewRequest("GET", "/repos/apache/airflow", github.WithAccept(github.Preview("topics")))
+//
+// Repository requests made by this package (GetRepoDetail, ListOrgRepos) ask for the topics preview
+// themselves.
+package github
+
+import (
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// previewCodenames maps preview features to GitHub's codenames for them.
+var previewCodenames = map[string]string{
+	"topics":              "mercy",
+	"reactions":           "squirrel-girl",
+	"repository-template": "baptiste",
+	"checks":              "antiope",
+	"commit-search":       "cloak",
+	"update-branch":       "lydian",
+	"deployment-statuses": "flash",
+	"visibility":          "nebula",
+	"commit-pulls":        "groot",
+	"projects":            "inertia",
+	"package-deletes":     "package-deletes",
+}
+
+// Preview returns the Accept value for a preview feature, given either its name from the table above
+// ("topics") or its codename ("mercy"). Other names are taken as codenames.
+func Preview(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if codename, ok := previewCodenames[name]; ok {
+		name = codename
+	}
+	return "application/vnd.github." + strings.TrimSuffix(name, "-preview") + "-preview+json"
+}
+
+// RequestOption modifies a request built by NewRequest.
+type RequestOption func(req *resilientbridge.NormalizedRequest)
+
+// WithAccept sets the request's Accept header to the given media types, in order of preference. The
+// standard GitHub media type is kept as the last choice, so endpoints outside the preview still answer
+// with JSON.
+func WithAccept(mediaTypes ...string) RequestOption {
+	return func(req *resilientbridge.NormalizedRequest) {
+		if req.Headers == nil {
+			req.Headers = map[string]string{}
+		}
+		req.Headers["Accept"] = strings.Join(append(append([]string(nil), mediaTypes...), acceptHeader), ", ")
+	}
+}
+
+// NewRequest returns a request for endpoint with the GitHub JSON media type, modified by opts.
+func NewRequest(method, endpoint string, opts ...RequestOption) *resilientbridge.NormalizedRequest {
+	req := newRequest(method, endpoint)
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
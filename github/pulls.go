@@ -0,0 +1,115 @@
+// pulls.go
+// --------
+// This file provides the PullRequest type, GetPullRequest, and ListPullRequests. ListPullRequests takes
+// GitHub's filters (state, base, head) and ordering (sort, direction) and follows every page up to an
+// optional maximum.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// PullRequest is a pull request as returned by GitHub's pulls API.
+type PullRequest struct {
+	ID        int64             `json:"id"`
+	NodeID    string            `json:"node_id"`
+	Number    int               `json:"number"`
+	State     string            `json:"state"` // "open" or "closed"
+	Title     string            `json:"title"`
+	HTMLURL   string            `json:"html_url"`
+	User      *User             `json:"user"`
+	Draft     bool              `json:"draft"`
+	Base      PullRequestBranch `json:"base"`
+	Head      PullRequestBranch `json:"head"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	ClosedAt  *time.Time        `json:"closed_at"`
+	MergedAt  *time.Time        `json:"merged_at"` // nil unless the pull request was merged
+}
+
+// PullRequestBranch is the base or head of a PullRequest.
+type PullRequestBranch struct {
+	Label string `json:"label"` // "owner:branch"
+	Ref   string `json:"ref"`
+	SHA   string `json:"sha"`
+}
+
+// GetPullRequest returns pull request number of owner/repo.
+func GetPullRequest(sdk *resilientbridge.ResilientBridge, owner, repo string, number int) (*PullRequest, error) {
+	var pr PullRequest
+	if err := getJSON(context.Background(), sdk, repoEndpoint(owner, repo)+"/pulls/"+strconv.Itoa(number), &pr); err != nil {
+		return nil, fmt.Errorf("error fetching pull request #%d of %s/%s: %w", number, owner, repo, err)
+	}
+	return &pr, nil
+}
+
+// ListPullRequestsOptions filters, orders, and bounds ListPullRequests. The zero value lists the open
+// pull requests, newest first.
+type ListPullRequestsOptions struct {
+	State     string // "open" (default), "closed", or "all"
+	Base      string // Only pull requests into this branch
+	Head      string // Only pull requests from this branch, as "user:branch" or "org:branch"
+	Sort      string // "created" (default), "updated", "popularity", or "long-running"
+	Direction string // "asc" or "desc"; GitHub defaults to "desc" for created and "asc" otherwise
+	Max       int    // Stop after this many pull requests; 0 means no limit
+}
+
+// errEnoughPulls stops pagination once Max pull requests have been collected.
+var errEnoughPulls = errors.New("enough pull requests")
+
+// ListPullRequests returns the pull requests of owner/repo, following every page until opts.Max pull
+// requests have been collected. A nil opts uses the defaults.
+func ListPullRequests(sdk *resilientbridge.ResilientBridge, owner, repo string, opts *ListPullRequestsOptions) ([]PullRequest, error) {
+	if opts == nil {
+		opts = &ListPullRequestsOptions{}
+	}
+	perPage := 100
+	if opts.Max > 0 && opts.Max < perPage {
+		perPage = opts.Max
+	}
+	q := url.Values{}
+	q.Set("per_page", strconv.Itoa(perPage))
+	if opts.State != "" {
+		q.Set("state", opts.State)
+	}
+	if opts.Base != "" {
+		q.Set("base", opts.Base)
+	}
+	if opts.Head != "" {
+		q.Set("head", opts.Head)
+	}
+	if opts.Sort != "" {
+		q.Set("sort", opts.Sort)
+	}
+	if opts.Direction != "" {
+		q.Set("direction", opts.Direction)
+	}
+	req := newRequest("GET", repoEndpoint(owner, repo)+"/pulls?"+q.Encode())
+
+	var pulls []PullRequest
+	err := sdk.Paginate(context.Background(), ProviderName, req, nil, func(resp *resilientbridge.NormalizedResponse) error {
+		var page []PullRequest
+		if err := decode(resp, &page); err != nil {
+			return err
+		}
+		pulls = append(pulls, page...)
+		if opts.Max > 0 && len(pulls) >= opts.Max {
+			return errEnoughPulls
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errEnoughPulls) {
+		return nil, fmt.Errorf("error listing pull requests of %s/%s: %w", owner, repo, err)
+	}
+	if opts.Max > 0 && len(pulls) > opts.Max {
+		pulls = pulls[:opts.Max]
+	}
+	return pulls, nil
+}
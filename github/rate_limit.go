@@ -0,0 +1,103 @@
+// rate_limit.go
+// -------------
+// This file provides GetRateLimit, which reads GET /rate_limit: the limit, remaining quota, and reset time
+// of every rate limit pool of the token. The endpoint does not count against the core quota (the GitHub
+// adapter doesn't count it in its local windows either), so it is safe to poll, e.g. for dashboards.
+//
+// RateLimitSummary.ApplyDefaults feeds the reported limits into the adapter's SetRateLimitDefaultsForType,
+// so a token with higher limits (GitHub Apps, Enterprise Cloud) is paced by its real budget from the start
+// instead of the documented defaults.
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// RateLimitResource is one rate limit pool of a /rate_limit response.
+type RateLimitResource struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Used      int   `json:"used"`
+	Reset     int64 `json:"reset"` // Unix seconds
+}
+
+// ResetTime returns Reset as a time.Time.
+func (r RateLimitResource) ResetTime() time.Time {
+	return time.Unix(r.Reset, 0)
+}
+
+// RateLimitResources holds the pools of a /rate_limit response. Pools GitHub doesn't report for the token
+// (e.g. on GitHub Enterprise Server) are nil.
+type RateLimitResources struct {
+	Core                      *RateLimitResource `json:"core"`
+	Search                    *RateLimitResource `json:"search"`
+	CodeSearch                *RateLimitResource `json:"code_search"`
+	GraphQL                   *RateLimitResource `json:"graphql"`
+	IntegrationManifest       *RateLimitResource `json:"integration_manifest"`
+	CodeScanningUpload        *RateLimitResource `json:"code_scanning_upload"`
+	CodeScanningAutofix       *RateLimitResource `json:"code_scanning_autofix"`
+	DependencySnapshots       *RateLimitResource `json:"dependency_snapshots"`
+	DependencySBOM            *RateLimitResource `json:"dependency_sbom"`
+	AuditLog                  *RateLimitResource `json:"audit_log"`
+	AuditLogStreaming         *RateLimitResource `json:"audit_log_streaming"`
+	SourceImport              *RateLimitResource `json:"source_import"`
+	ActionsRunnerRegistration *RateLimitResource `json:"actions_runner_registration"`
+	SCIM                      *RateLimitResource `json:"scim"`
+}
+
+// RateLimitSummary is the full /rate_limit response. Rate is the core pool again, kept by GitHub for
+// backwards compatibility.
+type RateLimitSummary struct {
+	Resources RateLimitResources `json:"resources"`
+	Rate      RateLimitResource  `json:"rate"`
+}
+
+// GetRateLimit returns the token's rate limit pools from GET /rate_limit.
+func GetRateLimit(sdk *resilientbridge.ResilientBridge) (*RateLimitSummary, error) {
+	var summary RateLimitSummary
+	if err := getJSON(context.Background(), sdk, "/rate_limit", &summary); err != nil {
+		return nil, fmt.Errorf("error fetching rate limits: %w", err)
+	}
+	return &summary, nil
+}
+
+// Pools returns the reported pools keyed by the GitHub adapter's request type ("rest" for core, "graphql",
+// "search", "code_search", ...).
+func (s *RateLimitSummary) Pools() map[string]RateLimitResource {
+	pools := make(map[string]RateLimitResource)
+	for requestType, r := range map[string]*RateLimitResource{
+		"rest":                        s.Resources.Core,
+		"search":                      s.Resources.Search,
+		"code_search":                 s.Resources.CodeSearch,
+		"graphql":                     s.Resources.GraphQL,
+		"integration_manifest":        s.Resources.IntegrationManifest,
+		"code_scanning_upload":        s.Resources.CodeScanningUpload,
+		"code_scanning_autofix":       s.Resources.CodeScanningAutofix,
+		"dependency_snapshots":        s.Resources.DependencySnapshots,
+		"dependency_sbom":             s.Resources.DependencySBOM,
+		"audit_log":                   s.Resources.AuditLog,
+		"audit_log_streaming":         s.Resources.AuditLogStreaming,
+		"source_import":               s.Resources.SourceImport,
+		"actions_runner_registration": s.Resources.ActionsRunnerRegistration,
+		"scim":                        s.Resources.SCIM,
+	} {
+		if r != nil {
+			pools[requestType] = *r
+		}
+	}
+	return pools
+}
+
+// ApplyDefaults sets each reported pool's limit as the adapter's max requests for that request type,
+// with the adapter's default window length. Request types the adapter doesn't track are ignored by it.
+func (s *RateLimitSummary) ApplyDefaults(adapter resilientbridge.ProviderAdapter) {
+	for requestType, pool := range s.Pools() {
+		if pool.Limit > 0 {
+			adapter.SetRateLimitDefaultsForType(requestType, pool.Limit, 0)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// repo_detail_cache.go
+// --------------------
+// This file provides RepoDetailCache, which fetches each repository of an enrichment session once. Commit
+// enrichment places every commit in its repository (ID, node ID, full name), and fetching
+// /repos/{owner}/{repo} again for each commit makes one identical request per commit. A RepoDetailCache is
+// created for the session and shared by its commits: the first lookup of a repository fetches it, and
+// lookups made while that request is in flight wait for it instead of sending their own.
+//
+// The cache is scoped on purpose: it lives as long as the caller keeps it, so a long-running process
+// sees repository changes in its next session. Failed lookups are not cached.
+package github
+
+import (
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// RepoDetailCache remembers the repositories fetched with Get. It is safe for concurrent use.
+type RepoDetailCache struct {
+	sdk *resilientbridge.ResilientBridge
+
+	mu    sync.Mutex
+	repos map[string]*repoLookup // repoStatusKey -> lookup, in flight or done
+}
+
+// repoLookup is one fetch of a repository, shared by the lookups waiting for it.
+type repoLookup struct {
+	done chan struct{}
+	repo *Repository
+	err  error
+}
+
+// NewRepoDetailCache returns an empty RepoDetailCache fetching through sdk.
+func NewRepoDetailCache(sdk *resilientbridge.ResilientBridge) *RepoDetailCache {
+	return &RepoDetailCache{sdk: sdk, repos: make(map[string]*repoLookup)}
+}
+
+// Get returns the repository owner/repo, fetching it with GetRepository unless it was fetched (or is being
+// fetched) already.
+func (c *RepoDetailCache) Get(owner, repo string) (*Repository, error) {
+	key := repoStatusKey(owner + "/" + repo)
+	c.mu.Lock()
+	lookup, ok := c.repos[key]
+	if !ok {
+		lookup = &repoLookup{done: make(chan struct{})}
+		c.repos[key] = lookup
+	}
+	c.mu.Unlock()
+	if ok {
+		<-lookup.done
+		return lookup.repo, lookup.err
+	}
+
+	lookup.repo, lookup.err = GetRepository(c.sdk, owner, repo)
+	if lookup.err != nil {
+		c.mu.Lock()
+		delete(c.repos, key)
+		c.mu.Unlock()
+	}
+	close(lookup.done)
+	return lookup.repo, lookup.err
+}
+
+// GetCommitDetail is GetCommitDetail for a commit whose Repository is looked up in c.
+func (c *RepoDetailCache) GetCommitDetail(owner, repo, sha string) (*CommitDetail, error) {
+	detail, err := GetCommitDetail(c.sdk, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	detail.repos = c
+	return detail, nil
+}
@@ -0,0 +1,143 @@
+// repos.go
+// --------
+// This file provides GetRepository and IsRepoActive. Archived or disabled repositories (and ones that no
+// longer exist) have nothing worth crawling, so the Count* helpers consult IsRepoActive first. Its answer
+// is cached per SDK for RepositoryStatusTTL (see cache.go), so counting several resources of one
+// repository costs a single repository lookup. Repositories already fetched through this package (GetRepository,
+// GetRepoDetail, ListOrgRepos) are cached as they come in, so checking them costs no request at all;
+// pass BypassCache to look a repository up again regardless.
+//
+// It also provides IsEmptyRepoResponse. A newly created repository without commits shows up in org
+// listings like any other, but GitHub answers its git-backed endpoints with 409 Conflict ("Git Repository
+// is empty.") and its contents API with 404 ("This repository is empty."). The list helpers (ListCommits,
+// ListBranches, ListContents, and the Count* helpers) treat those answers as empty results.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// RepositoryStatusTTL is how long IsRepoActive remembers a repository's status.
+var RepositoryStatusTTL = 5 * time.Minute
+
+// Repository is a repository as returned by GET /repos/{owner}/{repo}.
+type Repository struct {
+	ID            int64     `json:"id"`
+	NodeID        string    `json:"node_id"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Owner         User      `json:"owner"`
+	Private       bool      `json:"private"`
+	Fork          bool      `json:"fork"`
+	Archived      bool      `json:"archived"`
+	Disabled      bool      `json:"disabled"`
+	DefaultBranch string    `json:"default_branch"`
+	HTMLURL       string    `json:"html_url"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+	PushedAt      time.Time `json:"pushed_at"`
+}
+
+// GetRepository returns the repository owner/repo.
+func GetRepository(sdk *resilientbridge.ResilientBridge, owner, repo string) (*Repository, error) {
+	var r Repository
+	if err := getJSON(context.Background(), sdk, repoEndpoint(owner, repo), &r); err != nil {
+		return nil, fmt.Errorf("error fetching repository %s/%s: %w", owner, repo, err)
+	}
+	rememberRepoStatus(sdk, owner+"/"+repo, !r.Archived && !r.Disabled)
+	return &r, nil
+}
+
+// ActiveCheckOption modifies how IsRepoActive answers.
+type ActiveCheckOption func(*activeCheck)
+
+type activeCheck struct {
+	bypassCache bool
+}
+
+// BypassCache makes IsRepoActive fetch the repository even if its status is cached. The fresh answer
+// replaces the cached one.
+func BypassCache() ActiveCheckOption {
+	return func(c *activeCheck) { c.bypassCache = true }
+}
+
+// IsRepoActive reports whether owner/repo exists and is neither archived nor disabled. A missing
+// repository (404) is reported as inactive rather than as an error.
+func IsRepoActive(sdk *resilientbridge.ResilientBridge, owner, repo string, opts ...ActiveCheckOption) (bool, error) {
+	var check activeCheck
+	for _, opt := range opts {
+		opt(&check)
+	}
+	if !check.bypassCache {
+		if active, ok := cacheFor[bool](sdk, repoStatusCacheKey).get(repoStatusKey(owner+"/"+repo), RepositoryStatusTTL); ok {
+			return active, nil
+		}
+	}
+
+	// GetRepository caches the status of repositories it finds
+	r, err := GetRepository(sdk, owner, repo)
+	if err != nil {
+		var httpErr *resilientbridge.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+			return false, err
+		}
+		rememberRepoStatus(sdk, owner+"/"+repo, false)
+		return false, nil
+	}
+	return !r.Archived && !r.Disabled, nil
+}
+
+// IsRepositoryActive is IsRepoActive without options, kept for existing callers.
+func IsRepositoryActive(sdk *resilientbridge.ResilientBridge, owner, repo string) (bool, error) {
+	return IsRepoActive(sdk, owner, repo)
+}
+
+// rememberRepoStatus caches whether the repository fullName ("owner/repo") is active.
+func rememberRepoStatus(sdk *resilientbridge.ResilientBridge, fullName string, active bool) {
+	cacheFor[bool](sdk, repoStatusCacheKey).set(repoStatusKey(fullName), active, RepositoryStatusTTL)
+}
+
+// repoStatusKey returns the cache key of fullName. GitHub names are case-insensitive, so the key is
+// lowercased.
+func repoStatusKey(fullName string) string {
+	return strings.ToLower(fullName)
+}
+
+// IsEmptyRepoResponse reports whether resp is GitHub's answer for a repository without any commits: a 409
+// Conflict saying "Git Repository is empty." (or carrying no body, as for HEAD requests), or a 404 saying
+// "This repository is empty." from the contents API.
+func IsEmptyRepoResponse(resp *resilientbridge.NormalizedResponse) bool {
+	if resp == nil {
+		return false
+	}
+	message := strings.ToLower(resilientbridge.ExtractErrorMessage(resp.Data))
+	switch resp.StatusCode {
+	case http.StatusConflict:
+		return message == "" || strings.Contains(message, "repository is empty")
+	case http.StatusNotFound:
+		return strings.Contains(message, "repository is empty")
+	}
+	return false
+}
+
+// isEmptyRepoError reports whether err is an *HTTPError for a response IsEmptyRepoResponse accepts.
+func isEmptyRepoError(err error) bool {
+	var httpErr *resilientbridge.HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	return IsEmptyRepoResponse(&resilientbridge.NormalizedResponse{StatusCode: httpErr.StatusCode, Data: httpErr.Body})
+}
+
+// repoEndpoint returns /repos/{owner}/{repo}.
+func repoEndpoint(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
@@ -0,0 +1,59 @@
+// has_more.go
+// -----------
+// This file implements PaginateOptions.HasMoreExtractor for APIs that say whether another page exists
+// with a boolean in the body rather than by the presence of a next link. Paginate stops as soon as the
+// extractor returns false, whatever NextPage or NextURLExtractor would make of the response; while it
+// returns true, they still build the next request.
+//
+// JSONHasMore and JSONIsLastPage build extractors for a flag at a JSON path. Ready-made ones cover the
+// common fields:
+//   - MoreFlag: "more" (PagerDuty)
+//   - NotLastPage: "isLastPage" (Bitbucket Server / Data Center)
+//   - HasAdditional: "has_additional" (Quay)
+package resilientbridge
+
+import "encoding/json"
+
+// HasMoreFunc reports whether there is a page after resp.
+type HasMoreFunc func(resp *NormalizedResponse) bool
+
+var (
+	MoreFlag      = JSONHasMore("more")
+	NotLastPage   = JSONIsLastPage("isLastPage")
+	HasAdditional = JSONHasMore("has_additional")
+)
+
+// JSONHasMore returns a HasMoreFunc reporting the boolean at path in a JSON object body. A missing or
+// non-boolean value, or a body that isn't a JSON object, means there are no more pages.
+func JSONHasMore(path ...string) HasMoreFunc {
+	return func(resp *NormalizedResponse) bool {
+		flag, ok := jsonBool(resp.Data, path)
+		return ok && flag
+	}
+}
+
+// JSONIsLastPage returns a HasMoreFunc for a flag that is true on the last page. As with JSONHasMore, a
+// missing or non-boolean value means there are no more pages.
+func JSONIsLastPage(path ...string) HasMoreFunc {
+	return func(resp *NormalizedResponse) bool {
+		last, ok := jsonBool(resp.Data, path)
+		return ok && !last
+	}
+}
+
+// jsonBool returns the boolean at path in the JSON object data, and whether there is one.
+func jsonBool(data []byte, path []string) (bool, bool) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return false, false
+	}
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false, false
+		}
+		value = object[key]
+	}
+	flag, ok := value.(bool)
+	return flag, ok
+}
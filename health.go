@@ -0,0 +1,76 @@
+// health.go
+// ---------
+// This file implements sdk.HealthCheck, a readiness probe that confirms each registered provider is
+// reachable with valid credentials.
+//
+// Adapters opt in by implementing HealthProber, returning a cheap, side-effect free request for a known
+// endpoint (GitHub /rate_limit, Linode /account, ...). Each probe is sent once, without retries or waits,
+// so a readiness check never stalls on backoff. A rate-limited answer counts as healthy: the provider is
+// up and the credentials were accepted, it is only throttling.
+package resilientbridge
+
+import (
+	"context"
+	"sync"
+)
+
+// HealthCheck probes every registered provider concurrently and returns the outcome per provider name:
+// nil when healthy (or merely rate limited), ErrNoHealthProbe when the adapter has no probe, and otherwise
+// the error that made the probe fail (network error, ErrUnauthorized, *HTTPError, ...).
+func (sdk *ResilientBridge) HealthCheck(ctx context.Context) map[string]error {
+	sdk.mu.Lock()
+	providers := make(map[string]ProviderAdapter, len(sdk.providers))
+	for name, adapter := range sdk.providers {
+		providers[name] = adapter
+	}
+	sdk.mu.Unlock()
+
+	results := make(map[string]error, len(providers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, adapter := range providers {
+		wg.Add(1)
+		go func(name string, adapter ProviderAdapter) {
+			defer wg.Done()
+			err := sdk.probeProvider(ctx, name, adapter)
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}(name, adapter)
+	}
+	wg.Wait()
+	return results
+}
+
+// probeProvider sends the adapter's health probe once and interprets the response.
+func (sdk *ResilientBridge) probeProvider(ctx context.Context, providerName string, adapter ProviderAdapter) error {
+	prober, ok := adapter.(HealthProber)
+	if !ok || !CapabilitiesOf(adapter).SupportsHealthProbe {
+		return ErrNoHealthProbe
+	}
+	req := prober.HealthProbe()
+	config := sdk.getProviderConfig(providerName)
+
+	resp, err := executeAdapterRequest(withProviderConfig(ctx, config), adapter, req)
+	if err != nil {
+		sdk.debugf("Provider %s: health probe failed: %v\n", providerName, err)
+		return err
+	}
+
+	if rateInfo, parseErr := adapter.ParseRateLimitInfo(resp); parseErr == nil && rateInfo != nil {
+		sdk.rateLimiter.UpdateRateLimits(providerName, adapter.IdentifyRequestType(req), rateInfo, config)
+	}
+	if classifier, ok := adapter.(ErrorClassifier); ok {
+		if classErr := classifier.ClassifyError(resp); classErr != nil {
+			return classErr
+		}
+	}
+	if adapter.IsRateLimitError(resp) {
+		sdk.debugf("Provider %s: health probe rate limited; reporting healthy.\n", providerName)
+		return nil
+	}
+	if resp.StatusCode >= 400 {
+		return newHTTPError(providerName, resp, adapter)
+	}
+	return nil
+}
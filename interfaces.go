@@ -9,8 +9,23 @@
 // - IsRateLimitError: How to identify a rate limit error (e.g., HTTP 429).
 // - SetRateLimitDefaultsForType: Initialize default rate limits for different request types (rest, graphql, etc.).
 // - IdentifyRequestType: Determine the type of request (rest, graphql, read, write, etc.) based on the request.
+//
+// Adapters may additionally implement ContextAdapter to receive the request context (which carries the
+// provider's ProviderConfig), StreamingAdapter to support RequestStream, ErrorClassifier to flag
+// responses that must fail immediately instead of being retried, ErrorMessageExtractor to turn
+// provider-specific error bodies into readable HTTPError messages, HealthProber to take part in
+// sdk.HealthCheck, WindowReporter to expose local window usage through sdk.RateLimitStatus, and
+// RateLimitStateExporter to carry local windows across restarts via sdk.ExportRateLimitState, and
+// DeprecationDetector to report deprecated endpoints through ProviderConfig.OnDeprecation, and
+// RateLimitWaiter to supply the wait for rate limit responses that carry no Retry-After header, and
+// CapabilityReporter to advertise which of these optional features it supports (see capabilities.go).
 package resilientbridge
 
+import (
+	"context"
+	"time"
+)
+
 // ProviderAdapter defines the interface all adapters must implement.
 type ProviderAdapter interface {
 	ExecuteRequest(req *NormalizedRequest) (*NormalizedResponse, error)
@@ -20,3 +35,69 @@ type ProviderAdapter interface {
 	SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64)
 	IdentifyRequestType(req *NormalizedRequest) string
 }
+
+// ContextAdapter is implemented by adapters that accept a context. When present, the SDK calls
+// ExecuteRequestWithContext instead of ExecuteRequest, passing a context that carries the provider's
+// ProviderConfig (see ProviderConfigFromContext).
+type ContextAdapter interface {
+	ExecuteRequestWithContext(ctx context.Context, req *NormalizedRequest) (*NormalizedResponse, error)
+}
+
+// StreamingAdapter is implemented by adapters that can return a response without buffering its body.
+// The returned StreamResponse.Body must be closed by the caller.
+type StreamingAdapter interface {
+	ExecuteStreamRequest(ctx context.Context, req *NormalizedRequest) (*StreamResponse, error)
+}
+
+// ErrorClassifier is implemented by adapters that can recognize terminal failures, such as rejected
+// credentials, which retrying cannot fix. ClassifyError returns nil when resp is not such a failure.
+// The SDK consults it before rate limit and server error handling.
+type ErrorClassifier interface {
+	ClassifyError(resp *NormalizedResponse) error
+}
+
+// ErrorMessageExtractor is implemented by adapters whose error bodies need provider-specific parsing.
+// The result becomes HTTPError.Message(); returning "" falls back to ExtractErrorMessage.
+type ErrorMessageExtractor interface {
+	ExtractErrorMessage(resp *NormalizedResponse) string
+}
+
+// HealthProber is implemented by adapters that can name a cheap, read-only request confirming
+// connectivity and credentials. sdk.HealthCheck sends it once per check.
+type HealthProber interface {
+	HealthProbe() *NormalizedRequest
+}
+
+// WindowReporter is implemented by adapters that keep local rolling windows. RateLimitWindows returns
+// the current usage of each window keyed by request type.
+type WindowReporter interface {
+	RateLimitWindows() map[string]WindowUsage
+}
+
+// RateLimitStateExporter is implemented by adapters that keep local rate limit state (rolling windows,
+// spent points). Export returns a snapshot in an adapter-defined encoding; Import restores one produced
+// by Export, merging it into the adapter's current state.
+type RateLimitStateExporter interface {
+	Export() ([]byte, error)
+	Import(data []byte) error
+}
+
+// DeprecationDetector is implemented by adapters that recognize their provider's deprecation signals
+// (Deprecation, Sunset, and Warning headers). DeprecationNotice returns nil when resp carries none.
+type DeprecationDetector interface {
+	DeprecationNotice(resp *NormalizedResponse) *Deprecation
+}
+
+// RateLimitWaiter is implemented by adapters whose provider documents a wait for rate limit responses
+// without a Retry-After header. When Retry-After is absent, the SDK uses RateLimitWait(resp) in its
+// place; returning 0 keeps the default exponential backoff.
+type RateLimitWaiter interface {
+	RateLimitWait(resp *NormalizedResponse) time.Duration
+}
+
+// PageSizer is implemented by adapters that know the largest page size of their provider's list
+// endpoints. MaxPageSize returns the query parameter and maximum for req, or "" if req is not a known
+// list endpoint. sdk.Paginate consults it when PaginateOptions.MaxPageSize is set.
+type PageSizer interface {
+	MaxPageSize(req *NormalizedRequest) (param string, size int)
+}
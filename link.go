@@ -0,0 +1,166 @@
+// link.go
+// -------
+// This file parses Link headers (RFC 8288), which GitHub, Shopify, and most REST APIs use for pagination
+// (rel="next", rel="last") and which GitHub also uses to point at deprecation notices. The parser follows
+// the RFC rather than splitting on commas and semicolons, so it handles:
+//   - commas and semicolons inside a target URI or a quoted parameter value (title="a, b; c")
+//   - several relation types in one rel parameter (rel="next last"), matched case-insensitively
+//   - quoted-pair escapes in quoted values, and unquoted values (rel=next)
+//   - responses with several Link headers, which the round-trip helpers join into one comma-separated value
+//
+// NormalizedResponse.Link looks up the target of one relation type; LinkNextPage, LinkPageCount, and the
+// github package's count helpers are built on it.
+package resilientbridge
+
+import "strings"
+
+// Link is one entry of a Link header.
+type Link struct {
+	URL    string            // The target URI, as written between < and >
+	Rel    []string          // The relation types of the rel parameter, lowercased
+	Params map[string]string // Every parameter by lowercased name, rel included, with quotes removed
+}
+
+// HasRel reports whether the link has relation type rel, compared case-insensitively.
+func (l Link) HasRel(rel string) bool {
+	for _, r := range l.Rel {
+		if strings.EqualFold(r, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Link returns the target URI of the first entry with relation type rel in the response's Link header,
+// and whether there is one.
+func (r *NormalizedResponse) Link(rel string) (string, bool) {
+	return LinkURL(r.Headers["link"], rel)
+}
+
+// LinkURL returns the target URI of the first entry with relation type rel in a Link header value, and
+// whether there is one.
+func LinkURL(header, rel string) (string, bool) {
+	for _, link := range ParseLinkHeader(header) {
+		if link.HasRel(rel) {
+			return link.URL, true
+		}
+	}
+	return "", false
+}
+
+// ParseLinkHeader returns the entries of a Link header value in order. Malformed entries are skipped; as
+// the RFC requires, only the first occurrence of a parameter in an entry is kept.
+func ParseLinkHeader(header string) []Link {
+	var links []Link
+	p := linkParser{s: header}
+	for {
+		p.skip(" \t,")
+		if p.done() {
+			return links
+		}
+		if p.s[p.i] != '<' {
+			p.skipEntry()
+			continue
+		}
+		end := strings.IndexByte(p.s[p.i:], '>')
+		if end < 0 {
+			return links
+		}
+		link := Link{URL: strings.TrimSpace(p.s[p.i+1 : p.i+end]), Params: map[string]string{}}
+		p.i += end + 1
+		p.params(&link)
+		links = append(links, link)
+	}
+}
+
+// linkParser scans a Link header value.
+type linkParser struct {
+	s string
+	i int
+}
+
+func (p *linkParser) done() bool { return p.i >= len(p.s) }
+
+// skip advances past any of the bytes in set.
+func (p *linkParser) skip(set string) {
+	for !p.done() && strings.IndexByte(set, p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+// skipEntry advances to the comma ending the current entry, stepping over quoted strings.
+func (p *linkParser) skipEntry() {
+	for !p.done() && p.s[p.i] != ',' {
+		if p.s[p.i] == '"' {
+			p.quoted()
+			continue
+		}
+		p.i++
+	}
+}
+
+// params reads the ";name=value" parameters following a target URI into link.
+func (p *linkParser) params(link *Link) {
+	for {
+		p.skip(" \t")
+		if p.done() || p.s[p.i] == ',' {
+			return
+		}
+		if p.s[p.i] != ';' {
+			p.skipEntry()
+			return
+		}
+		p.i++
+		p.skip(" \t")
+		name := strings.ToLower(p.token())
+		p.skip(" \t")
+		value := ""
+		if !p.done() && p.s[p.i] == '=' {
+			p.i++
+			p.skip(" \t")
+			if !p.done() && p.s[p.i] == '"' {
+				value = p.quoted()
+			} else {
+				value = p.token()
+			}
+		}
+		if name == "" {
+			continue
+		}
+		if _, seen := link.Params[name]; seen {
+			continue
+		}
+		link.Params[name] = value
+		if name == "rel" {
+			link.Rel = strings.Fields(strings.ToLower(value))
+		}
+	}
+}
+
+// token reads up to the next delimiter of a parameter name or unquoted value.
+func (p *linkParser) token() string {
+	start := p.i
+	for !p.done() && strings.IndexByte(" \t;,=\"", p.s[p.i]) < 0 {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// quoted reads a quoted string starting at the opening quote, unescaping quoted pairs. An unterminated
+// string runs to the end of the header.
+func (p *linkParser) quoted() string {
+	var b strings.Builder
+	for p.i++; !p.done(); p.i++ {
+		switch c := p.s[p.i]; {
+		case c == '"':
+			p.i++
+			return b.String()
+		case c == '\\' && p.i+1 < len(p.s):
+			p.i++
+			b.WriteByte(p.s[p.i])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+// methods.go
+// ----------
+// This file provides NormalizeMethod and IsReadMethod, which adapters use to classify requests by HTTP
+// method. Methods are matched case-insensitively and an empty method means GET, as in net/http, so "get",
+// "" and "GET" land in the same window. HEAD and OPTIONS are reads: they change nothing on the provider,
+// and providers that count reads and writes separately count them as reads.
+package resilientbridge
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NormalizeMethod returns method in upper case, or GET when it is empty.
+func NormalizeMethod(method string) string {
+	if method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(method)
+}
+
+// IsReadMethod reports whether method is GET, HEAD, or OPTIONS (or empty, meaning GET).
+func IsReadMethod(method string) bool {
+	switch NormalizeMethod(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
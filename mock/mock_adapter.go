@@ -1,17 +1,21 @@
 // Package mock provides a mock adapter that simulates a provider's behavior.
 // It can be used to test the SDK's rate limiting, retry logic, and error handling.
 // This adapter is highly configurable and allows developers to simulate multiple scenarios:
-// - Always returning a rate limit error (429).
+// - Always returning a rate limit error (429), optionally with a Retry-After header.
 // - Returning successful responses until a certain threshold, then returning rate limit errors.
 // - Distinguishing between REST and GraphQL request limits.
 // - Simulating random delays or transient errors.
 //
+// Request counts start over every WindowSecsRest (WindowSecsGraphQL) seconds, measured on Clock. Give
+// it the FakeClock set as ProviderConfig.Clock to move the mock into its next window without waiting.
+//
 // By adjusting the fields below, you can create a variety of test conditions.
 package mock
 
 import (
 	"errors"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,6 +40,9 @@ type MockAdapter struct {
 	// Useful to test retry logic immediately.
 	ShouldReturn429Always bool
 
+	// RetryAfterSecs, when set, is sent as the Retry-After header of every 429.
+	RetryAfterSecs int
+
 	// RandomDelayEnabled introduces a random sleep before responding, simulating network latency.
 	// The delay is between 0 and 500ms.
 	RandomDelayEnabled bool
@@ -48,11 +55,16 @@ type MockAdapter struct {
 	MaxRequestsRest         int
 	WindowSecsRest          int64
 	currentRequestCountRest int
+	windowStartRest         time.Time
 
 	// Maximum and window for GraphQL requests
 	MaxRequestsGraphQL         int
 	WindowSecsGraphQL          int64
 	currentRequestCountGraphQL int
+	windowStartGraphQL         time.Time
+
+	// Clock measures the windows; nil means real time. Use the provider's ProviderConfig.Clock.
+	Clock resilientbridge.Clock
 }
 
 // SetRateLimitDefaultsForType configures default rate limits for a given request type ("rest" or "graphql").
@@ -89,6 +101,8 @@ func (m *MockAdapter) IdentifyRequestType(req *resilientbridge.NormalizedRequest
 // - Checks if rate limit should be enforced.
 // - Otherwise returns a 200 success response.
 func (m *MockAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	m.rollWindows(m.now())
+
 	// Simulate network randomness
 	m.maybeDelay()
 	if m.maybeRandomError() {
@@ -99,9 +113,13 @@ func (m *MockAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*r
 
 	// Determine if we should return 429:
 	if m.ShouldReturn429Always || m.rateLimitReached(isGraphQL) {
+		headers := map[string]string{}
+		if m.RetryAfterSecs > 0 {
+			headers["retry-after"] = strconv.Itoa(m.RetryAfterSecs)
+		}
 		return &resilientbridge.NormalizedResponse{
 			StatusCode: 429,
-			Headers:    map[string]string{},
+			Headers:    headers,
 			Data:       []byte(`{"error":"Rate limited"}`),
 		}, nil
 	}
@@ -139,8 +157,12 @@ func (m *MockAdapter) ParseRateLimitInfo(resp *resilientbridge.NormalizedRespons
 	}
 	var resetAt *int64
 	if remaining == 0 {
-		// If no requests remain, pretend reset is after the window
-		future := (time.Now().Unix() + m.WindowSecsRest) * 1000
+		// If no requests remain, the limit resets when the current window ends
+		start := m.windowStartRest
+		if start.IsZero() {
+			start = m.now()
+		}
+		future := start.Add(time.Duration(m.WindowSecsRest) * time.Second).UnixMilli()
 		resetAt = &future
 	}
 	info := &resilientbridge.NormalizedRateLimitInfo{
@@ -157,6 +179,27 @@ func (m *MockAdapter) IsRateLimitError(resp *resilientbridge.NormalizedResponse)
 	return resp.StatusCode == 429
 }
 
+// rollWindows starts a new REST or GraphQL window, with its count back at zero, once the current one has
+// lasted its WindowSecs.
+func (m *MockAdapter) rollWindows(now time.Time) {
+	if m.windowStartRest.IsZero() || (m.WindowSecsRest > 0 && !now.Before(m.windowStartRest.Add(time.Duration(m.WindowSecsRest)*time.Second))) {
+		m.windowStartRest = now
+		m.currentRequestCountRest = 0
+	}
+	if m.windowStartGraphQL.IsZero() || (m.WindowSecsGraphQL > 0 && !now.Before(m.windowStartGraphQL.Add(time.Duration(m.WindowSecsGraphQL)*time.Second))) {
+		m.windowStartGraphQL = now
+		m.currentRequestCountGraphQL = 0
+	}
+}
+
+// now reads Clock, or the real time when it is nil.
+func (m *MockAdapter) now() time.Time {
+	if m.Clock == nil {
+		return time.Now()
+	}
+	return m.Clock.Now()
+}
+
 // rateLimitReached checks if we should return 429 based on RequestsUntilRateLimit.
 // If RequestsUntilRateLimit is > 0, once we exceed that threshold for the given request type, we rate limit.
 func (m *MockAdapter) rateLimitReached(isGraphQL bool) bool {
@@ -0,0 +1,72 @@
+// ndjson.go
+// ---------
+// This file implements sdk.StreamNDJSON, which paginates a list endpoint and writes its items to an
+// io.Writer as newline-delimited JSON (one compact object per line), the output format of most of the
+// CLI examples. Each page is written as soon as it is fetched and then dropped, so memory stays constant
+// however long the listing is, and a consumer reading the output (jq, a pipe into a loader) sees items
+// while later pages are still being requested.
+//
+// Items are taken from the page body as is: from the body itself when it is a JSON array, or from the
+// array at PaginateOptions.ItemsPath (e.g. "workflow_runs", "data"). Callers that reshape items before
+// printing can use a json.Encoder in their own Paginate callback instead, which writes the same format.
+package resilientbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamNDJSON paginates req like Paginate and writes every item of every page to w, one JSON value per
+// line. It returns the number of items written, which is accurate even when an error ends the listing
+// part way.
+func (sdk *ResilientBridge) StreamNDJSON(ctx context.Context, w io.Writer, providerName string, req *NormalizedRequest, opts *PaginateOptions) (int, error) {
+	var itemsPath []string
+	if opts != nil {
+		itemsPath = opts.ItemsPath
+	}
+
+	written := 0
+	var line bytes.Buffer
+	err := sdk.Paginate(ctx, providerName, req, opts, func(resp *NormalizedResponse) error {
+		items, err := pageItems(resp, itemsPath)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			line.Reset()
+			if err := json.Compact(&line, item); err != nil {
+				return resp.decodeError(err)
+			}
+			line.WriteByte('\n')
+			if _, err := w.Write(line.Bytes()); err != nil {
+				return fmt.Errorf("error writing NDJSON output: %w", err)
+			}
+			written++
+		}
+		return nil
+	})
+	return written, err
+}
+
+// pageItems returns the elements of the JSON array at path in resp's body, or of the body itself when path
+// is empty. A page without the field, or with null there, has no items.
+func pageItems(resp *NormalizedResponse, path []string) ([]json.RawMessage, error) {
+	data := json.RawMessage(resp.Data)
+	for _, key := range path {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, resp.decodeError(err)
+		}
+		if data = object[key]; data == nil {
+			return nil, nil
+		}
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, resp.decodeError(fmt.Errorf("items at %v: %w", path, err))
+	}
+	return items, nil
+}
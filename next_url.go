@@ -0,0 +1,61 @@
+// next_url.go
+// -----------
+// This file implements PaginateOptions.NextURLExtractor for APIs that put the next page's URL in the
+// response body instead of a Link header. The extractor only has to find the URL; Paginate turns it into
+// the next request, keeping the original request's method, headers, and body. Most providers return an
+// absolute URL, which every adapter sends as is (host checks, such as GCP's googleapis.com rule, still
+// apply); providers returning a path relative to their API root (Twilio's next_page_uri) work the same way.
+//
+// JSONNextURL builds an extractor for a field at a JSON path. Ready-made ones cover the common shapes:
+//   - LinksNextURL: "links.next" (JSON:API, Bitbucket-style HAL links as a string or {"href": ...})
+//   - NextFieldURL: a top-level "next" (Bitbucket, Django REST Framework)
+//   - NextLinkURL: "nextLink" (Azure Resource Manager)
+//   - NextPageURI: "next_page_uri" (Twilio's 2010-04-01 API)
+package resilientbridge
+
+import "encoding/json"
+
+// NextURLFunc returns the URL of the page after resp, and whether there is one.
+type NextURLFunc func(resp *NormalizedResponse) (string, bool)
+
+var (
+	LinksNextURL = JSONNextURL("links", "next")
+	NextFieldURL = JSONNextURL("next")
+	NextLinkURL  = JSONNextURL("nextLink")
+	NextPageURI  = JSONNextURL("next_page_uri")
+)
+
+// JSONNextURL returns a NextURLFunc reading the string at path in a JSON object body. A value that is an
+// object with an "href" string (HAL links) is followed as well. A missing, null, or empty value, or a body
+// that isn't a JSON object, means there are no more pages.
+func JSONNextURL(path ...string) NextURLFunc {
+	return func(resp *NormalizedResponse) (string, bool) {
+		var value interface{}
+		if err := json.Unmarshal(resp.Data, &value); err != nil {
+			return "", false
+		}
+		for _, key := range path {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			value = object[key]
+		}
+		if object, ok := value.(map[string]interface{}); ok {
+			value = object["href"]
+		}
+		next, ok := value.(string)
+		return next, ok && next != ""
+	}
+}
+
+// nextURLPage adapts a NextURLFunc to a NextPageFunc.
+func nextURLPage(extract NextURLFunc) NextPageFunc {
+	return func(req *NormalizedRequest, resp *NormalizedResponse) (*NormalizedRequest, error) {
+		next, ok := extract(resp)
+		if !ok {
+			return nil, nil
+		}
+		return NextPageRequest(req, next), nil
+	}
+}
@@ -0,0 +1,147 @@
+// paginate_parallel.go
+// --------------------
+// This file implements sdk.PaginateParallel, which fetches the pages of a page-number paginated listing
+// concurrently. sdk.Paginate has to be sequential, because each page's request depends on the previous
+// response; with page-number pagination (GitHub's page=N, Linode's page/pages), every page's request is
+// known as soon as the first response reveals the total page count.
+//
+// PaginateParallel only applies to offset/page-number pagination. APIs paginating with opaque cursors
+// or next-page tokens must use sdk.Paginate.
+//
+// Pages are fetched by at most ParallelPaginateOptions.Concurrency goroutines, and every request still
+// goes through sdk.Request's rate limiting, retries, and the provider's MaxConcurrency slots, so fetching
+// in parallel never sends more than the provider is configured to accept.
+package resilientbridge
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// DefaultParallelPages is the number of pages PaginateParallel fetches at once when neither
+// ParallelPaginateOptions.Concurrency nor the provider's MaxConcurrency is set.
+const DefaultParallelPages = 4
+
+// PageCountFunc returns the total number of pages announced by the first page's response, or 0 if the
+// response doesn't say.
+type PageCountFunc func(resp *NormalizedResponse) (int, error)
+
+// ParallelPaginateOptions controls sdk.PaginateParallel.
+type ParallelPaginateOptions struct {
+	PageCount   PageCountFunc // How to find the total page count; defaults to LinkPageCount
+	PageParam   string        // Query parameter carrying the page number; defaults to "page"
+	MaxPages    int           // Fetch at most this many pages; 0 means no limit
+	Concurrency int           // Pages fetched at once; defaults to the provider's MaxConcurrency, or DefaultParallelPages
+}
+
+// PaginateParallel sends req, which must request the first page, reads the total page count from its
+// response, and fetches pages 2..N concurrently by setting opts.PageParam on req's endpoint. It returns
+// every page's response in page order. If any page fails, the remaining requests are canceled and the
+// first error is returned. A nil opts uses the defaults.
+func (sdk *ResilientBridge) PaginateParallel(ctx context.Context, providerName string, req *NormalizedRequest, opts *ParallelPaginateOptions) ([]*NormalizedResponse, error) {
+	if opts == nil {
+		opts = &ParallelPaginateOptions{}
+	}
+	pageCount := opts.PageCount
+	if pageCount == nil {
+		pageCount = LinkPageCount
+	}
+	param := opts.PageParam
+	if param == "" {
+		param = "page"
+	}
+
+	first, err := sdk.RequestWithContext(ctx, providerName, req)
+	if err != nil {
+		return nil, err
+	}
+	total, err := pageCount(first)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxPages > 0 && total > opts.MaxPages {
+		total = opts.MaxPages
+	}
+	if total <= 1 {
+		return []*NormalizedResponse{first}, nil
+	}
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = sdk.getProviderConfig(providerName).MaxConcurrency
+	}
+	if workers <= 0 {
+		workers = DefaultParallelPages
+	}
+	sdk.debugf("Provider %s: fetching %d pages with %d workers.\n", providerName, total, workers)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]*NormalizedResponse, total)
+	pages[0] = first
+	numbers := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i := 0; i < workers && i < total-1; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range numbers {
+				pageReq := NextPageRequest(req, WithQueryParam(req.Endpoint, param, strconv.Itoa(n)))
+				resp, err := sdk.RequestWithContext(ctx, providerName, pageReq)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				pages[n-1] = resp
+			}
+		}()
+	}
+
+feed:
+	for n := 2; n <= total; n++ {
+		select {
+		case numbers <- n:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(numbers)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// LinkPageCount is the default PageCountFunc. It reads the "page" query parameter of the rel="last"
+// URL of the Link header, as sent by GitHub; without a rel="last" link there is only one page.
+func LinkPageCount(resp *NormalizedResponse) (int, error) {
+	last, ok := resp.Link("last")
+	if !ok {
+		return 1, nil
+	}
+	u, err := url.Parse(last)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rel=\"last\" link %q: %w", last, err)
+	}
+	page := u.Query().Get("page")
+	if page == "" {
+		return 1, nil
+	}
+	return strconv.Atoi(page)
+}
@@ -0,0 +1,202 @@
+// paginator.go
+// ------------
+// This file implements sdk.Paginate, which follows a provider's pagination scheme page by page, sending
+// every page through the same rate limiting and retry logic as sdk.Request.
+//
+// How the next page is located is pluggable via PaginateOptions.NextPage. The default follows the
+// rel="next" entry of the Link header (GitHub, Shopify, and most REST APIs). Adapters for providers
+// with body-based schemes (offset/limit, cursors, "more" flags) export their own NextPageFunc.
+// For bodies that simply carry the next page's URL, PaginateOptions.NextURLExtractor is enough (see
+// next_url.go). PaginateOptions.HasMoreExtractor adds a stop condition for bodies flagging the last page
+// with a boolean ("more", "isLastPage", "has_additional"; see has_more.go).
+//
+// PaginateOptions.StopWhenQuotaBelow lets background crawls spend leftover quota without running into a
+// reset wait: after each page, the remaining request count parsed from the response is compared with the
+// threshold, and pagination stops early (without error) once it drops below. PaginateWithResult reports
+// such early stops through PaginateResult.Partial.
+//
+// sdk.StreamNDJSON builds on Paginate to write every item of a listing as newline-delimited JSON (see
+// ndjson.go).
+//
+// PaginateOptions.MaxPageSize asks for the largest page size on the first request, for adapters that know
+// it (PageSizer): the GitHub adapter adds per_page=100 to its known list endpoints. A page size already
+// set on the request is kept.
+package resilientbridge
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// NextPageFunc returns the request for the page after resp, or nil when there are no more pages.
+// req is the request that produced resp.
+type NextPageFunc func(req *NormalizedRequest, resp *NormalizedResponse) (*NormalizedRequest, error)
+
+// PaginateOptions controls sdk.Paginate.
+type PaginateOptions struct {
+	NextPage NextPageFunc // How to find the next page; defaults to LinkNextPage
+	MaxPages int          // Stop after this many pages; 0 means no limit
+
+	// NextURLExtractor finds the next page's URL in the response body, e.g. LinksNextURL or NextLinkURL.
+	// It is used when NextPage is nil.
+	NextURLExtractor NextURLFunc
+
+	// HasMoreExtractor reads whether another page exists from the response, e.g. MoreFlag or NotLastPage.
+	// Pagination stops when it returns false, before NextPage or NextURLExtractor are consulted.
+	HasMoreExtractor HasMoreFunc
+
+	// StopWhenQuotaBelow stops pagination once the provider reports fewer remaining requests than this;
+	// 0 disables the check. Responses without rate limit info never stop pagination.
+	StopWhenQuotaBelow int
+
+	// MaxPageSize sets the adapter's largest page size on the request when it sets none, if the adapter
+	// implements PageSizer and knows the endpoint. Fewer, fuller pages use fewer requests.
+	MaxPageSize bool
+
+	// ItemsPath is where StreamNDJSON finds a page's items in a JSON object body, e.g. []string{"data"};
+	// empty means the body is itself the array of items.
+	ItemsPath []string
+}
+
+// PaginateResult summarizes a PaginateWithResult run.
+type PaginateResult struct {
+	Pages   int  // Pages passed to onPage
+	Partial bool // Stopped early because of StopWhenQuotaBelow; more pages remain
+}
+
+// Paginate sends req to the provider and calls onPage with each page's response, following
+// opts.NextPage until it reports no further pages, MaxPages is reached, or onPage returns an error.
+// A nil opts uses the defaults.
+func (sdk *ResilientBridge) Paginate(ctx context.Context, providerName string, req *NormalizedRequest, opts *PaginateOptions, onPage func(resp *NormalizedResponse) error) error {
+	_, err := sdk.PaginateWithResult(ctx, providerName, req, opts, onPage)
+	return err
+}
+
+// PaginateWithResult is Paginate that also reports how many pages were fetched and whether it stopped
+// early because of opts.StopWhenQuotaBelow.
+func (sdk *ResilientBridge) PaginateWithResult(ctx context.Context, providerName string, req *NormalizedRequest, opts *PaginateOptions, onPage func(resp *NormalizedResponse) error) (PaginateResult, error) {
+	var result PaginateResult
+	if opts == nil {
+		opts = &PaginateOptions{}
+	}
+	next := opts.NextPage
+	if next == nil && opts.NextURLExtractor != nil {
+		next = nextURLPage(opts.NextURLExtractor)
+	}
+	if next == nil {
+		next = LinkNextPage
+	}
+	if opts.MaxPageSize && req != nil {
+		req = sdk.withMaxPageSize(providerName, req)
+	}
+
+	for req != nil {
+		if opts.MaxPages > 0 && result.Pages >= opts.MaxPages {
+			sdk.debugf("Provider %s: stopping pagination after %d pages (MaxPages).\n", providerName, result.Pages)
+			return result, nil
+		}
+
+		resp, err := sdk.RequestWithContext(ctx, providerName, req)
+		if err != nil {
+			return result, err
+		}
+		if err := onPage(resp); err != nil {
+			return result, err
+		}
+		result.Pages++
+
+		if opts.HasMoreExtractor != nil && !opts.HasMoreExtractor(resp) {
+			return result, nil
+		}
+		req, err = next(req, resp)
+		if err != nil {
+			return result, err
+		}
+		if req != nil && sdk.quotaBelow(providerName, resp, opts.StopWhenQuotaBelow) {
+			sdk.debugf("Provider %s: stopping pagination after %d pages, remaining quota below %d.\n", providerName, result.Pages, opts.StopWhenQuotaBelow)
+			result.Partial = true
+			return result, nil
+		}
+	}
+	return result, nil
+}
+
+// quotaBelow reports whether resp carries a remaining request count below threshold.
+func (sdk *ResilientBridge) quotaBelow(providerName string, resp *NormalizedResponse, threshold int) bool {
+	if threshold <= 0 {
+		return false
+	}
+	sdk.mu.Lock()
+	adapter, ok := sdk.providers[providerName]
+	sdk.mu.Unlock()
+	if !ok {
+		return false
+	}
+	info, err := adapter.ParseRateLimitInfo(resp)
+	if err != nil || info == nil || info.RemainingRequests == nil {
+		return false
+	}
+	return *info.RemainingRequests < threshold
+}
+
+// withMaxPageSize returns a copy of req with the adapter's largest page size set, or req itself if the
+// adapter doesn't know one for it or req already sets the parameter.
+func (sdk *ResilientBridge) withMaxPageSize(providerName string, req *NormalizedRequest) *NormalizedRequest {
+	sdk.mu.Lock()
+	adapter, ok := sdk.providers[providerName]
+	sdk.mu.Unlock()
+	sizer, isSizer := adapter.(PageSizer)
+	if !ok || !isSizer {
+		return req
+	}
+	param, size := sizer.MaxPageSize(req)
+	if param == "" || size <= 0 {
+		return req
+	}
+	if u, err := url.Parse(req.Endpoint); err != nil || u.Query().Has(param) {
+		return req
+	}
+	return NextPageRequest(req, WithQueryParam(req.Endpoint, param, strconv.Itoa(size)))
+}
+
+// LinkNextPage is the default NextPageFunc. It follows the rel="next" URL of the response's Link
+// header, keeping the original request's method and headers.
+func LinkNextPage(req *NormalizedRequest, resp *NormalizedResponse) (*NormalizedRequest, error) {
+	next, ok := resp.Link("next")
+	if !ok {
+		return nil, nil
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return nil, err
+	}
+	return NextPageRequest(req, u.RequestURI()), nil
+}
+
+// NextPageRequest returns a copy of req targeting endpoint, for use by NextPageFunc implementations.
+func NextPageRequest(req *NormalizedRequest, endpoint string) *NormalizedRequest {
+	return &NormalizedRequest{
+		Method:   req.Method,
+		Endpoint: endpoint,
+		Headers:  req.Headers,
+		Body:     req.Body,
+		Tags:     req.Tags,
+
+		Idempotent:              req.Idempotent,
+		BaseURLOverride:         req.BaseURLOverride,
+		FollowLocationStripAuth: req.FollowLocationStripAuth,
+	}
+}
+
+// WithQueryParam returns endpoint with the query parameter key set to value, replacing any existing value.
+func WithQueryParam(endpoint, key, value string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
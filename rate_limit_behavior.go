@@ -0,0 +1,46 @@
+// rate_limit_behavior.go
+// ----------------------
+// This file defines RateLimitBehavior, which controls what the SDK does when a provider is rate limited:
+// wait it out (the default), return a *RateLimitError immediately, or wait only up to a time budget.
+// Failing fast lets interactive tools tell the user "try again in N seconds" instead of hanging, and lets
+// schedulers decide for themselves when to come back.
+//
+// Interaction with MaxRetries:
+//   - RateLimitBlock retries rate-limited attempts up to MaxRetries, then returns a *RateLimitError.
+//   - RateLimitFailFast never waits on a rate limit, so MaxRetries only applies to network and 5xx errors.
+//   - RateLimitBlockWithTimeout(d) retries until MaxRetries is reached or the next wait would end more than
+//     d after the request started, whichever comes first.
+package resilientbridge
+
+import "time"
+
+// RateLimitBehavior selects how ExecuteWithRetry reacts to rate limits (429 responses, preemptive
+// waits from reported limits, and token bucket pacing).
+type RateLimitBehavior struct {
+	failFast bool
+	timeout  time.Duration // 0 means no budget
+}
+
+var (
+	// RateLimitBlock waits until the rate limit resets (default).
+	RateLimitBlock = RateLimitBehavior{}
+	// RateLimitFailFast returns a *RateLimitError instead of waiting.
+	RateLimitFailFast = RateLimitBehavior{failFast: true}
+)
+
+// RateLimitBlockWithTimeout waits on rate limits as long as the request completes within d of its
+// first attempt; a wait that would end later fails with a *RateLimitError instead.
+func RateLimitBlockWithTimeout(d time.Duration) RateLimitBehavior {
+	return RateLimitBehavior{timeout: d}
+}
+
+// allowsWait reports whether a rate-limit wait of d may proceed, given the request started at start.
+func (b RateLimitBehavior) allowsWait(start, now time.Time, d time.Duration) bool {
+	if b.failFast {
+		return d <= 0
+	}
+	if b.timeout > 0 {
+		return now.Add(d).Sub(start) <= b.timeout
+	}
+	return true
+}
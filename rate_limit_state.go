@@ -0,0 +1,144 @@
+// rate_limit_state.go
+// -------------------
+// This file implements sdk.ExportRateLimitState and sdk.ImportRateLimitState, a one-shot hand-off of
+// rate limit state from an old process to its replacement during a deploy. Without it, a new process
+// starts with empty windows and can burst through quota the old one already spent.
+//
+// The snapshot is JSON and holds the SDK's own state (last reported limits, token buckets, endpoint
+// windows) plus, for every adapter implementing RateLimitStateExporter, the adapter's own export.
+// Import on startup, after registering providers: entries for providers that are not registered are
+// ignored, and timestamps that have since left their window age out as usual.
+package resilientbridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// rateLimitStateVersion is bumped when the snapshot layout changes incompatibly.
+const rateLimitStateVersion = 1
+
+type rateLimitState struct {
+	Version         int                                 `json:"version"`
+	Limits          map[string]*NormalizedRateLimitInfo `json:"limits,omitempty"`
+	Buckets         map[string]tokenBucketState         `json:"buckets,omitempty"`
+	EndpointWindows map[string][]time.Time              `json:"endpoint_windows,omitempty"`
+	Adapters        map[string]json.RawMessage          `json:"adapters,omitempty"`
+}
+
+type tokenBucketState struct {
+	Rate   float64   `json:"rate"`
+	Burst  float64   `json:"burst"`
+	Tokens float64   `json:"tokens"`
+	Last   time.Time `json:"last"`
+}
+
+// ExportRateLimitState returns a JSON snapshot of the rate limit state of every registered provider.
+func (sdk *ResilientBridge) ExportRateLimitState() ([]byte, error) {
+	state := rateLimitState{
+		Version:         rateLimitStateVersion,
+		Limits:          make(map[string]*NormalizedRateLimitInfo),
+		Buckets:         make(map[string]tokenBucketState),
+		EndpointWindows: make(map[string][]time.Time),
+		Adapters:        make(map[string]json.RawMessage),
+	}
+
+	r := sdk.rateLimiter
+	r.mu.Lock()
+	for key, info := range r.providerLimits {
+		if info != nil {
+			state.Limits[key] = info
+		}
+	}
+	for key, bucket := range r.buckets {
+		bucket.mu.Lock()
+		state.Buckets[key] = tokenBucketState{Rate: bucket.rate, Burst: bucket.burst, Tokens: bucket.tokens, Last: bucket.last}
+		bucket.mu.Unlock()
+	}
+	for key, w := range r.endpointWindows {
+		state.EndpointWindows[key] = append([]time.Time(nil), w.times...)
+	}
+	r.mu.Unlock()
+
+	sdk.mu.Lock()
+	providers := make(map[string]ProviderAdapter, len(sdk.providers))
+	for name, adapter := range sdk.providers {
+		providers[name] = adapter
+	}
+	sdk.mu.Unlock()
+	for name, adapter := range providers {
+		exporter, ok := adapter.(RateLimitStateExporter)
+		if !ok {
+			continue
+		}
+		data, err := exporter.Export()
+		if err != nil {
+			return nil, fmt.Errorf("exporting rate limit state of %s: %w", name, err)
+		}
+		state.Adapters[name] = data
+	}
+
+	return json.Marshal(state)
+}
+
+// ImportRateLimitState loads a snapshot produced by ExportRateLimitState. Call it after registering
+// providers; state for unregistered providers is skipped.
+func (sdk *ResilientBridge) ImportRateLimitState(data []byte) error {
+	var state rateLimitState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("decoding rate limit state: %w", err)
+	}
+	if state.Version != rateLimitStateVersion {
+		return fmt.Errorf("unsupported rate limit state version %d", state.Version)
+	}
+
+	sdk.mu.Lock()
+	providers := make(map[string]ProviderAdapter, len(sdk.providers))
+	clocks := make(map[string]Clock, len(sdk.providers))
+	for name, adapter := range sdk.providers {
+		providers[name] = adapter
+		clocks[name] = sdk.clockFor(sdk.configs[name])
+	}
+	sdk.mu.Unlock()
+	registered := func(key string) bool {
+		provider, _, _ := strings.Cut(key, ":")
+		_, ok := providers[provider]
+		return ok
+	}
+
+	r := sdk.rateLimiter
+	r.mu.Lock()
+	for key, info := range state.Limits {
+		if registered(key) {
+			r.providerLimits[key] = info
+		}
+	}
+	for key, b := range state.Buckets {
+		if registered(key) && b.Rate > 0 {
+			provider, _, _ := strings.Cut(key, ":")
+			bucket := newTokenBucketLimiter(b.Rate, int(b.Burst), clocks[provider])
+			bucket.tokens = b.Tokens
+			bucket.last = b.Last
+			r.buckets[key] = bucket
+		}
+	}
+	for key, times := range state.EndpointWindows {
+		if registered(key) {
+			r.endpointWindows[key] = &endpointWindow{times: times}
+		}
+	}
+	r.mu.Unlock()
+
+	for name, raw := range state.Adapters {
+		exporter, ok := providers[name].(RateLimitStateExporter)
+		if !ok {
+			continue
+		}
+		if err := exporter.Import(raw); err != nil {
+			return fmt.Errorf("importing rate limit state of %s: %w", name, err)
+		}
+	}
+	return nil
+}
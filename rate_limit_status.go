@@ -0,0 +1,114 @@
+// rate_limit_status.go
+// --------------------
+// This file implements sdk.RateLimitStatus, which answers "how much quota is left?" before a big job
+// starts, so a scheduler can decide whether to run now or wait for a reset.
+//
+// The status combines two sources:
+// - the rate limit info last parsed from the provider's response headers (ParseRateLimitInfo), and
+// - the adapter's own rolling windows, for adapters that implement WindowReporter.
+// When both are known, the lower remaining count wins, since either one can throttle the next request.
+// Each call type (e.g. GitHub's "rest", "graphql", "search") is reported separately.
+//
+// sdk.WaitForReset builds on the same status: when a call type's quota is exhausted it sleeps until the
+// reported reset (GitHub's X-RateLimit-Reset epoch, or the moment the oldest request leaves a local
+// window), instead of polling.
+package resilientbridge
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WindowUsage describes one of an adapter's local rolling windows.
+type WindowUsage struct {
+	Used       int    // Requests recorded in the current window
+	Max        int    // Requests allowed per window
+	WindowSecs int64  // Window length in seconds
+	ResetAt    *int64 // Unix ms at which the oldest recorded request leaves the window, if any
+}
+
+// RateLimitStatus returns the known rate limit state for provider's "rest" call type. The result is nil
+// (with a nil error) if neither the provider nor the adapter has reported anything yet.
+func (sdk *ResilientBridge) RateLimitStatus(providerName string) (*NormalizedRateLimitInfo, error) {
+	all, err := sdk.RateLimitStatusByType(providerName)
+	if err != nil {
+		return nil, err
+	}
+	return all["rest"], nil
+}
+
+// RateLimitStatusByType returns the known rate limit state of every call type of provider.
+func (sdk *ResilientBridge) RateLimitStatusByType(providerName string) (map[string]*NormalizedRateLimitInfo, error) {
+	sdk.mu.Lock()
+	adapter, ok := sdk.providers[providerName]
+	sdk.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("provider %q not registered", providerName)
+	}
+
+	status := sdk.rateLimiter.providerInfo(providerName)
+	if reporter, ok := adapter.(WindowReporter); ok {
+		for callType, w := range reporter.RateLimitWindows() {
+			info := status[callType]
+			if info == nil {
+				info = &NormalizedRateLimitInfo{}
+				status[callType] = info
+			}
+			mergeWindowUsage(info, w)
+		}
+	}
+	return status, nil
+}
+
+// WaitForReset blocks until provider's "rest" call type has quota again, or ctx is done. It returns
+// immediately when quota remains or no reset time is known.
+func (sdk *ResilientBridge) WaitForReset(ctx context.Context, providerName string) error {
+	return sdk.WaitForResetType(ctx, providerName, "rest")
+}
+
+// WaitForResetType is WaitForReset for a specific call type, e.g. GitHub's "search" or "graphql".
+func (sdk *ResilientBridge) WaitForResetType(ctx context.Context, providerName string, callType string) error {
+	status, err := sdk.RateLimitStatusByType(providerName)
+	if err != nil {
+		return err
+	}
+	info := status[callType]
+	if info == nil || info.RemainingRequests == nil || *info.RemainingRequests > 0 || info.ResetRequestsAt == nil {
+		return ctx.Err()
+	}
+	clock := sdk.clockFor(sdk.getProviderConfig(providerName))
+	wait := time.UnixMilli(*info.ResetRequestsAt).Sub(clock.Now())
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	sdk.debugf("Provider %s (callType=%s): Quota exhausted, waiting %v for reset.\n", providerName, callType, wait)
+	notifyThrottle(ctx, providerName, callType, wait)
+	return sleepContext(ctx, clock, wait)
+}
+
+// mergeWindowUsage folds a local window into info, filling unknown fields and keeping the lower
+// remaining count.
+func mergeWindowUsage(info *NormalizedRateLimitInfo, w WindowUsage) {
+	if w.Max <= 0 {
+		return
+	}
+	remaining := w.Max - w.Used
+	if remaining < 0 {
+		remaining = 0
+	}
+	if info.MaxRequests == nil {
+		info.MaxRequests = IntPtr(w.Max)
+	}
+	if info.RemainingRequests == nil || remaining < *info.RemainingRequests {
+		info.RemainingRequests = IntPtr(remaining)
+		if w.ResetAt != nil {
+			reset := *w.ResetAt
+			info.ResetRequestsAt = &reset
+		}
+	}
+	if info.ResetRequestsAt == nil && w.ResetAt != nil {
+		reset := *w.ResetAt
+		info.ResetRequestsAt = &reset
+	}
+}
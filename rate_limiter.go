@@ -5,25 +5,34 @@
 // to determine if a request can proceed immediately or if it must wait until a reset time.
 //
 // Responsibilities:
-// - Storing rate limit info keyed by "provider:callType".
-// - Checking if requests can proceed based on RemainingRequests and ResetRequestsAt.
-// - Calculating delay durations before the next allowed request if the rate limit is exceeded.
-// - Integrating with ProviderConfig overrides if UseProviderLimits is false.
+//   - Storing rate limit info keyed by "provider:callType".
+//   - Checking if requests can proceed based on RemainingRequests and ResetRequestsAt, and likewise for every
+//     other limit the info reports (tokens, points, Dimensions): a request waits until every exhausted
+//     limit has reset, i.e. for whichever is scarcer.
+//   - Calculating delay durations before the next allowed request if the rate limit is exceeded.
+//   - Integrating with ProviderConfig overrides if UseProviderLimits is false.
+//   - Holding the per provider/callType token buckets used when RateLimitAlgorithm is LimiterTokenBucket.
+//   - Holding the rolling windows for ProviderConfig.EndpointLimits (see endpoint_limits.go).
 package resilientbridge
 
 import (
+	"strings"
 	"sync"
 	"time"
 )
 
 type RateLimiter struct {
-	mu             sync.Mutex
-	providerLimits map[string]*NormalizedRateLimitInfo
+	mu              sync.Mutex
+	providerLimits  map[string]*NormalizedRateLimitInfo
+	buckets         map[string]*TokenBucketLimiter
+	endpointWindows map[string]*endpointWindow
 }
 
 func NewRateLimiter() *RateLimiter {
 	return &RateLimiter{
-		providerLimits: make(map[string]*NormalizedRateLimitInfo),
+		providerLimits:  make(map[string]*NormalizedRateLimitInfo),
+		buckets:         make(map[string]*TokenBucketLimiter),
+		endpointWindows: make(map[string]*endpointWindow),
 	}
 }
 
@@ -57,8 +66,8 @@ func (r *RateLimiter) UpdateRateLimits(provider string, callType string, info *N
 }
 
 // canProceed checks if a request can proceed immediately for a given provider and callType.
-// It returns false if the rate limit has been hit and the reset time hasn't passed yet.
-func (r *RateLimiter) canProceed(provider string, callType string) bool {
+// It returns false if the request or token limit has been hit and its reset time hasn't passed yet at now.
+func (r *RateLimiter) canProceed(provider string, callType string, now time.Time) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -68,19 +77,12 @@ func (r *RateLimiter) canProceed(provider string, callType string) bool {
 		// No known limits, assume proceed
 		return true
 	}
-
-	if info.RemainingRequests != nil && *info.RemainingRequests <= 0 {
-		// Out of requests, check if reset time is still in the future
-		if info.ResetRequestsAt != nil && time.Now().UnixMilli() < *info.ResetRequestsAt {
-			return false
-		}
-	}
-	return true
+	return blockedUntil(info) <= now.UnixMilli()
 }
 
 // delayBeforeNextRequest calculates how long we must wait before making another request
-// if the rate limit is currently exceeded. It returns a duration to sleep, if any.
-func (r *RateLimiter) delayBeforeNextRequest(provider string, callType string) time.Duration {
+// if the rate limit is exceeded at now. It returns a duration to sleep, if any.
+func (r *RateLimiter) delayBeforeNextRequest(provider string, callType string, now time.Time) time.Duration {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -90,15 +92,58 @@ func (r *RateLimiter) delayBeforeNextRequest(provider string, callType string) t
 		return 0
 	}
 
-	if info.RemainingRequests != nil && *info.RemainingRequests <= 0 && info.ResetRequestsAt != nil {
-		nowMs := time.Now().UnixMilli()
-		if nowMs < *info.ResetRequestsAt {
-			delayMs := *info.ResetRequestsAt - nowMs
-			return time.Duration(delayMs) * time.Millisecond
+	if until, nowMs := blockedUntil(info), now.UnixMilli(); nowMs < until {
+		return time.Duration(until-nowMs) * time.Millisecond
+	}
+	return 0
+}
+
+// blockedUntil returns the time (Unix ms) until which info forbids requests: the latest reset of the
+// exhausted limits (see NormalizedRateLimitInfo.Limits), or 0 if none is exhausted.
+func blockedUntil(info *NormalizedRateLimitInfo) int64 {
+	var until int64
+	for _, d := range info.Limits() {
+		if d.Remaining != nil && *d.Remaining <= 0 && d.ResetAt != nil && *d.ResetAt > until {
+			until = *d.ResetAt
 		}
 	}
+	return until
+}
 
-	return 0
+// resetAt returns the known reset time (Unix ms) for provider and callType, or nil if unknown. While
+// the token limit is the exhausted one, that is the token reset time.
+func (r *RateLimiter) resetAt(provider string, callType string) *int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, ok := r.providerLimits[provider+":"+callType]
+	if !ok || info == nil {
+		return nil
+	}
+	if until := blockedUntil(info); until > 0 {
+		return &until
+	}
+	if info.ResetRequestsAt == nil {
+		return nil
+	}
+	reset := *info.ResetRequestsAt
+	return &reset
+}
+
+// providerInfo returns copies of the stored rate limit info of every call type of provider.
+func (r *RateLimiter) providerInfo(provider string) map[string]*NormalizedRateLimitInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make(map[string]*NormalizedRateLimitInfo)
+	prefix := provider + ":"
+	for key, info := range r.providerLimits {
+		if info == nil || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		result[strings.TrimPrefix(key, prefix)] = info.clone()
+	}
+	return result
 }
 
 // GetRateLimitInfo returns a copy of the rate limit info for a given provider's "rest" call type.
@@ -108,9 +153,36 @@ func (r *RateLimiter) GetRateLimitInfo(provider string) *NormalizedRateLimitInfo
 	defer r.mu.Unlock()
 
 	key := provider + ":rest"
-	if info, ok := r.providerLimits[key]; ok {
-		copyInfo := *info
-		return &copyInfo
+	if info, ok := r.providerLimits[key]; ok && info != nil {
+		return info.clone()
 	}
 	return nil
 }
+
+// tokenBucket returns the token bucket for provider and callType, creating it on first use.
+// It returns nil if the config does not select LimiterTokenBucket or no rate can be determined.
+func (r *RateLimiter) tokenBucket(provider string, callType string, config *ProviderConfig, clock Clock) *TokenBucketLimiter {
+	if config.RateLimitAlgorithm != LimiterTokenBucket {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := provider + ":" + callType
+	if bucket, ok := r.buckets[key]; ok {
+		return bucket
+	}
+
+	rate := config.TokenBucketRate
+	if rate <= 0 && config.MaxRequestsOverride != nil && config.WindowSecsOverride != nil && *config.WindowSecsOverride > 0 {
+		rate = float64(*config.MaxRequestsOverride) / float64(*config.WindowSecsOverride)
+	}
+	if rate <= 0 {
+		return nil
+	}
+
+	bucket := newTokenBucketLimiter(rate, config.TokenBucketBurst, clock)
+	r.buckets[key] = bucket
+	return bucket
+}
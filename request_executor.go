@@ -4,16 +4,33 @@
 // with retry logic, exponential backoff, and handling rate limit (429) responses.
 // It integrates with the RateLimiter and ProviderAdapter interfaces to determine
 // how to retry and when to respect provider-specific rate limits. It also checks
-// for Retry-After headers and applies jitter to wait durations.
+// for Retry-After headers (seconds or HTTP-date), falling back to the adapter's RateLimitWaiter when the
+// header is absent, and applies jitter to wait durations.
+// Exponential backoff uses full jitter: each wait is drawn uniformly from zero up to the exponential
+// value, which is itself capped at ProviderConfig.MaxBackoff (DefaultMaxBackoff, 30s, when unset).
 //
 // The ExecuteWithRetry method is the core entry point, called by the SDK to issue
 // a request repeatedly until success or until the configured max retries are reached.
+// Server and network errors are only retried when ProviderConfig.RetryPolicy allows it for the request.
+// ProviderConfig.Retry, when set, supplies all of these settings instead (see retry_config.go).
+// ProviderConfig.MaxRetryElapsed additionally bounds the total time spent: a retry whose wait would end
+// past the budget is not attempted, and the last failure is returned wrapped in a *RetryBudgetError.
+// All waits go through the provider's Clock (ProviderConfig.Clock, or the SDK's) and abort as soon as the
+// request context is cancelled.
+// ProviderConfig.DisableRateLimiting skips the endpoint limit, token bucket, and preemptive waits; 429
+// responses are handled as usual.
+// ProviderConfig.RetryableErrorClassifier, when set, decides about every attempt that is neither a terminal
+// adapter error nor a rate limit, ahead of the status code handling (see retry_classifier.go).
+// ProviderConfig.RetryOnDecodeError retries 2xx bodies that sdk.RequestJSON could not decode (see
+// decode_retry.go), and ProviderConfig.ResponseValidator applies caller-defined success criteria to 2xx
+// bodies (see response_validator.go).
 package resilientbridge
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
-	"strconv"
 	"time"
 )
 
@@ -25,33 +42,184 @@ func NewRequestExecutor(sdk *ResilientBridge) *RequestExecutor {
 	return &RequestExecutor{sdk: sdk}
 }
 
-func (re *RequestExecutor) ExecuteWithRetry(providerName string, callType string, operation func() (*NormalizedResponse, error), adapter ProviderAdapter) (*NormalizedResponse, error) {
+func (re *RequestExecutor) ExecuteWithRetry(ctx context.Context, providerName string, callType string, req *NormalizedRequest, operation func() (*NormalizedResponse, error), adapter ProviderAdapter) (*NormalizedResponse, error) {
 	config := re.sdk.getProviderConfig(providerName)
-	maxRetries := config.MaxRetries
-	baseBackoff := config.BaseBackoff
-	if baseBackoff == 0 {
-		baseBackoff = time.Second
+	retry := config.retryConfig()
+	maxRetries := retry.MaxRetries
+	baseBackoff := retry.BaseBackoff
+	switch {
+	case baseBackoff == 0:
+		baseBackoff = DefaultBaseBackoff
+	case baseBackoff < 0:
+		baseBackoff = 0
+	}
+	maxBackoff := retry.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
 	}
 
+	clock := re.sdk.clockFor(config)
+	start := clock.Now()
 	attempts := 0
+
+	// decide carries out config.RetryableErrorClassifier's decision about an attempt. decided is false when
+	// there is no classifier or it left the decision to the built-in handling; otherwise again reports
+	// that the wait before the next attempt is over, or the request ends with final.
+	decide := func(resp *NormalizedResponse, err error) (decided, again bool, final error) {
+		if config.RetryableErrorClassifier == nil {
+			return false, false, nil
+		}
+		decision := config.RetryableErrorClassifier(resp, err)
+		if decision == nil {
+			return false, false, nil
+		}
+		failure := err
+		if failure == nil {
+			failure = newHTTPError(providerName, resp, adapter)
+		}
+		switch d := decision.(type) {
+		case Success:
+			if resp != nil {
+				return true, false, nil
+			}
+		case Fail:
+			if d.Err != nil {
+				return true, false, d.Err
+			}
+		case Retry:
+			if attempts >= maxRetries {
+				re.sdk.debugf("Provider %s (callType=%s): Classifier requested a retry, max retries reached. Giving up.\n", providerName, callType)
+				return true, false, failure
+			}
+			wait := d.After
+			if wait <= 0 {
+				wait = re.calculateBackoffWithJitter(baseBackoff, maxBackoff, attempts)
+			}
+			if re.retryBudgetExceeded(clock, retry, start, wait) {
+				re.sdk.debugf("Provider %s (callType=%s): Classifier requested a retry, retry budget exhausted. Giving up.\n", providerName, callType)
+				return true, false, re.newRetryBudgetError(clock, providerName, start, attempts+1, failure)
+			}
+			re.sdk.debugf("Provider %s (callType=%s): Classifier requested a retry. Retrying in %v (attempt %d/%d)...\n", providerName, callType, wait, attempts+1, maxRetries)
+			if err := re.waitBeforeRetry(ctx, clock, config, req, resp, attempts+1, wait); err != nil {
+				return true, false, err
+			}
+			return true, true, nil
+		}
+		return true, false, failure
+	}
+
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Respect user-defined limits for the endpoint, if one matches. The slot is taken here and given back
+		// by unreserve if the attempt ends before it is sent.
+		unreserve := func() {}
+		if req != nil && len(config.EndpointLimits) > 0 && !config.DisableRateLimiting {
+			for {
+				now := clock.Now()
+				delay := re.sdk.rateLimiter.reserveEndpoint(providerName, callType, req.Method, req.Endpoint, config.EndpointLimits, config.WindowMode, now)
+				if delay <= 0 {
+					unreserve = func() {
+						re.sdk.rateLimiter.releaseEndpoint(providerName, req.Method, req.Endpoint, config.EndpointLimits, now)
+					}
+					break
+				}
+				if !config.RateLimitBehavior.allowsWait(start, clock.Now(), delay) {
+					return nil, newRateLimitError(providerName, clock.Now(), delay)
+				}
+				re.sdk.debugf("Provider %s (callType=%s): Endpoint limit reached for %s, waiting %v.\n", providerName, callType, req.Endpoint, delay)
+				notifyThrottle(ctx, providerName, callType, delay)
+				if err := sleepContext(ctx, clock, delay); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// Pace the attempt through the token bucket, if one is configured
+		if bucket := re.sdk.rateLimiter.tokenBucket(providerName, callType, config, clock); bucket != nil && !config.DisableRateLimiting {
+			if next := bucket.NextTokenIn(); !config.RateLimitBehavior.allowsWait(start, clock.Now(), next) {
+				re.sdk.debugf("Provider %s (callType=%s): Token bucket empty for %v. Not waiting.\n", providerName, callType, next)
+				unreserve()
+				return nil, newRateLimitError(providerName, clock.Now(), next)
+			}
+			if delay := bucket.Reserve(); delay > 0 {
+				re.sdk.debugf("Provider %s (callType=%s): Token bucket pacing, waiting %v.\n", providerName, callType, delay)
+				notifyThrottle(ctx, providerName, callType, delay)
+				if err := sleepContext(ctx, clock, delay); err != nil {
+					unreserve()
+					return nil, err
+				}
+			}
+		}
+
 		// Preemptively wait if the SDK knows we must delay due to rate limit info
-		if !re.sdk.rateLimiter.canProceed(providerName, callType) {
-			delay := re.sdk.rateLimiter.delayBeforeNextRequest(providerName, callType)
+		if !config.DisableRateLimiting && !re.sdk.rateLimiter.canProceed(providerName, callType, clock.Now()) {
+			delay := re.sdk.rateLimiter.delayBeforeNextRequest(providerName, callType, clock.Now())
 			if delay > 0 && re.sdk.Debug {
 				fmt.Printf("[DEBUG] Provider %s (callType=%s): Must wait %v due to preemptive rate limit.\n", providerName, callType, delay)
 			}
-			time.Sleep(delay)
+			re.notifyRateLimited(config, providerName, callType)
+			if !config.RateLimitBehavior.allowsWait(start, clock.Now(), delay) {
+				unreserve()
+				return nil, newRateLimitError(providerName, clock.Now(), delay)
+			}
+			notifyThrottle(ctx, providerName, callType, delay)
+			if err := sleepContext(ctx, clock, delay); err != nil {
+				unreserve()
+				return nil, err
+			}
+		}
+
+		// Hold one of the provider's concurrency slots, if limited, only while the attempt is in flight
+		release, err := re.sdk.acquireSlot(ctx, providerName, config.MaxConcurrency)
+		if err != nil {
+			unreserve()
+			return nil, err
 		}
 
 		re.sdk.debugf("Provider %s (callType=%s): Sending request (attempt %d)...\n", providerName, callType, attempts+1)
+		if config.OnRequest != nil {
+			config.OnRequest(req)
+		}
 		resp, err := operation()
+		release()
+		if resp != nil {
+			re.sdk.checkDeprecation(config, providerName, req, resp, adapter)
+		}
+		if resp != nil && config.OnResponse != nil {
+			config.OnResponse(req, resp, attempts+1)
+		}
+		if errors.Is(err, ErrResponseTooLarge) {
+			// Retrying would only download the same oversized body again
+			re.sdk.debugf("Provider %s (callType=%s): %v. Not retrying.\n", providerName, callType, err)
+			return resp, err
+		}
 		if err != nil {
+			if decided, again, final := decide(nil, err); decided {
+				if again {
+					attempts++
+					continue
+				}
+				return nil, final
+			}
+
 			// Non-HTTP/network error
+			if attempts < maxRetries && !retryAllowed(retry, req, nil, err) {
+				re.sdk.debugf("Provider %s (callType=%s): Operation error: %v. Not retrying %s: not idempotent.\n", providerName, callType, err, req.Method)
+				return nil, err
+			}
 			if attempts < maxRetries {
-				wait := re.calculateBackoffWithJitter(baseBackoff, attempts)
+				wait := re.calculateBackoffWithJitter(baseBackoff, maxBackoff, attempts)
+				if re.retryBudgetExceeded(clock, retry, start, wait) {
+					re.sdk.debugf("Provider %s (callType=%s): Operation error: %v. Retry budget exhausted, giving up.\n", providerName, callType, err)
+					return nil, re.newRetryBudgetError(clock, providerName, start, attempts+1, err)
+				}
 				re.sdk.debugf("Provider %s (callType=%s): Operation error: %v. Retrying in %v (attempt %d/%d)...\n", providerName, callType, err, wait, attempts+1, maxRetries)
-				time.Sleep(wait)
+				if err := re.waitBeforeRetry(ctx, clock, config, req, nil, attempts+1, wait); err != nil {
+					return nil, err
+				}
 				attempts++
 				continue
 			}
@@ -64,38 +232,129 @@ func (re *RequestExecutor) ExecuteWithRetry(providerName string, callType string
 			re.sdk.rateLimiter.UpdateRateLimits(providerName, callType, rateInfo, config)
 		}
 
+		// Terminal failures flagged by the adapter (e.g., rejected credentials) are never retried
+		if classifier, ok := adapter.(ErrorClassifier); ok {
+			if classErr := classifier.ClassifyError(resp); classErr != nil {
+				re.sdk.debugf("Provider %s (callType=%s): %v. Not retrying.\n", providerName, callType, classErr)
+				return resp, classErr
+			}
+		}
+
 		// Handle rate limit (429) responses
 		if adapter.IsRateLimitError(resp) {
+			re.notifyRateLimited(config, providerName, callType)
+			retryAfter := re.parseRetryAfter(clock, resp, retry)
+			if waiter, ok := adapter.(RateLimitWaiter); ok && retryAfter == 0 {
+				retryAfter = waiter.RateLimitWait(resp)
+			}
 			if attempts < maxRetries {
-				retryAfter := re.parseRetryAfter(resp)
+				var wait time.Duration
 				if retryAfter > 0 {
 					jitter := re.calculateJitter(retryAfter, 0.1)
-					totalWait := retryAfter + jitter
+					wait = retryAfter + jitter
 					re.sdk.debugf("Provider %s (callType=%s): 429 rate limit, Retry-After present: waiting %v (+%v jitter) before retry (attempt %d/%d).\n", providerName, callType, retryAfter, jitter, attempts+1, maxRetries)
-					time.Sleep(totalWait)
 				} else {
-					wait := re.calculateBackoffWithJitter(baseBackoff, attempts)
+					wait = re.calculateBackoffWithJitter(baseBackoff, maxBackoff, attempts)
 					re.sdk.debugf("Provider %s (callType=%s): 429 rate limit, no Retry-After header. Backing off %v before retry (attempt %d/%d)...\n", providerName, callType, wait, attempts+1, maxRetries)
-					time.Sleep(wait)
 				}
+				if re.retryBudgetExceeded(clock, retry, start, wait) {
+					re.sdk.debugf("Provider %s (callType=%s): 429 rate limit, retry budget exhausted. Giving up.\n", providerName, callType)
+					rlErr := newRateLimitError(providerName, clock.Now(), re.rateLimitResetIn(clock, providerName, callType, retryAfter))
+					return resp, re.newRetryBudgetError(clock, providerName, start, attempts+1, rlErr)
+				}
+				if config.RateLimitBehavior.allowsWait(start, clock.Now(), wait) {
+					notifyThrottle(ctx, providerName, callType, wait)
+					if err := re.waitBeforeRetry(ctx, clock, config, req, resp, attempts+1, wait); err != nil {
+						return nil, err
+					}
+					attempts++
+					continue
+				}
+				re.sdk.debugf("Provider %s (callType=%s): 429 rate limit, wait not allowed by RateLimitBehavior. Giving up.\n", providerName, callType)
+			} else {
+				re.sdk.debugf("Provider %s (callType=%s): Actual 429 encountered and max retries reached. Giving up.\n", providerName, callType)
+			}
+			return resp, newRateLimitError(providerName, clock.Now(), re.rateLimitResetIn(clock, providerName, callType, retryAfter))
+		}
+
+		// Let the caller's classifier decide before the status code handling
+		if decided, again, final := decide(resp, nil); decided {
+			if again {
 				attempts++
 				continue
 			}
-			re.sdk.debugf("Provider %s (callType=%s): Actual 429 encountered and max retries reached. Giving up.\n", providerName, callType)
-			return resp, fmt.Errorf("rate limit exceeded and max retries reached")
+			return resp, final
 		}
 
 		// Handle server errors (5xx)
+		if resp.StatusCode >= 500 && attempts < maxRetries && !retryAllowed(retry, req, resp, nil) {
+			re.sdk.debugf("Provider %s (callType=%s): Server error %d. Not retrying %s: not idempotent.\n", providerName, callType, resp.StatusCode, req.Method)
+			return resp, newHTTPError(providerName, resp, adapter)
+		}
 		if resp.StatusCode >= 500 && attempts < maxRetries {
-			wait := re.calculateBackoffWithJitter(baseBackoff, attempts)
+			wait := re.calculateBackoffWithJitter(baseBackoff, maxBackoff, attempts)
+			if re.retryBudgetExceeded(clock, retry, start, wait) {
+				re.sdk.debugf("Provider %s (callType=%s): Server error %d, retry budget exhausted. Giving up.\n", providerName, callType, resp.StatusCode)
+				return resp, re.newRetryBudgetError(clock, providerName, start, attempts+1, newHTTPError(providerName, resp, adapter))
+			}
 			re.sdk.debugf("Provider %s (callType=%s): Server error %d. Retrying in %v (attempt %d/%d)...\n", providerName, callType, resp.StatusCode, wait, attempts+1, maxRetries)
-			time.Sleep(wait)
+			if err := re.waitBeforeRetry(ctx, clock, config, req, resp, attempts+1, wait); err != nil {
+				return nil, err
+			}
 			attempts++
 			continue
 		} else if resp.StatusCode >= 400 {
-			// Client error (4xx), do not retry
-			re.sdk.debugf("Provider %s (callType=%s): Client error %d encountered. Not retrying.\n", providerName, callType, resp.StatusCode)
-			return resp, fmt.Errorf("client error: %d", resp.StatusCode)
+			// Client error (4xx), or server error after max retries: do not retry
+			re.sdk.debugf("Provider %s (callType=%s): Error status %d encountered. Not retrying.\n", providerName, callType, resp.StatusCode)
+			return resp, newHTTPError(providerName, resp, adapter)
+		}
+
+		// Bodies RequestJSON cannot decode, with RetryOnDecodeError (see decode_retry.go)
+		if decodeErr := malformedBody(ctx, resp); decodeErr != nil {
+			if attempts < maxRetries && retryAllowed(retry, req, resp, decodeErr) {
+				wait := re.calculateBackoffWithJitter(baseBackoff, maxBackoff, attempts)
+				if re.retryBudgetExceeded(clock, retry, start, wait) {
+					re.sdk.debugf("Provider %s (callType=%s): Malformed body, retry budget exhausted. Giving up.\n", providerName, callType)
+					return resp, re.newRetryBudgetError(clock, providerName, start, attempts+1, decodeErr)
+				}
+				re.sdk.debugf("Provider %s (callType=%s): Malformed body. Retrying in %v (attempt %d/%d)...\n", providerName, callType, wait, attempts+1, maxRetries)
+				if err := re.waitBeforeRetry(ctx, clock, config, req, resp, attempts+1, wait); err != nil {
+					return nil, err
+				}
+				attempts++
+				continue
+			}
+			re.sdk.debugf("Provider %s (callType=%s): Malformed body. Not retrying.\n", providerName, callType)
+			return resp, decodeErr
+		}
+
+		// Caller-defined success criteria for 2xx bodies (see response_validator.go)
+		if validatesBody(ctx, config) && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if invalid := config.ResponseValidator(resp); invalid != nil {
+				var retryable *RetryableError
+				if !errors.As(invalid, &retryable) {
+					re.sdk.debugf("Provider %s (callType=%s): Response rejected by validator: %v. Not retrying.\n", providerName, callType, invalid)
+					return resp, invalid
+				}
+				if attempts >= maxRetries {
+					re.sdk.debugf("Provider %s (callType=%s): Response rejected by validator, max retries reached. Giving up.\n", providerName, callType)
+					return resp, retryable.Err
+				}
+				wait := retryable.After
+				if wait <= 0 {
+					wait = re.calculateBackoffWithJitter(baseBackoff, maxBackoff, attempts)
+				}
+				if re.retryBudgetExceeded(clock, retry, start, wait) {
+					re.sdk.debugf("Provider %s (callType=%s): Response rejected by validator, retry budget exhausted. Giving up.\n", providerName, callType)
+					return resp, re.newRetryBudgetError(clock, providerName, start, attempts+1, retryable.Err)
+				}
+				re.sdk.debugf("Provider %s (callType=%s): Response rejected by validator: %v. Retrying in %v (attempt %d/%d)...\n", providerName, callType, invalid, wait, attempts+1, maxRetries)
+				if err := re.waitBeforeRetry(ctx, clock, config, req, resp, attempts+1, wait); err != nil {
+					return nil, err
+				}
+				attempts++
+				continue
+			}
 		}
 
 		// Success
@@ -108,25 +367,83 @@ func (re *RequestExecutor) ExecuteWithRetry(providerName string, callType string
 	}
 }
 
-func (re *RequestExecutor) calculateBackoffWithJitter(base time.Duration, attempt int) time.Duration {
-	backoff := base * (1 << attempt)
-	if backoff > 30*time.Second {
-		backoff = 30 * time.Second
+// waitBeforeRetry invokes the OnRetry callback and then waits for d, aborting early if ctx is cancelled.
+// resp is nil when the attempt failed without an HTTP response.
+func (re *RequestExecutor) waitBeforeRetry(ctx context.Context, clock Clock, config *ProviderConfig, req *NormalizedRequest, resp *NormalizedResponse, attempt int, d time.Duration) error {
+	if config.OnRetry != nil {
+		config.OnRetry(req, resp, attempt, d)
+	}
+	return sleepContext(ctx, clock, d)
+}
+
+// retryBudgetExceeded reports whether waiting d before the next attempt would take a request that
+// started at start past retry.MaxRetryElapsed.
+func (re *RequestExecutor) retryBudgetExceeded(clock Clock, retry *RetryConfig, start time.Time, d time.Duration) bool {
+	return retry.MaxRetryElapsed > 0 && clock.Now().Add(d).Sub(start) > retry.MaxRetryElapsed
+}
+
+// newRetryBudgetError wraps the last failure of a request that ran out of retry budget.
+func (re *RequestExecutor) newRetryBudgetError(clock Clock, providerName string, start time.Time, attempts int, err error) *RetryBudgetError {
+	return &RetryBudgetError{
+		Provider: providerName,
+		Attempts: attempts,
+		Elapsed:  clock.Now().Sub(start),
+		Err:      err,
+	}
+}
+
+// notifyRateLimited invokes the OnRateLimited callback with the last known reset time, if any.
+func (re *RequestExecutor) notifyRateLimited(config *ProviderConfig, providerName string, callType string) {
+	if config.OnRateLimited != nil {
+		config.OnRateLimited(providerName, re.sdk.rateLimiter.resetAt(providerName, callType))
 	}
-	jitterFactor := 0.5
-	jitter := time.Duration(rand.Float64() * float64(backoff) * jitterFactor)
-	return backoff + jitter
 }
 
-func (re *RequestExecutor) parseRetryAfter(resp *NormalizedResponse) time.Duration {
-	if val, ok := resp.Headers["retry-after"]; ok {
-		if seconds, err := strconv.Atoi(val); err == nil {
-			return time.Duration(seconds) * time.Second
+// rateLimitResetIn returns how long until a rate limit lifts: the Retry-After value if present,
+// otherwise the reset time last reported by the provider, or 0 if unknown.
+func (re *RequestExecutor) rateLimitResetIn(clock Clock, providerName string, callType string, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	if resetAt := re.sdk.rateLimiter.resetAt(providerName, callType); resetAt != nil {
+		if d := time.UnixMilli(*resetAt).Sub(clock.Now()); d > 0 {
+			return d
 		}
 	}
 	return 0
 }
 
+// calculateBackoffWithJitter returns the wait before retry number attempt+1: a uniformly random duration
+// between 0 and base*2^attempt, capped at maxBackoff ("full jitter"). Drawing from the whole range keeps
+// many goroutines failing together from retrying in lockstep.
+func (re *RequestExecutor) calculateBackoffWithJitter(base, maxBackoff time.Duration, attempt int) time.Duration {
+	backoff := maxBackoff
+	if attempt < 62 && base < maxBackoff>>attempt {
+		backoff = base << attempt
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// parseRetryAfter returns the wait requested by resp's Retry-After header (seconds or HTTP-date),
+// capped at retry.MaxRetryAfter when set. It returns 0 when the header is absent or invalid.
+func (re *RequestExecutor) parseRetryAfter(clock Clock, resp *NormalizedResponse, retry *RetryConfig) time.Duration {
+	val, ok := resp.Headers["retry-after"]
+	if !ok {
+		return 0
+	}
+	wait, ok := ParseRetryAfter(val, clock.Now())
+	if !ok {
+		return 0
+	}
+	if retry.MaxRetryAfter > 0 && wait > retry.MaxRetryAfter {
+		wait = retry.MaxRetryAfter
+	}
+	return wait
+}
+
 func (re *RequestExecutor) calculateJitter(base time.Duration, fraction float64) time.Duration {
 	jitter := time.Duration(rand.Float64() * float64(base) * fraction)
 	return jitter
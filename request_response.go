@@ -6,22 +6,151 @@
 //
 // NormalizedRateLimitInfo holds parsed rate limit details (like max requests, remaining,
 // and reset time) that adapters can extract from response headers.
+// Besides requests, an info can carry tokens (LLM APIs' tokens per minute), points (GitHub GraphQL's
+// point budget), and further named limits in Dimensions. Limits returns all of them uniformly, and the
+// SDK waits out whichever one is exhausted.
+//
+// NormalizedRequest.Tags carries caller-defined metadata (an operation name, a tenant id) for
+// correlating requests in logs, metrics, and the ProviderConfig callbacks. Tags are observability-only:
+// adapters never send them to the provider.
+//
+// NormalizedRequest.BaseURLOverride sends one request to a different host than the adapter's default
+// (GitHub's uploads.github.com, a regional endpoint) without registering another provider, so its rate
+// limits are still tracked under the same provider. Adapters build the request URL with URL (or BaseURL,
+// when they rewrite the endpoint first), which also passes absolute endpoints through unchanged. Adapters
+// only attach their credentials to https URLs that TrustedHost accepts: the provider's own hosts, plus
+// the host of a BaseURLOverride the caller set (TrustedOverride), such as a GitHub Enterprise server. An
+// absolute endpoint, which may have come from a response, gets the request without them unless it is on
+// one of the provider's hosts, as redirects do with FollowLocationStripAuth.
+//
+// NormalizedResponse.JSON and JSONArrayLen decode Data; their errors include the status code and the
+// start of the body, so an HTML error page or a truncated payload is recognizable from the error alone.
+//
+// StreamResponse is the unbuffered counterpart of NormalizedResponse, used for large downloads
+// where the body should be consumed incrementally instead of being held in memory.
 //
 // Together, these types ensure a consistent interface for requests and responses across
 // all providers.
 package resilientbridge
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
 type NormalizedRequest struct {
 	Method   string
 	Endpoint string
 	Headers  map[string]string
 	Body     []byte
+
+	Tags map[string]string // Observability-only metadata, e.g. {"op": "enrich_repo", "org": "acme"}; never sent
+
+	Idempotent bool // Safe to retry after a 5xx or network error even if the method is POST or PATCH (see RetryPolicy)
+
+	BaseURLOverride string // Replaces the adapter's base URL for this request, e.g. "https://uploads.github.com"; ignored for absolute endpoints
+
+	FollowLocationStripAuth bool // Follow redirects, sending no credentials to other hosts (signed storage URLs; see follow_location.go)
+}
+
+// BaseURL returns the base URL an adapter should join r's endpoint to: BaseURLOverride (without a trailing
+// slash) when set, otherwise defaultBase.
+func (r *NormalizedRequest) BaseURL(defaultBase string) string {
+	if r.BaseURLOverride != "" {
+		return strings.TrimRight(r.BaseURLOverride, "/")
+	}
+	return defaultBase
+}
+
+// URL returns the URL to send r to: the endpoint itself when it is an absolute http(s) URL, otherwise the
+// endpoint joined to BaseURL(defaultBase).
+func (r *NormalizedRequest) URL(defaultBase string) string {
+	if isAbsoluteURL(r.Endpoint) {
+		return r.Endpoint
+	}
+	return r.BaseURL(defaultBase) + r.Endpoint
+}
+
+// TrustedOverride returns r's BaseURLOverride when it applies to r (the endpoint is a path) and "" otherwise,
+// for adapters to pass to TrustedHost along with the provider's hosts.
+func (r *NormalizedRequest) TrustedOverride() string {
+	if isAbsoluteURL(r.Endpoint) {
+		return ""
+	}
+	return r.BaseURLOverride
+}
+
+// TrustedHost reports whether rawURL may be sent an adapter's credentials: it is an https URL and its host
+// is one of domains or a subdomain of one ("github.com" trusts api.github.com and uploads.github.com). A
+// domain may also be given as a URL, such as an adapter's configured base URL, whose host is then used.
+// Ports are ignored, and empty domains are skipped.
+func TrustedHost(rawURL string, domains ...string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" || !strings.EqualFold(u.Scheme, "https") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, domain := range domains {
+		if strings.Contains(domain, "://") {
+			d, err := url.Parse(domain)
+			if err != nil {
+				continue
+			}
+			domain = d.Hostname()
+		}
+		domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAbsoluteURL reports whether endpoint is a full http or https URL rather than a path.
+func isAbsoluteURL(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://")
 }
 
 type NormalizedResponse struct {
 	StatusCode int
 	Headers    map[string]string
 	Data       []byte
+
+	Deprecation *Deprecation // Set by the SDK when the adapter reports the endpoint as deprecated
+}
+
+// JSON unmarshals the response body into out.
+func (r *NormalizedResponse) JSON(out any) error {
+	if err := json.Unmarshal(r.Data, out); err != nil {
+		return r.decodeError(err)
+	}
+	return nil
+}
+
+// JSONArrayLen returns the number of elements of a JSON array body, without decoding the elements.
+func (r *NormalizedResponse) JSONArrayLen() (int, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(r.Data, &items); err != nil {
+		return 0, r.decodeError(err)
+	}
+	return len(items), nil
+}
+
+// decodeError wraps err with the status code and a preview of the body.
+func (r *NormalizedResponse) decodeError(err error) error {
+	preview := previewText(strings.TrimSpace(string(r.Data)))
+	return fmt.Errorf("error decoding JSON response (status %d, body %q): %w", r.StatusCode, preview, err)
+}
+
+// StreamResponse carries the status and headers of a response whose body has not been read.
+// The caller is responsible for closing Body.
+type StreamResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       io.ReadCloser
 }
 
 type NormalizedRateLimitInfo struct {
@@ -33,7 +162,51 @@ type NormalizedRateLimitInfo struct {
 	RemainingTokens *int
 	ResetTokensAt   *int64
 
+	MaxPoints       *int
+	RemainingPoints *int
+	ResetPointsAt   *int64
+
 	GlobalResetAt *int64
+
+	Dimensions map[string]Dimension // Further named limits, e.g. {"images": ...}; nil if none
+}
+
+// Dimension is one limit of a provider: how much is allowed, how much is left, and when it resets.
+type Dimension struct {
+	Max       *int
+	Remaining *int
+	ResetAt   *int64 // Unix ms
+}
+
+// Limits returns every limit info reports, keyed "requests", "tokens", "points", or the Dimensions name.
+// Dimensions entries don't replace the built-in ones.
+func (i *NormalizedRateLimitInfo) Limits() map[string]Dimension {
+	limits := make(map[string]Dimension, len(i.Dimensions)+3)
+	for name, d := range i.Dimensions {
+		limits[name] = d
+	}
+	for name, d := range map[string]Dimension{
+		"requests": {Max: i.MaxRequests, Remaining: i.RemainingRequests, ResetAt: i.ResetRequestsAt},
+		"tokens":   {Max: i.MaxTokens, Remaining: i.RemainingTokens, ResetAt: i.ResetTokensAt},
+		"points":   {Max: i.MaxPoints, Remaining: i.RemainingPoints, ResetAt: i.ResetPointsAt},
+	} {
+		if d.Max != nil || d.Remaining != nil || d.ResetAt != nil {
+			limits[name] = d
+		}
+	}
+	return limits
+}
+
+// clone returns a copy of i that shares no map with it.
+func (i *NormalizedRateLimitInfo) clone() *NormalizedRateLimitInfo {
+	c := *i
+	if i.Dimensions != nil {
+		c.Dimensions = make(map[string]Dimension, len(i.Dimensions))
+		for name, d := range i.Dimensions {
+			c.Dimensions[name] = d
+		}
+	}
+	return &c
 }
 
 // IntPtr is a helper to quickly create an *int from an int.
@@ -0,0 +1,76 @@
+// request_timeout.go
+// ------------------
+// This file implements ProviderConfig.RequestTimeout, a deadline on each individual attempt. The caller's
+// context bounds the whole request (retries and waits included); RequestTimeout bounds a single HTTP call,
+// so a connection that hangs without ever answering fails that attempt and is retried like any other
+// network error instead of stalling the request until the caller gives up.
+//
+// An attempt that runs into RequestTimeout fails with an error matching ErrRequestTimeout. The caller's
+// own cancellation or deadline is reported as ctx.Err() as before.
+//
+// For sdk.RequestStream the timeout covers the attempt up to the response headers; reading the streamed
+// body is bounded by the caller's context only, so long downloads are not cut off.
+//
+// The timeout takes effect through the context handed to the adapter, i.e. for adapters implementing
+// ContextAdapter or StreamingAdapter (all adapters in this repository).
+package resilientbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// attemptContext is the context of one attempt, cancelled with ErrRequestTimeout once timeout elapses.
+type attemptContext struct {
+	context.Context
+	timeout time.Duration
+	timer   *time.Timer
+	cancel  context.CancelCauseFunc
+}
+
+// newAttemptContext derives the context of one attempt from ctx. A timeout <= 0 sets no deadline.
+func newAttemptContext(ctx context.Context, timeout time.Duration) *attemptContext {
+	actx, cancel := context.WithCancelCause(ctx)
+	a := &attemptContext{Context: actx, timeout: timeout, cancel: cancel}
+	if timeout > 0 {
+		a.timer = time.AfterFunc(timeout, func() { cancel(ErrRequestTimeout) })
+	}
+	return a
+}
+
+// disarm stops the timeout without cancelling the context, once a streamed response's headers are in.
+func (a *attemptContext) disarm() {
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+}
+
+// done releases the attempt's context.
+func (a *attemptContext) done() {
+	a.disarm()
+	a.cancel(nil)
+}
+
+// timeoutError returns err as an ErrRequestTimeout if the attempt was cut off by its timeout rather
+// than by the caller's context.
+func (a *attemptContext) timeoutError(err error) error {
+	if err == nil || !errors.Is(context.Cause(a.Context), ErrRequestTimeout) {
+		return err
+	}
+	return fmt.Errorf("%w after %v: %v", ErrRequestTimeout, a.timeout, err)
+}
+
+// attemptBody releases a streamed attempt's context when the body is closed.
+type attemptBody struct {
+	io.ReadCloser
+	attempt *attemptContext
+}
+
+func (b *attemptBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.attempt.done()
+	return err
+}
@@ -0,0 +1,86 @@
+// request_validation.go
+// ---------------------
+// This file checks a NormalizedRequest before it is sent, so that malformed requests fail at once with a
+// *RequestError naming the problem, rather than deep in net/http or with a provider's 400 or 404. Request,
+// RequestWithContext, RequestJSON, RequestStream, and the paginators all run the check; it rejects:
+//   - a nil request
+//   - a Method that is empty, not one of the standard HTTP methods, or not upper case (net/http sends
+//     the method as written, and "get" is not GET to most servers)
+//   - an empty Endpoint, or one that is neither a path starting with "/" nor an absolute http(s) URL with
+//     a host (a URL missing its scheme, such as "api.github.com/repos", would be appended to the base URL)
+//   - an Endpoint that net/url cannot parse (control characters, bad percent-encoding in the path)
+//   - an absolute Endpoint for an adapter that reports it cannot send one (see capabilities.go); that
+//     error also matches ErrUnsupportedCapability
+//
+// Bodies are not checked: whether a POST or PUT needs one depends on the API.
+package resilientbridge
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// httpMethods are the methods a NormalizedRequest may use.
+var httpMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+	http.MethodConnect: true,
+	http.MethodTrace:   true,
+}
+
+// validateRequest returns a *RequestError if req cannot be sent to providerName's adapter.
+func validateRequest(providerName string, adapter ProviderAdapter, req *NormalizedRequest) error {
+	if req == nil {
+		return &RequestError{Provider: providerName, Reason: "request is nil"}
+	}
+	invalid := func(reason string) error {
+		return &RequestError{Provider: providerName, Method: req.Method, Endpoint: req.Endpoint, Reason: reason}
+	}
+
+	switch upper := strings.ToUpper(req.Method); {
+	case req.Method == "":
+		return invalid("method is empty")
+	case !httpMethods[upper]:
+		return invalid("unknown HTTP method")
+	case upper != req.Method:
+		return invalid("method must be upper case: use " + upper)
+	}
+
+	endpoint := req.Endpoint
+	switch {
+	case endpoint == "":
+		return invalid("endpoint is empty")
+	case isAbsoluteURL(endpoint):
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return invalid(parseErrorReason(err))
+		}
+		if u.Host == "" {
+			return invalid("absolute URL has no host")
+		}
+		return checkAbsoluteURL(providerName, adapter, req)
+	case strings.Contains(endpoint, "://"):
+		return invalid("only http and https URLs are supported")
+	case !strings.HasPrefix(endpoint, "/"):
+		return invalid(`endpoint must start with "/" or be an absolute http(s) URL`)
+	}
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return invalid(parseErrorReason(err))
+	}
+	return nil
+}
+
+// parseErrorReason returns the cause of a net/url parse error without the quoted URL, which the
+// RequestError already shows.
+func parseErrorReason(err error) string {
+	if urlErr, ok := err.(*url.Error); ok {
+		return "invalid endpoint: " + urlErr.Err.Error()
+	}
+	return "invalid endpoint: " + err.Error()
+}
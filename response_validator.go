@@ -0,0 +1,109 @@
+// response_validator.go
+// ---------------------
+// This file implements ProviderConfig.ResponseValidator, for APIs that report failures in the body of a
+// 2xx response ({"ok": false} from Slack, {"success": false} from Cloudflare), which status code handling
+// alone takes for successes.
+//
+// The executor calls the validator on every 2xx attempt that was not already settled by the adapter's
+// ClassifyError, a rate limit, or the RetryableErrorClassifier. A nil error accepts the response. An error
+// wrapped with Retryable sends the request again, after RetryableError.After or the usual backoff,
+// counting against MaxRetries and MaxRetryElapsed (as with the classifier, RetryPolicy is not consulted:
+// the validator has judged the request safe to resend). Any other error ends the request and is returned
+// with the response.
+//
+// RequestStream never runs the validator: its bodies are handed to the caller unread, so there is nothing
+// for a body check to look at. RequestStream marks its context with withStreamedBody to that effect.
+//
+// JSONSuccessValidator builds a validator for a boolean success flag. Ready-made ones cover:
+//   - SlackOK: "ok", retrying Slack's transient errors (internal_error, fatal_error, service_unavailable,
+//     request_timeout, ratelimited)
+//   - CloudflareSuccess: "success"
+//
+// Their errors wrap ErrUnsuccessfulResponse and carry the provider's message (see ExtractErrorMessage).
+package resilientbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ResponseValidator checks a 2xx response, returning nil to accept it, a Retryable error to send the
+// request again, or any other error to fail the request.
+type ResponseValidator func(resp *NormalizedResponse) error
+
+type streamedBodyKey struct{}
+
+// withStreamedBody marks ctx as belonging to RequestStream, whose attempts carry no buffered body.
+func withStreamedBody(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamedBodyKey{}, true)
+}
+
+// validatesBody reports whether config.ResponseValidator applies to the attempts of ctx.
+func validatesBody(ctx context.Context, config *ProviderConfig) bool {
+	streamed, _ := ctx.Value(streamedBodyKey{}).(bool)
+	return config.ResponseValidator != nil && !streamed
+}
+
+// RetryableError marks a ResponseValidator error as transient.
+type RetryableError struct {
+	Err   error
+	After time.Duration // Wait before the retry; 0 = the usual exponential backoff
+}
+
+func (e *RetryableError) Error() string { return e.Err.Error() }
+func (e *RetryableError) Unwrap() error { return e.Err }
+
+// Retryable returns err marked as transient, for a ResponseValidator to request a retry.
+func Retryable(err error) error {
+	return &RetryableError{Err: err}
+}
+
+var (
+	SlackOK           ResponseValidator = slackOK
+	CloudflareSuccess ResponseValidator = JSONSuccessValidator("success")
+)
+
+// JSONSuccessValidator returns a ResponseValidator failing responses whose boolean at path in a JSON
+// object body is false. A missing or non-boolean flag, or a body that isn't a JSON object, is accepted.
+func JSONSuccessValidator(path ...string) ResponseValidator {
+	return func(resp *NormalizedResponse) error {
+		if ok, found := jsonBool(resp.Data, path); found && !ok {
+			return unsuccessful(resp)
+		}
+		return nil
+	}
+}
+
+// slackRetryableErrors are the Slack error codes documented as transient.
+var slackRetryableErrors = map[string]bool{
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+	"ratelimited":         true,
+}
+
+// slackOK fails responses with "ok": false, marking Slack's transient error codes retryable.
+func slackOK(resp *NormalizedResponse) error {
+	if ok, found := jsonBool(resp.Data, []string{"ok"}); !found || ok {
+		return nil
+	}
+	err := unsuccessful(resp)
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(resp.Data, &body) == nil && slackRetryableErrors[body.Error] {
+		return Retryable(err)
+	}
+	return err
+}
+
+// unsuccessful returns an error wrapping ErrUnsuccessfulResponse with the provider's message.
+func unsuccessful(resp *NormalizedResponse) error {
+	if msg := ExtractErrorMessage(resp.Data); msg != "" {
+		return fmt.Errorf("%w (status %d): %s", ErrUnsuccessfulResponse, resp.StatusCode, msg)
+	}
+	return fmt.Errorf("%w (status %d)", ErrUnsuccessfulResponse, resp.StatusCode)
+}
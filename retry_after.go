@@ -0,0 +1,35 @@
+// retry_after.go
+// --------------
+// This file contains the Retry-After parsing shared by the retry loop and adapters. Per RFC 9110 the
+// header carries either a number of seconds ("120") or an HTTP-date ("Wed, 21 Oct 2015 07:28:00 GMT");
+// both forms are converted into a wait duration relative to the current time.
+package resilientbridge
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRetryAfter converts a Retry-After header value into a wait duration relative to now.
+// Dates in the past yield 0. It reports false if the value is neither integer seconds nor an HTTP-date.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if until, err := http.ParseTime(value); err == nil {
+		if d := until.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,49 @@
+// retry_classifier.go
+// -------------------
+// This file defines RetryDecision and the ProviderConfig.RetryableErrorClassifier hook, which lets callers
+// decide what an attempt's outcome means without writing an adapter: providers signal "try again" in their
+// own ways (GCP's JSON "reason", AWS's exception names, GraphQL error types in a 200 body), and the
+// built-in handling only looks at status codes.
+//
+// The executor handles each attempt in this order:
+//   - Cancelled contexts and ErrResponseTooLarge end the request; the classifier is not consulted.
+//   - Errors from the adapter's ClassifyError (e.g. ErrUnauthorized) end the request.
+//   - Responses the adapter's IsRateLimitError accepts are retried as rate limits (Retry-After, reset
+//     times, RateLimitBehavior).
+//   - Everything else, including network errors, goes to the classifier. Retry, Fail, and Success replace
+//     the built-in handling for that attempt; a nil decision falls through to it (5xx retried per
+//     RetryPolicy, other 4xx failed, the rest returned as success).
+//
+// Retry decisions still count against MaxRetries and MaxRetryElapsed, but are not subject to RetryPolicy:
+// the classifier has already decided the request is safe to send again.
+package resilientbridge
+
+import "time"
+
+// RetryableErrorClassifier decides what happens after an attempt that returned resp (nil on network
+// errors) and err. It returns Retry, Fail, Success, or nil to leave the decision to the built-in handling.
+type RetryableErrorClassifier func(resp *NormalizedResponse, err error) RetryDecision
+
+// RetryDecision is the outcome chosen by a RetryableErrorClassifier: Retry, Fail, or Success.
+type RetryDecision interface {
+	retryDecision()
+}
+
+// Retry sends the request again after After, or after the usual exponential backoff when After is 0.
+type Retry struct {
+	After time.Duration
+}
+
+// Fail ends the request with Err, or, when Err is nil, with an *HTTPError for the response (the network
+// error for attempts without one).
+type Fail struct {
+	Err error
+}
+
+// Success returns the response to the caller as is, whatever its status. For network errors, which have
+// no response to return, it is treated as Fail.
+type Success struct{}
+
+func (Retry) retryDecision()   {}
+func (Fail) retryDecision()    {}
+func (Success) retryDecision() {}
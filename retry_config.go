@@ -0,0 +1,74 @@
+// retry_config.go
+// ---------------
+// This file defines RetryConfig, which bundles every retry knob of a provider in one place. Setting
+// ProviderConfig.Retry replaces the individual MaxRetries, BaseBackoff, MaxBackoff, MaxRetryAfter,
+// MaxRetryElapsed, RequestTimeout, and RetryPolicy fields, which remain for existing callers and are
+// only read when Retry is nil.
+//
+// The recipe it configures:
+//   - Each attempt gets RequestTimeout of its own; an attempt that hangs past it fails with
+//     ErrRequestTimeout and is retried like any other network error.
+//   - Failed attempts (network errors and 5xx allowed by Policy, and 429s) are retried with exponential
+//     backoff from BaseBackoff, with full jitter and each wait capped at MaxBackoff. A Retry-After sent by
+//     the provider replaces the backoff, capped at MaxRetryAfter.
+//   - Retrying stops after MaxRetries retries, or once the next wait would end more than MaxRetryElapsed
+//     after the first attempt (a *RetryBudgetError).
+//   - The caller's context bounds everything: cancelling it aborts the attempt in flight and any wait.
+//
+// DefaultRetryConfig returns a starting point suitable for most APIs. Zero fields mean the same as the
+// corresponding ProviderConfig field left at zero.
+package resilientbridge
+
+import "time"
+
+const (
+	// DefaultRetryMaxRetries is DefaultRetryConfig's MaxRetries.
+	DefaultRetryMaxRetries = 3
+
+	// DefaultRetryMaxElapsed is DefaultRetryConfig's MaxRetryElapsed.
+	DefaultRetryMaxElapsed = 2 * time.Minute
+
+	// DefaultRetryRequestTimeout is DefaultRetryConfig's RequestTimeout.
+	DefaultRetryRequestTimeout = 30 * time.Second
+)
+
+// RetryConfig holds a provider's retry settings; see ProviderConfig for the meaning of each field.
+type RetryConfig struct {
+	MaxRetries      int           // Max number of retries after the first attempt
+	BaseBackoff     time.Duration // Initial exponential backoff; 0 = DefaultBaseBackoff, NoBackoff = none
+	MaxBackoff      time.Duration // Ceiling on a single backoff wait; 0 = DefaultMaxBackoff
+	MaxRetryAfter   time.Duration // Cap on waits requested via Retry-After; 0 means no cap
+	MaxRetryElapsed time.Duration // Total time budget across retries; 0 means none
+	RequestTimeout  time.Duration // Deadline for each attempt; 0 means none
+	Policy          RetryPolicy   // Which 5xx and network failures may be retried; nil = DefaultRetryPolicy
+}
+
+// DefaultRetryConfig returns DefaultRetryMaxRetries retries with the default backoff (DefaultBaseBackoff
+// doubling up to DefaultMaxBackoff), a DefaultRetryMaxElapsed budget, a DefaultRetryRequestTimeout per
+// attempt, and DefaultRetryPolicy. The result is a new value the caller may adjust.
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxRetries:      DefaultRetryMaxRetries,
+		BaseBackoff:     DefaultBaseBackoff,
+		MaxBackoff:      DefaultMaxBackoff,
+		MaxRetryElapsed: DefaultRetryMaxElapsed,
+		RequestTimeout:  DefaultRetryRequestTimeout,
+		Policy:          DefaultRetryPolicy,
+	}
+}
+
+// retryConfig returns config.Retry, or the equivalent of the individual retry fields when it is nil.
+func (config *ProviderConfig) retryConfig() *RetryConfig {
+	if config.Retry != nil {
+		return config.Retry
+	}
+	return &RetryConfig{
+		MaxRetries:      config.MaxRetries,
+		BaseBackoff:     config.BaseBackoff,
+		MaxBackoff:      config.MaxBackoff,
+		MaxRetryAfter:   config.MaxRetryAfter,
+		MaxRetryElapsed: config.MaxRetryElapsed,
+		RequestTimeout:  config.RequestTimeout,
+		Policy:          config.RetryPolicy,
+	}
+}
@@ -0,0 +1,51 @@
+// retry_policy.go
+// ---------------
+// This file defines RetryPolicy, which decides whether a failed attempt may be sent again after a server
+// error (5xx) or a network error. Either failure can happen after the provider already acted on the
+// request, so blindly retrying a POST can create a resource twice.
+//
+// DefaultRetryPolicy retries methods that are idempotent by definition (GET, HEAD, OPTIONS, PUT, DELETE)
+// and retries POST and PATCH only when the request is marked NormalizedRequest.Idempotent or carries an
+// Idempotency-Key header. Rate limited responses (429) are always retried: the provider rejected them
+// without acting on them.
+package resilientbridge
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RetryPolicy reports whether req may be retried after an attempt that failed with a 5xx response
+// (resp set) or a network error (resp nil, err set).
+type RetryPolicy func(req *NormalizedRequest, resp *NormalizedResponse, err error) bool
+
+// DefaultRetryPolicy is used when ProviderConfig.RetryPolicy is nil.
+func DefaultRetryPolicy(req *NormalizedRequest, resp *NormalizedResponse, err error) bool {
+	if req == nil || req.Idempotent {
+		return true
+	}
+	switch strings.ToUpper(req.Method) {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	for k, v := range req.Headers {
+		if v != "" && (strings.EqualFold(k, "Idempotency-Key") || strings.EqualFold(k, "X-Idempotency-Key")) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryAllMethods retries every request regardless of its method.
+func RetryAllMethods(req *NormalizedRequest, resp *NormalizedResponse, err error) bool {
+	return true
+}
+
+// retryAllowed applies retry.Policy, or DefaultRetryPolicy when unset.
+func retryAllowed(retry *RetryConfig, req *NormalizedRequest, resp *NormalizedResponse, err error) bool {
+	policy := retry.Policy
+	if policy == nil {
+		policy = DefaultRetryPolicy
+	}
+	return policy(req, resp, err)
+}
@@ -0,0 +1,202 @@
+// round_trip.go
+// -------------
+// This file contains the shared round-trip helpers adapters use to send an *http.Request and convert
+// the result into the SDK's normalized types. Centralizing this keeps header normalization and body
+// handling consistent across providers, and lets per-provider settings from ProviderConfig apply
+// uniformly without every adapter re-implementing them.
+//
+// The SDK attaches the calling provider's ProviderConfig to the request context, so adapters only need
+// to build their request with http.NewRequestWithContext and hand it to DoRoundTrip or DoStreamRoundTrip.
+//
+// Settings applied here:
+// - MaxResponseBytes: bodies larger than the limit fail with ErrResponseTooLarge instead of being buffered.
+// - DebugDump (or RESILIENTBRIDGE_DEBUG=1): requests and responses are printed with secrets masked (see debug_dump.go).
+// - UserAgent: requests without a User-Agent header get ProviderConfig.UserAgent, or DefaultUserAgent.
+// - RequestSigner: signs each request just before it is sent (see signer.go).
+// - HTTPClient: replaces the adapter's client; otherwise clients without a Transport use DefaultTransport (see transport.go).
+package resilientbridge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type providerConfigKey struct{}
+
+// withProviderConfig returns a copy of ctx carrying the ProviderConfig of the provider being called.
+func withProviderConfig(ctx context.Context, config *ProviderConfig) context.Context {
+	return context.WithValue(ctx, providerConfigKey{}, config)
+}
+
+// ProviderConfigFromContext returns the ProviderConfig the SDK attached to ctx, or nil if there is none
+// (for example when an adapter is called directly rather than through the SDK).
+func ProviderConfigFromContext(ctx context.Context) *ProviderConfig {
+	config, _ := ctx.Value(providerConfigKey{}).(*ProviderConfig)
+	return config
+}
+
+// RateLimitingDisabled reports whether the ProviderConfig attached to ctx sets DisableRateLimiting, in which
+// case adapters send the request without checking their local windows.
+func RateLimitingDisabled(ctx context.Context) bool {
+	config := ProviderConfigFromContext(ctx)
+	return config != nil && config.DisableRateLimiting
+}
+
+// DoRoundTrip sends httpReq using client and returns the fully read response.
+// Header names are lower-cased and only the first value of each header is kept.
+// If the body exceeds MaxResponseBytes, the response is returned without Data together with
+// ErrResponseTooLarge, since the round trip itself did complete.
+func DoRoundTrip(client *http.Client, httpReq *http.Request) (*NormalizedResponse, error) {
+	setUserAgent(httpReq)
+	if err := signRequest(httpReq); err != nil {
+		return nil, err
+	}
+	dump := dumpEnabled(httpReq)
+	if dump {
+		dumpRequest(httpReq)
+	}
+	resp, err := clientFor(httpReq, client).Do(httpReq)
+	if err != nil {
+		if dump {
+			dumpResponse(httpReq, nil, nil, err)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	normalized := &NormalizedResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    normalizeHeaders(resp.Header),
+	}
+
+	data, err := readBody(resp.Body, maxResponseBytes(httpReq.Context()))
+	if dump {
+		dumpResponse(httpReq, resp, data, err)
+	}
+	if err != nil {
+		if errors.Is(err, ErrResponseTooLarge) {
+			return normalized, err
+		}
+		return nil, err
+	}
+	normalized.Data = data
+	return normalized, nil
+}
+
+// DoStreamRoundTrip sends httpReq using client and returns the response with its body unread.
+// When MaxResponseBytes is set, reading past the limit fails with ErrResponseTooLarge.
+// The caller is responsible for closing the returned Body.
+func DoStreamRoundTrip(client *http.Client, httpReq *http.Request) (*StreamResponse, error) {
+	setUserAgent(httpReq)
+	if err := signRequest(httpReq); err != nil {
+		return nil, err
+	}
+	dump := dumpEnabled(httpReq)
+	if dump {
+		dumpRequest(httpReq)
+	}
+	resp, err := clientFor(httpReq, client).Do(httpReq)
+	if dump {
+		dumpResponse(httpReq, resp, nil, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	body := resp.Body
+	if limit := maxResponseBytes(httpReq.Context()); limit > 0 {
+		body = &limitedBody{rc: resp.Body, remaining: limit}
+	}
+
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    normalizeHeaders(resp.Header),
+		Body:       body,
+	}, nil
+}
+
+// normalizeHeaders lower-cases header names and keeps the first value of each, except for Link, whose
+// values are joined with commas as the list syntax allows, so links split over several headers are kept.
+func normalizeHeaders(h http.Header) map[string]string {
+	headers := make(map[string]string)
+	for k, vals := range h {
+		if len(vals) == 0 {
+			continue
+		}
+		name := strings.ToLower(k)
+		if name == "link" {
+			headers[name] = strings.Join(vals, ", ")
+			continue
+		}
+		headers[name] = vals[0]
+	}
+	return headers
+}
+
+// setUserAgent sets the User-Agent of httpReq, unless it already has one, to the ProviderConfig.UserAgent
+// carried by its context or DefaultUserAgent.
+func setUserAgent(httpReq *http.Request) {
+	if httpReq.Header.Get("User-Agent") != "" {
+		return
+	}
+	ua := DefaultUserAgent
+	if config := ProviderConfigFromContext(httpReq.Context()); config != nil && config.UserAgent != "" {
+		ua = config.UserAgent
+	}
+	httpReq.Header.Set("User-Agent", ua)
+}
+
+// maxResponseBytes returns the MaxResponseBytes configured for the provider carried by ctx (0 = unlimited).
+func maxResponseBytes(ctx context.Context) int64 {
+	if config := ProviderConfigFromContext(ctx); config != nil {
+		return config.MaxResponseBytes
+	}
+	return 0
+}
+
+// readBody reads r fully, failing with ErrResponseTooLarge if more than limit bytes are available.
+// A limit <= 0 means unlimited.
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrResponseTooLarge
+	}
+	return data, nil
+}
+
+// limitedBody wraps a streamed body and fails with ErrResponseTooLarge once more than
+// remaining bytes have been read.
+type limitedBody struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.rc.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = -1
+		return n, ErrResponseTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.rc.Close()
+}
@@ -6,15 +6,28 @@
 // Key functionalities include:
 // - Initializing the SDK with NewResilientBridge()
 // - Registering providers with RegisterProvider()
-// - Making requests via sdk.Request()
+// - Deriving differently configured SDKs that share providers and quota via sdk.Clone()
+// - Making requests via sdk.Request() or, with cancellation support, sdk.RequestWithContext()
+// - Rejecting malformed requests up front with a *RequestError (see request_validation.go)
+// - Decoding JSON responses in one step via sdk.RequestJSON()
+// - Streaming large response bodies via sdk.RequestStream()
+// - Asking which optional features a provider's adapter supports via sdk.Capabilities() (see capabilities.go)
+// - Following paginated endpoints via sdk.Paginate() (see paginator.go)
+// - Writing paginated listings as newline-delimited JSON via sdk.StreamNDJSON() (see ndjson.go)
 // - Managing and retrieving provider configurations and rate limit info
+// - Attaching per-SDK state for packages built on it (such as the github package's caches) via sdk.Value()
 //
 // The ResilientBridge relies on a RateLimiter and a RequestExecutor to handle
 // rate limiting and retries, ensuring consistent behavior across all providers.
 package resilientbridge
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"sync"
 )
 
@@ -24,6 +37,10 @@ type ResilientBridge struct {
 	configs     map[string]*ProviderConfig
 	rateLimiter *RateLimiter
 	executor    *RequestExecutor
+	clock       Clock
+	slots       *slotRegistry   // per-provider MaxConcurrency slots, shared with clones
+	deprecated  map[string]bool // "provider METHOD /path" keys already reported to OnDeprecation
+	values      map[any]any     // state attached with Value; not shared with clones
 
 	Debug bool // If true, print debug info
 }
@@ -33,12 +50,97 @@ func NewResilientBridge() *ResilientBridge {
 		providers:   make(map[string]ProviderAdapter),
 		configs:     make(map[string]*ProviderConfig),
 		rateLimiter: NewRateLimiter(),
+		slots:       newSlotRegistry(),
+		deprecated:  make(map[string]bool),
+		clock:       realClock{},
 		Debug:       false,
 	}
 	sdk.executor = NewRequestExecutor(sdk)
 	return sdk
 }
 
+// Clone returns a new ResilientBridge with the same providers, for a subsystem that needs different
+// settings (MaxRetries, callbacks, debug output) without registering its providers again. The clone gets
+// its own copy of every ProviderConfig, which SetProviderConfig can replace without affecting the
+// original, and vice versa; providers registered on one afterwards are not seen by the other. The copies
+// are shallow: maps and slices such as DefaultHeaders are shared, so replace them rather than modify them.
+//
+// Everything that keeps track of quota stays shared: the adapters themselves (and their local windows),
+// the rate limit info reported by providers, token buckets, EndpointLimits windows, and MaxConcurrency
+// slots. Requests made through a clone therefore count against the same limits as the original's, so
+// cloning never multiplies the budget. Values attached with Value are not carried over: a clone starts
+// without any.
+func (sdk *ResilientBridge) Clone() *ResilientBridge {
+	sdk.mu.Lock()
+	defer sdk.mu.Unlock()
+
+	clone := &ResilientBridge{
+		providers:   make(map[string]ProviderAdapter, len(sdk.providers)),
+		configs:     make(map[string]*ProviderConfig, len(sdk.configs)),
+		rateLimiter: sdk.rateLimiter,
+		slots:       sdk.slots,
+		deprecated:  make(map[string]bool),
+		clock:       sdk.clock,
+		Debug:       sdk.Debug,
+	}
+	for name, adapter := range sdk.providers {
+		clone.providers[name] = adapter
+	}
+	for name, config := range sdk.configs {
+		if config != nil {
+			copied := *config
+			config = &copied
+		}
+		clone.configs[name] = config
+	}
+	clone.executor = NewRequestExecutor(clone)
+	return clone
+}
+
+// Value returns the value attached to sdk under key, first attaching newValue() if there is none. It lets
+// packages built on the SDK keep per-SDK state, such as caches, that is released with the SDK rather than
+// held in package-level maps. Use a key of an unexported type, as with context.WithValue. newValue is
+// called with the SDK locked, so it must not use the SDK.
+func (sdk *ResilientBridge) Value(key any, newValue func() any) any {
+	sdk.mu.Lock()
+	defer sdk.mu.Unlock()
+	if v, ok := sdk.values[key]; ok {
+		return v
+	}
+	if sdk.values == nil {
+		sdk.values = make(map[any]any)
+	}
+	v := newValue()
+	sdk.values[key] = v
+	return v
+}
+
+// GetProviderConfig returns a copy of the ProviderConfig of a registered provider, or nil. Modify it and
+// pass it to SetProviderConfig to change the provider's settings.
+func (sdk *ResilientBridge) GetProviderConfig(providerName string) *ProviderConfig {
+	sdk.mu.Lock()
+	defer sdk.mu.Unlock()
+	config, ok := sdk.configs[providerName]
+	if !ok || config == nil {
+		return nil
+	}
+	copied := *config
+	return &copied
+}
+
+// SetProviderConfig replaces the ProviderConfig of a registered provider, for example on a clone. Unlike
+// RegisterProvider it leaves the adapter untouched: the adapter's local windows, which are shared with
+// clones, keep the limits they were registered with.
+func (sdk *ResilientBridge) SetProviderConfig(providerName string, config *ProviderConfig) error {
+	sdk.mu.Lock()
+	defer sdk.mu.Unlock()
+	if _, ok := sdk.providers[providerName]; !ok {
+		return fmt.Errorf("provider %q not registered", providerName)
+	}
+	sdk.configs[providerName] = config
+	return nil
+}
+
 // SetDebug enables or disables debug logging for the SDK.
 func (sdk *ResilientBridge) SetDebug(enabled bool) {
 	sdk.mu.Lock()
@@ -71,18 +173,159 @@ func (sdk *ResilientBridge) RegisterProvider(name string, adapter ProviderAdapte
 // Request sends a NormalizedRequest to the specified provider and returns a NormalizedResponse.
 // It uses the RequestExecutor to handle retries, rate limits, and backoff.
 func (sdk *ResilientBridge) Request(providerName string, req *NormalizedRequest) (*NormalizedResponse, error) {
+	return sdk.RequestWithContext(context.Background(), providerName, req)
+}
+
+// RequestWithContext is like Request but honors ctx: cancelling it aborts in-flight HTTP calls as well
+// as any backoff or Retry-After wait, returning ctx.Err().
+func (sdk *ResilientBridge) RequestWithContext(ctx context.Context, providerName string, req *NormalizedRequest) (*NormalizedResponse, error) {
+	sdk.mu.Lock()
+	adapter, ok := sdk.providers[providerName]
+	sdk.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("provider %q not registered", providerName)
+	}
+	if err := validateRequest(providerName, adapter, req); err != nil {
+		return nil, err
+	}
+
+	config := sdk.getProviderConfig(providerName)
+	ctx = withFollowLocation(withProviderConfig(ctx, config), req)
+	req = withDefaultHeaders(req, config.DefaultHeaders)
+	callType := adapter.IdentifyRequestType(req)
+	sdk.debugf("Requesting provider %s (callType=%s) at endpoint %s%s\n", providerName, callType, req.Endpoint, tagsSuffix(req.Tags))
+	return sdk.executor.ExecuteWithRetry(ctx, providerName, callType, req, func() (*NormalizedResponse, error) {
+		attempt := newAttemptContext(ctx, config.retryConfig().RequestTimeout)
+		defer attempt.done()
+		resp, err := executeAdapterRequest(attempt, adapter, req)
+		return resp, attempt.timeoutError(err)
+	}, adapter)
+}
+
+// RequestJSON sends req like Request and unmarshals the JSON response body into out. Error statuses
+// (>= 400) come back as an *HTTPError carrying the provider's error message, so callers need no status
+// check of their own. An empty body (e.g. 204) leaves out untouched; a nil out skips decoding. The
+// response is returned alongside any error, for access to headers. With ProviderConfig.RetryOnDecodeError,
+// empty or truncated 2xx bodies are retried instead (see decode_retry.go).
+func (sdk *ResilientBridge) RequestJSON(providerName string, req *NormalizedRequest, out interface{}) (*NormalizedResponse, error) {
+	return sdk.RequestJSONWithContext(context.Background(), providerName, req, out)
+}
+
+// RequestJSONWithContext is RequestJSON with a caller-supplied context, as for RequestWithContext.
+func (sdk *ResilientBridge) RequestJSONWithContext(ctx context.Context, providerName string, req *NormalizedRequest, out interface{}) (*NormalizedResponse, error) {
+	if out != nil {
+		ctx = withDecodeRetry(ctx, sdk.getProviderConfig(providerName))
+	}
+	resp, err := sdk.RequestWithContext(ctx, providerName, req)
+	if err != nil {
+		return resp, err
+	}
+	if out == nil || len(bytes.TrimSpace(resp.Data)) == 0 {
+		return resp, nil
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return resp, fmt.Errorf("error decoding %s response for %s: %w", providerName, req.Endpoint, err)
+	}
+	return resp, nil
+}
+
+// RequestStream sends a NormalizedRequest to the specified provider and returns the response with its
+// body unread, for downloads too large to buffer (workflow logs, artifact archives, etc.).
+// Rate limiting and retries are applied before the body is handed back; only successful responses
+// are streamed, error responses are buffered so the retry logic can inspect them.
+// The caller is responsible for closing the returned Body.
+func (sdk *ResilientBridge) RequestStream(ctx context.Context, providerName string, req *NormalizedRequest) (*StreamResponse, error) {
 	sdk.mu.Lock()
 	adapter, ok := sdk.providers[providerName]
 	sdk.mu.Unlock()
 	if !ok {
 		return nil, fmt.Errorf("provider %q not registered", providerName)
 	}
+	streamer, ok := adapter.(StreamingAdapter)
+	if !ok || !CapabilitiesOf(adapter).SupportsStreaming {
+		return nil, fmt.Errorf("provider %q does not support streaming: %w", providerName, ErrUnsupportedCapability)
+	}
+	if err := validateRequest(providerName, adapter, req); err != nil {
+		return nil, err
+	}
 
+	config := sdk.getProviderConfig(providerName)
+	ctx = withStreamedBody(withFollowLocation(withProviderConfig(ctx, config), req))
+	req = withDefaultHeaders(req, config.DefaultHeaders)
 	callType := adapter.IdentifyRequestType(req)
-	sdk.debugf("Requesting provider %s (callType=%s) at endpoint %s\n", providerName, callType, req.Endpoint)
-	return sdk.executor.ExecuteWithRetry(providerName, callType, func() (*NormalizedResponse, error) {
-		return adapter.ExecuteRequest(req)
+	sdk.debugf("Streaming from provider %s (callType=%s) at endpoint %s%s\n", providerName, callType, req.Endpoint, tagsSuffix(req.Tags))
+
+	// stream holds the body of the latest successful attempt. The executor may still reject that attempt
+	// (a RetryableErrorClassifier deciding Retry or Fail), so it is released before every retry and
+	// whenever the executor returns an error.
+	var stream *StreamResponse
+	release := func() {
+		if stream != nil {
+			stream.Body.Close()
+			stream = nil
+		}
+	}
+	resp, err := sdk.executor.ExecuteWithRetry(ctx, providerName, callType, req, func() (*NormalizedResponse, error) {
+		release()
+		attempt := newAttemptContext(ctx, config.retryConfig().RequestTimeout)
+		s, err := streamer.ExecuteStreamRequest(attempt, req)
+		attempt.disarm()
+		if err != nil {
+			attempt.done()
+			return nil, attempt.timeoutError(err)
+		}
+		if s.StatusCode < 400 {
+			s.Body = &attemptBody{ReadCloser: s.Body, attempt: attempt}
+			stream = s
+			return &NormalizedResponse{StatusCode: s.StatusCode, Headers: s.Headers}, nil
+		}
+		defer attempt.done()
+		defer s.Body.Close()
+		data, _ := io.ReadAll(s.Body)
+		return &NormalizedResponse{StatusCode: s.StatusCode, Headers: s.Headers, Data: data}, nil
 	}, adapter)
+
+	if err != nil {
+		release()
+	}
+	if stream != nil {
+		return stream, nil
+	}
+	if resp == nil {
+		return nil, err
+	}
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers,
+		Body:       io.NopCloser(bytes.NewReader(resp.Data)),
+	}, err
+}
+
+// withDefaultHeaders returns req with defaults added for every header it doesn't set itself (header
+// names compare case-insensitively). req is copied rather than modified, so callers can reuse it.
+func withDefaultHeaders(req *NormalizedRequest, defaults map[string]string) *NormalizedRequest {
+	if req == nil || len(defaults) == 0 {
+		return req
+	}
+	headers := make(map[string]string, len(req.Headers)+len(defaults))
+	for k, v := range defaults {
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	for k, v := range req.Headers {
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	merged := *req
+	merged.Headers = headers
+	return &merged
+}
+
+// executeAdapterRequest calls ExecuteRequestWithContext when the adapter implements ContextAdapter,
+// falling back to ExecuteRequest otherwise.
+func executeAdapterRequest(ctx context.Context, adapter ProviderAdapter, req *NormalizedRequest) (*NormalizedResponse, error) {
+	if ca, ok := adapter.(ContextAdapter); ok {
+		return ca.ExecuteRequestWithContext(ctx, req)
+	}
+	return adapter.ExecuteRequest(req)
 }
 
 // getProviderConfig retrieves the ProviderConfig for a given provider, or a default if not found.
@@ -108,6 +351,14 @@ func (sdk *ResilientBridge) GetRateLimitInfo(providerName string) *NormalizedRat
 	return sdk.rateLimiter.GetRateLimitInfo(providerName)
 }
 
+// tagsSuffix formats request tags for debug output, or returns "" when there are none.
+func tagsSuffix(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" tags=%v", tags)
+}
+
 // debugf prints debug messages if Debug mode is enabled.
 func (sdk *ResilientBridge) debugf(format string, args ...interface{}) {
 	if sdk.Debug {
@@ -0,0 +1,108 @@
+// capabilities.go
+//
+// Checks sdk.Capabilities and the fail-fast checks built on it. The GitHub adapter must report streaming,
+// GraphQL, and absolute URLs; an adapter without CapabilityReporter must get its interface-backed
+// capabilities inferred; a wrapper reporting SupportsStreaming=false must be refused by RequestStream even
+// though it embeds a streaming adapter; and an absolute endpoint sent to Jira (which only takes /rest/
+// paths) must fail with ErrUnsupportedCapability before anything reaches the network.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// stub answers every request with an empty 200 and counts them.
+type stub struct {
+	calls int
+}
+
+func (s *stub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.calls++
+	rec := httptest.NewRecorder()
+	rec.WriteString(`{}`)
+	return rec.Result(), nil
+}
+
+// plain is a minimal third-party adapter with a health probe and no CapabilityReporter.
+type plain struct{}
+
+func (plain) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return &resilientbridge.NormalizedResponse{StatusCode: 200}, nil
+}
+
+func (plain) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (plain) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool { return false }
+
+func (plain) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {}
+
+func (plain) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string { return "rest" }
+
+func (plain) HealthProbe() *resilientbridge.NormalizedRequest {
+	return &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/ping"}
+}
+
+// noStream wraps the GitHub adapter but must not be used for streaming.
+type noStream struct {
+	*adapters.GitHubAdapter
+}
+
+func (n noStream) Capabilities() resilientbridge.AdapterCapabilities {
+	caps := n.GitHubAdapter.Capabilities()
+	caps.SupportsStreaming = false
+	return caps
+}
+
+func main() {
+	s := &stub{}
+	sdk := resilientbridge.NewResilientBridge()
+	config := &resilientbridge.ProviderConfig{HTTPClient: &http.Client{Transport: s}}
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), config)
+	sdk.RegisterProvider("plain", plain{}, config)
+	sdk.RegisterProvider("nostream", noStream{adapters.NewGitHubAdapter("token")}, config)
+	sdk.RegisterProvider("jira", adapters.NewJiraAdapter("https://example.atlassian.net", "me@example.com", "token"), config)
+
+	gh, err := sdk.Capabilities("github")
+	if err != nil || !gh.SupportsStreaming || !gh.SupportsGraphQL || !gh.SupportsAbsoluteURLs || gh.SupportsConditionalRequests {
+		log.Fatalf("FAIL: github capabilities %+v (%v)", gh, err)
+	}
+	if caps, _ := sdk.Capabilities("plain"); caps != (resilientbridge.AdapterCapabilities{SupportsHealthProbe: true}) {
+		log.Fatalf("FAIL: inferred capabilities %+v, want only SupportsHealthProbe", caps)
+	}
+	if _, err := sdk.Capabilities("missing"); err == nil {
+		log.Fatalf("FAIL: capabilities of an unregistered provider")
+	}
+	log.Printf("ok: github %+v", gh)
+
+	_, err = sdk.RequestStream(context.Background(), "nostream", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/a/b/tarball"})
+	if !errors.Is(err, resilientbridge.ErrUnsupportedCapability) {
+		log.Fatalf("FAIL: streaming from a wrapper reporting no streaming: %v", err)
+	}
+	stream, err := sdk.RequestStream(context.Background(), "github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/a/b/tarball"})
+	if err != nil {
+		log.Fatalf("FAIL: streaming from github: %v", err)
+	}
+	stream.Body.Close()
+
+	calls := s.calls
+	_, err = sdk.Request("jira", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "https://example.atlassian.net/rest/api/3/myself"})
+	if !errors.Is(err, resilientbridge.ErrUnsupportedCapability) || s.calls != calls {
+		log.Fatalf("FAIL: absolute endpoint to jira: %v after %d requests, want ErrUnsupportedCapability and none", err, s.calls-calls)
+	}
+	if _, err := sdk.Request("jira", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/myself"}); err != nil {
+		log.Fatalf("FAIL: relative endpoint to jira: %v", err)
+	}
+	log.Printf("ok: refused: %v", err)
+
+	log.Println("PASS: adapters advertise capabilities and the SDK fails fast without them")
+}
@@ -0,0 +1,105 @@
+// credential_hosts.go
+//
+// Checks which requests adapters attach their credentials to. Requests are sent through the real adapters
+// to a recording transport. Requests to the default base URL, to other hosts of the provider given as an
+// absolute endpoint (api.github.com, a regional twilio.com host), and to any https host the caller set as
+// BaseURLOverride (a GitHub Enterprise server, a regional endpoint) must carry the adapter's credential
+// header. Absolute endpoints on any other host and plain http URLs, even to the provider's own hosts,
+// must be sent without it. A credential the caller sets on the request itself is always sent.
+// resilientbridge.TrustedHost is checked directly as well.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// recorder answers every request with an empty JSON object and remembers the credential header it saw.
+type recorder struct {
+	header string
+	seen   []string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.seen = append(r.seen, req.Header.Get(r.header))
+	rec := httptest.NewRecorder()
+	rec.WriteString(`{}`)
+	return rec.Result(), nil
+}
+
+type send struct {
+	req        resilientbridge.NormalizedRequest
+	credential bool // Whether the adapter's credential must be sent
+}
+
+func check(name string, adapter resilientbridge.ProviderAdapter, header string, sends []send) {
+	transport := &recorder{header: header}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(name, adapter, &resilientbridge.ProviderConfig{HTTPClient: &http.Client{Transport: transport}})
+	for i, s := range sends {
+		req := s.req
+		if _, err := sdk.Request(name, &req); err != nil {
+			log.Fatalf("FAIL: %s request %d: %v", name, i+1, err)
+		}
+		if got := transport.seen[i] != ""; got != s.credential {
+			log.Fatalf("FAIL: %s request %d (%s%s) sent %s = %q, want credential %v",
+				name, i+1, req.BaseURLOverride, req.Endpoint, header, transport.seen[i], s.credential)
+		}
+	}
+	log.Printf("ok: %s", name)
+}
+
+func main() {
+	if !resilientbridge.TrustedHost("https://uploads.github.com/x", "github.com") ||
+		!resilientbridge.TrustedHost("https://127.0.0.1:8080/api", "https://127.0.0.1:9090") ||
+		!resilientbridge.TrustedHost("https://api.github.com/x", "", "github.com") ||
+		resilientbridge.TrustedHost("http://api.github.com/x", "github.com") ||
+		resilientbridge.TrustedHost("https://github.com.example/x", "github.com") ||
+		resilientbridge.TrustedHost("https://evilgithub.com/x", "github.com") ||
+		resilientbridge.TrustedHost("/relative", "github.com") {
+		log.Fatalf("FAIL: TrustedHost host matching")
+	}
+	log.Println("ok: TrustedHost matches https hosts and subdomains only")
+
+	get := func(endpoint, override string) resilientbridge.NormalizedRequest {
+		return resilientbridge.NormalizedRequest{Method: "GET", Endpoint: endpoint, BaseURLOverride: override}
+	}
+
+	check("github", adapters.NewGitHubAdapter("token"), "Authorization", []send{
+		{get("/user", ""), true},
+		{get("/repos/acme/app/releases/1/assets", "https://uploads.github.com"), true},
+		{get("https://api.github.com/user", ""), true},
+		{get("/repos/a/b", "https://ghe.example.com/api/v3"), true},
+		{get("https://ghe.example.com/api/v3/repos/a/b", ""), false},
+		{get("https://attacker.example/collect", "https://ghe.example.com/api/v3"), false},
+		{get("/user", "http://ghe.example.com/api/v3"), false},
+	})
+	check("doppler", &adapters.DopplerAdapter{APIToken: "token"}, "Authorization", []send{
+		{get("/v3/projects", ""), true},
+		{get("/v3/projects", "https://eu.doppler.example"), true},
+		{get("https://eu.doppler.example/v3/projects", ""), false},
+		{resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "https://eu.doppler.example/v3/projects",
+			Headers: map[string]string{"Authorization": "Bearer caller-token"}}, true},
+	})
+	check("datadog", adapters.NewDatadogAdapter("api-key", "app-key", "datadoghq.eu"), "DD-API-KEY", []send{
+		{get("/api/v1/validate", ""), true},
+		{get("https://api.datadoghq.com/api/v1/validate", ""), false},
+	})
+	check("shopify", adapters.NewShopifyAdapter("acme", "token"), "X-Shopify-Access-Token", []send{
+		{get("/shop.json", ""), true},
+		{get("/shop.json", "http://acme.myshopify.com"), false},
+	})
+	check("twilio", adapters.NewTwilioAdapter("AC123", "secret"), "Authorization", []send{
+		{get("/2010-04-01/Accounts.json", ""), true},
+		{get("/2010-04-01/Accounts.json", "https://api.dublin.ie1.twilio.com"), true},
+		{get("https://api.dublin.ie1.twilio.com/2010-04-01/Accounts.json", ""), true},
+		{get("https://twilio.attacker.example/2010-04-01/Accounts.json", ""), false},
+	})
+
+	log.Println("PASS: adapters send credentials only to their provider's hosts and the caller's overrides")
+}
@@ -0,0 +1,103 @@
+// do_http.go
+//
+// Checks sdk.DoHTTP with the GitHub adapter in front of a stub transport. A GET built with
+// http.NewRequest must be retried past a 502 and come back as an *http.Response with the stub's body and
+// headers; a POST with an Idempotency-Key must be retried with its body sent again in full; a 404 must be
+// returned as a response rather than an error, as http.Client.Do does; and a URL without a host must be
+// sent to the adapter's base URL.
+
+package main
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// stub fails the first attempt of every path with a 502, answers /missing with a 404, and records the
+// requests it receives.
+type stub struct {
+	seen   map[string]int
+	bodies []string
+	urls   []string
+}
+
+func (s *stub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.seen[req.URL.Path]++
+	s.urls = append(s.urls, req.URL.String())
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		s.bodies = append(s.bodies, string(body))
+	}
+	rec := httptest.NewRecorder()
+	switch {
+	case req.URL.Path == "/missing":
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	case s.seen[req.URL.Path] == 1:
+		rec.WriteHeader(http.StatusBadGateway)
+	default:
+		rec.Header().Set("X-Request-Id", "abc123")
+		rec.WriteString(`{"full_name":"apache/airflow"}`)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	s := &stub{seen: map[string]int{}}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		MaxRetries:  2,
+		BaseBackoff: resilientbridge.NoBackoff,
+		HTTPClient:  &http.Client{Transport: s},
+	})
+
+	req, _ := http.NewRequest("GET", "https://api.github.com/repos/apache/airflow", nil)
+	resp, err := sdk.DoHTTP("github", req)
+	if err != nil {
+		log.Fatalf("FAIL: GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != 200 || string(body) != `{"full_name":"apache/airflow"}` || resp.Header.Get("X-Request-Id") != "abc123" {
+		log.Fatalf("FAIL: GET returned %s, %q, headers %v", resp.Status, body, resp.Header)
+	}
+	if s.seen["/repos/apache/airflow"] != 2 || resp.Request != req {
+		log.Fatalf("FAIL: GET took %d attempts, want 2", s.seen["/repos/apache/airflow"])
+	}
+	log.Println("ok: GET retried past a 502")
+
+	req, _ = http.NewRequest("POST", "https://api.github.com/repos/apache/airflow/issues", strings.NewReader(`{"title":"flaky"}`))
+	req.Header.Set("Idempotency-Key", "k1")
+	if resp, err = sdk.DoHTTP("github", req); err != nil || resp.StatusCode != 200 {
+		log.Fatalf("FAIL: POST: %v", err)
+	}
+	if len(s.bodies) < 2 || s.bodies[len(s.bodies)-2] != `{"title":"flaky"}` || s.bodies[len(s.bodies)-1] != `{"title":"flaky"}` {
+		log.Fatalf("FAIL: POST bodies sent %q, want the body on both attempts", s.bodies)
+	}
+	log.Println("ok: POST body buffered for the retry")
+
+	req, _ = http.NewRequest("GET", "https://api.github.com/missing", nil)
+	if resp, err = sdk.DoHTTP("github", req); err != nil || resp.StatusCode != http.StatusNotFound {
+		log.Fatalf("FAIL: 404 came back as %v, %v, want a response", resp, err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "Not Found") {
+		log.Fatalf("FAIL: 404 body %q", body)
+	}
+
+	req, _ = http.NewRequest("GET", "/repos/apache/kafka", nil)
+	if resp, err = sdk.DoHTTP("github", req); err != nil || resp.StatusCode != 200 {
+		log.Fatalf("FAIL: relative URL: %v", err)
+	}
+	if last := s.urls[len(s.urls)-1]; last != "https://api.github.com/repos/apache/kafka" {
+		log.Fatalf("FAIL: relative URL sent to %s", last)
+	}
+
+	log.Println("PASS: DoHTTP runs net/http requests through the SDK")
+}
@@ -0,0 +1,113 @@
+// kubernetes.go
+//
+// Checks the Kubernetes adapter against an httptest TLS server standing in for an API server. The
+// adapter must trust the server through KubernetesConfig.CAData alone and send the bearer token; a pod
+// list split over three pages must be followed with KubernetesNextPage, asking for 500-item chunks; the
+// client-side bucket must refuse the request past its burst with RateLimitFailFast, and otherwise delay
+// it by about one token interval rather than a whole second; a 401 must be ErrUnauthorized.
+
+package main
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// apiServer serves a three-page pod list and /version, and rejects other tokens with 401.
+func apiServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sa-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"kind":"Status","status":"Failure","message":"Unauthorized","reason":"Unauthorized","code":401}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/version":
+			fmt.Fprint(w, `{"major":"1","minor":"30"}`)
+		case "/api/v1/pods":
+			if r.URL.Query().Get("limit") != "500" {
+				http.Error(w, "missing limit", http.StatusBadRequest)
+				return
+			}
+			next := map[string]string{"": "c1", "c1": "c2", "c2": ""}[r.URL.Query().Get("continue")]
+			fmt.Fprintf(w, `{"kind":"PodList","metadata":{"continue":%q},"items":[{"metadata":{"name":"pod-%s"}}]}`, next, next)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func newSDK(server *httptest.Server, config adapters.KubernetesConfig, behavior resilientbridge.RateLimitBehavior) *resilientbridge.ResilientBridge {
+	config.Host = server.URL
+	config.CAData = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	adapter, err := adapters.NewKubernetesAdapter(config)
+	if err != nil {
+		log.Fatalf("FAIL: NewKubernetesAdapter: %v", err)
+	}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("k8s", adapter, &resilientbridge.ProviderConfig{MaxRetries: 3, RateLimitBehavior: behavior})
+	return sdk
+}
+
+func get(sdk *resilientbridge.ResilientBridge, endpoint string) error {
+	_, err := sdk.Request("k8s", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: endpoint})
+	return err
+}
+
+func main() {
+	server := apiServer()
+	defer server.Close()
+
+	sdk := newSDK(server, adapters.KubernetesConfig{BearerToken: "sa-token"}, resilientbridge.RateLimitBlock)
+	var pages []string
+	err := sdk.Paginate(context.Background(), "k8s", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/api/v1/pods"},
+		&resilientbridge.PaginateOptions{NextPage: adapters.KubernetesNextPage, MaxPageSize: true},
+		func(page *resilientbridge.NormalizedResponse) error {
+			pages = append(pages, string(page.Data))
+			return nil
+		})
+	if err != nil || len(pages) != 3 || !strings.Contains(pages[2], `"continue":""`) {
+		log.Fatalf("FAIL: listed %d pages (%v), want 3", len(pages), err)
+	}
+	log.Println("ok: TLS with CAData, bearer token, and continue-token pagination")
+
+	sdk = newSDK(server, adapters.KubernetesConfig{BearerToken: "sa-token", QPS: 1, Burst: 2}, resilientbridge.RateLimitFailFast)
+	for i := 0; i < 2; i++ {
+		if err := get(sdk, "/version"); err != nil {
+			log.Fatalf("FAIL: request %d within the burst: %v", i+1, err)
+		}
+	}
+	var rlErr *resilientbridge.RateLimitError
+	if err := get(sdk, "/version"); !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: request past the burst returned %v, want a *RateLimitError", err)
+	}
+
+	sdk = newSDK(server, adapters.KubernetesConfig{BearerToken: "sa-token", QPS: 20, Burst: 1}, resilientbridge.RateLimitBlock)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := get(sdk, "/version"); err != nil {
+			log.Fatalf("FAIL: paced request %d: %v", i+1, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond || elapsed > 900*time.Millisecond {
+		log.Fatalf("FAIL: three requests at 20 QPS with burst 1 took %v, want about 100ms", elapsed)
+	}
+	log.Println("ok: client-side QPS and burst")
+
+	sdk = newSDK(server, adapters.KubernetesConfig{BearerToken: "expired"}, resilientbridge.RateLimitBlock)
+	if err := get(sdk, "/version"); !errors.Is(err, resilientbridge.ErrUnauthorized) {
+		log.Fatalf("FAIL: rejected token returned %v, want ErrUnauthorized", err)
+	}
+
+	log.Println("PASS: the Kubernetes adapter paces, paginates, and verifies the API server")
+}
@@ -0,0 +1,117 @@
+// read_methods.go
+//
+// Checks that HEAD and OPTIONS requests are classified as reads by every adapter that classifies by
+// method. Adapters with an exported classifier (Azure, Fastly, HuggingFace, Discord) must give HEAD,
+// OPTIONS, lowercase, and empty methods the request type of a GET. For the adapters that classify
+// internally (Linode, Render, Fly.io, GitHub's points), HEAD requests are sent through the SDK to a stub
+// and must be paced by the read limit: the 21st HEAD /images within a minute is refused by Linode's
+// list_images window, 31 HEAD /v1/services pass Render's "get" window (not the 30/min write one), two
+// HEADs of one Fly.io machine pass its get_machine rate, and GitHub counts a HEAD as 1 point.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// stub answers every request with an empty 200 and counts them.
+type stub struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *stub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+	rec := httptest.NewRecorder()
+	rec.WriteString(`{}`)
+	return rec.Result(), nil
+}
+
+type classifier interface {
+	IdentifyRequestType(req *resilientbridge.NormalizedRequest) string
+}
+
+func main() {
+	classifiers := map[string]struct {
+		adapter  classifier
+		endpoint string
+	}{
+		"azure":       {adapters.NewAzureAdapter("token"), "/subscriptions/1/resourceGroups"},
+		"fastly":      {adapters.NewFastlyAdapter("token"), "/service"},
+		"huggingface": {adapters.NewHuggingFaceAdapter("token"), "/api/models"},
+		"discord":     {adapters.NewDiscordAdapter("token"), "/channels/123456789012345678/messages"},
+	}
+	for name, c := range classifiers {
+		want := c.adapter.IdentifyRequestType(&resilientbridge.NormalizedRequest{Method: "GET", Endpoint: c.endpoint})
+		methods := []string{"HEAD", "OPTIONS", "head", ""}
+		if name == "discord" {
+			// Discord buckets routes per method, so only the spelling of GET must not matter
+			methods = []string{"get", ""}
+		}
+		for _, method := range methods {
+			got := c.adapter.IdentifyRequestType(&resilientbridge.NormalizedRequest{Method: method, Endpoint: c.endpoint})
+			if got != want {
+				log.Fatalf("FAIL: %s classifies %q as %q, GET as %q", name, method, got, want)
+			}
+		}
+	}
+	log.Println("ok: exported classifiers treat HEAD and OPTIONS like GET")
+
+	if n, err := sendHEADs(adapters.NewLinodeAdapter("token"), "/images", 21); n != 20 || !isRateLimit(err) {
+		log.Fatalf("FAIL: linode sent %d of 21 HEAD /images (%v), want 20 and a rate limit error", n, err)
+	}
+	if n, err := sendHEADs(adapters.NewRenderAdapter("token"), "/v1/services", 31); n != 31 || err != nil {
+		log.Fatalf("FAIL: render sent %d of 31 HEAD /v1/services (%v), want all of them", n, err)
+	}
+	if n, err := sendHEADs(adapters.NewFlyIOAdapter("token"), "/apps/app/machines/m1", 2); n != 2 || err != nil {
+		log.Fatalf("FAIL: fly.io sent %d of 2 HEADs of one machine (%v), want both", n, err)
+	}
+	log.Println("ok: Linode, Render, and Fly.io pace HEAD requests as reads")
+
+	gh := adapters.NewGitHubAdapter("token")
+	for _, method := range []string{"HEAD", "OPTIONS"} {
+		if n, err := send(gh, method, "/repos/apache/airflow", 1); n != 1 || err != nil {
+			log.Fatalf("FAIL: github %s: %v", method, err)
+		}
+	}
+	if used := gh.RateLimitWindows()["secondary"].Used; used != 2 {
+		log.Fatalf("FAIL: github counted %d points for a HEAD and an OPTIONS, want 2", used)
+	}
+
+	log.Println("PASS: HEAD and OPTIONS are classified as reads across adapters")
+}
+
+func sendHEADs(adapter resilientbridge.ProviderAdapter, endpoint string, n int) (int, error) {
+	return send(adapter, "HEAD", endpoint, n)
+}
+
+// send issues n requests through an SDK that fails fast on rate limits, returning how many reached the
+// stub and the first error.
+func send(adapter resilientbridge.ProviderAdapter, method, endpoint string, n int) (int, error) {
+	s := &stub{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("p", adapter, &resilientbridge.ProviderConfig{
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+		HTTPClient:        &http.Client{Transport: s},
+	})
+	for i := 0; i < n; i++ {
+		if _, err := sdk.Request("p", &resilientbridge.NormalizedRequest{Method: method, Endpoint: endpoint}); err != nil {
+			return s.calls, err
+		}
+	}
+	return s.calls, nil
+}
+
+func isRateLimit(err error) bool {
+	var rlErr *resilientbridge.RateLimitError
+	return errors.As(err, &rlErr)
+}
@@ -0,0 +1,123 @@
+// request_signer.go
+//
+// Checks ProviderConfig.RequestSigner. SigV4Signer must reproduce the get-vanilla and post-vanilla-query
+// signatures of AWS's Signature Version 4 test suite. Through the SDK, in front of the npm adapter (which
+// has no token here): BearerSigner must authenticate requests but leave a request-level Authorization
+// header alone, a retried attempt must be signed again with a fresh X-Amz-Date, and EdgeGridSigner must
+// sign a POST without consuming its body.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// recorder answers with the queued statuses (then 200s) and records every request's headers and body.
+type recorder struct {
+	statuses []int
+	headers  []http.Header
+	bodies   []string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	r.headers = append(r.headers, req.Header.Clone())
+	r.bodies = append(r.bodies, string(body))
+	rec := httptest.NewRecorder()
+	if n := len(r.headers); n <= len(r.statuses) {
+		rec.WriteHeader(r.statuses[n-1])
+	}
+	rec.WriteString(`{}`)
+	return rec.Result(), nil
+}
+
+// awsExample are the credentials and time of AWS's SigV4 test suite.
+func awsExample() resilientbridge.SigV4Signer {
+	return resilientbridge.SigV4Signer{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:          "us-east-1",
+		Service:         "service",
+		Now:             func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) },
+	}
+}
+
+func sigV4(method, target, want string) {
+	req, _ := http.NewRequest(method, target, nil)
+	if err := awsExample().Sign(req); err != nil {
+		log.Fatalf("FAIL: signing %s %s: %v", method, target, err)
+	}
+	if got := req.Header.Get("Authorization"); !strings.HasSuffix(got, "Signature="+want) {
+		log.Fatalf("FAIL: %s %s signed as %q, want signature %s", method, target, got, want)
+	}
+}
+
+func register(stub *recorder, signer resilientbridge.RequestSigner) *resilientbridge.ResilientBridge {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("npm", adapters.NewNPMAdapter(), &resilientbridge.ProviderConfig{
+		MaxRetries:    1,
+		BaseBackoff:   resilientbridge.NoBackoff,
+		HTTPClient:    &http.Client{Transport: stub},
+		RequestSigner: signer,
+	})
+	return sdk
+}
+
+func main() {
+	sigV4("GET", "https://example.amazonaws.com/", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31")
+	sigV4("POST", "https://example.amazonaws.com/?Param1=value1", "28038455d6de14eafc1f9222cf5aa6f1a96197d7deb8263271d420d138af7f11")
+	log.Println("ok: SigV4 matches the AWS test suite")
+
+	stub := &recorder{}
+	sdk := register(stub, resilientbridge.BearerSigner{Token: "signed"})
+	if _, err := sdk.Request("npm", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/left-pad"}); err != nil {
+		log.Fatalf("FAIL: bearer request: %v", err)
+	}
+	override := map[string]string{"Authorization": "Bearer per-request"}
+	if _, err := sdk.Request("npm", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/left-pad", Headers: override}); err != nil {
+		log.Fatalf("FAIL: overridden request: %v", err)
+	}
+	if a, b := stub.headers[0].Get("Authorization"), stub.headers[1].Get("Authorization"); a != "Bearer signed" || b != "Bearer per-request" {
+		log.Fatalf("FAIL: Authorization %q and %q, want the signer's then the request's", a, b)
+	}
+	log.Println("ok: BearerSigner yields to request-level Authorization")
+
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signer := awsExample()
+	signer.Now = func() time.Time { clock = clock.Add(time.Second); return clock }
+	stub = &recorder{statuses: []int{http.StatusServiceUnavailable}}
+	if _, err := register(stub, signer).Request("npm", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/left-pad"}); err != nil {
+		log.Fatalf("FAIL: retried SigV4 request: %v", err)
+	}
+	if len(stub.headers) != 2 || stub.headers[0].Get("X-Amz-Date") == stub.headers[1].Get("X-Amz-Date") {
+		log.Fatalf("FAIL: %d attempts, X-Amz-Date not refreshed on retry", len(stub.headers))
+	}
+	log.Println("ok: retries are signed again")
+
+	stub = &recorder{}
+	edgeGrid := adapters.EdgeGridSigner{Creds: adapters.EdgeGridCreds{ClientToken: "ct", ClientSecret: "cs", AccessToken: "at"}}
+	body := []byte(`{"objects":["https://example.com/a"]}`)
+	if _, err := register(stub, edgeGrid).Request("npm", &resilientbridge.NormalizedRequest{Method: "POST", Endpoint: "/purge", Body: body}); err != nil {
+		log.Fatalf("FAIL: EdgeGrid request: %v", err)
+	}
+	if auth := stub.headers[0].Get("Authorization"); !strings.HasPrefix(auth, "EG1-HMAC-SHA256 client_token=ct;access_token=at;") || !strings.Contains(auth, ";signature=") {
+		log.Fatalf("FAIL: EdgeGrid Authorization %q", auth)
+	}
+	if !bytes.Equal([]byte(stub.bodies[0]), body) {
+		log.Fatalf("FAIL: body sent as %q after signing", stub.bodies[0])
+	}
+
+	log.Println("PASS: RequestSigner signs requests after the adapter's credentials")
+}
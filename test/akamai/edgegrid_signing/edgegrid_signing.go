@@ -0,0 +1,202 @@
+// edgegrid_signing.go
+//
+// Checks the Akamai adapter's EdgeGrid signatures. The vectors are Akamai's own: testdata.json as shipped
+// with the EdgeGrid client libraries (edgegrid-python's akamai/edgegrid/test/testdata.json), copied
+// unmodified next to this file. Every test in it that expects an Authorization header must be produced
+// exactly from its credentials, timestamp, nonce, headers_to_sign and max_body; tests expecting a client
+// error are skipped, as they cover checks the Python client does before signing.
+//
+// The max_body truncation and the header canonicalisation are also checked without the vectors: a POST
+// body longer than MaxBody must sign like its first MaxBody bytes (and one byte shorter must not), a PUT
+// body must not be signed at all, and signed header values must be compared with whitespace collapsed,
+// with headers outside HeadersToSign ignored. Finally, a request sent through the SDK must carry a
+// well-formed EG1-HMAC-SHA256 Authorization header.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+const (
+	timestamp = "20140321T19:34:21+0000"
+	nonce     = "nonce-xx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+)
+
+// testData is the layout of Akamai's testdata.json.
+type testData struct {
+	BaseURL       string   `json:"base_url"`
+	AccessToken   string   `json:"access_token"`
+	ClientToken   string   `json:"client_token"`
+	ClientSecret  string   `json:"client_secret"`
+	MaxBody       int      `json:"max_body"`
+	HeadersToSign []string `json:"headers_to_sign"`
+	Nonce         string   `json:"nonce"`
+	Timestamp     string   `json:"timestamp"`
+	Tests         []struct {
+		TestName string `json:"testName"`
+		Request  struct {
+			Method  string              `json:"method"`
+			Path    string              `json:"path"`
+			Headers []map[string]string `json:"headers"`
+			Data    string              `json:"data"`
+		} `json:"request"`
+		ExpectedAuthorization string `json:"expectedAuthorization"`
+		FailsWithMessage      string `json:"failsWithMessage"`
+	} `json:"tests"`
+}
+
+func main() {
+	publishedVectors()
+	canonicalisation()
+
+	creds := adapters.EdgeGridCreds{
+		Host:         "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret: "SOMESECRET",
+		AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+	}
+	var auth string
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("akamai", adapters.NewAkamaiAdapter(creds), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			auth = r.Header.Get("Authorization")
+			rec := httptest.NewRecorder()
+			rec.WriteString(`{}`)
+			return rec.Result(), nil
+		})},
+	})
+	if _, err := sdk.Request("akamai", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/papi/v1/groups"}); err != nil {
+		log.Fatalf("FAIL: request through the SDK: %v", err)
+	}
+	if !strings.HasPrefix(auth, "EG1-HMAC-SHA256 client_token="+creds.ClientToken+";access_token="+creds.AccessToken+";timestamp=") || !strings.Contains(auth, ";signature=") {
+		log.Fatalf("FAIL: unexpected Authorization header %q", auth)
+	}
+	log.Println("ok: requests through the SDK are signed")
+
+	log.Println("PASS: EdgeGrid signatures")
+}
+
+// publishedVectors checks every signing test of testdata.json. Without the file it says so and returns.
+func publishedVectors() {
+	_, source, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(source), "testdata.json")
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("skip: %s not present; copy Akamai's testdata.json there to check the published vectors", path)
+		return
+	}
+	if err != nil {
+		log.Fatalf("FAIL: reading %s: %v", path, err)
+	}
+	var data testData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Fatalf("FAIL: decoding %s: %v", path, err)
+	}
+	base, err := url.Parse(data.BaseURL)
+	if err != nil {
+		log.Fatalf("FAIL: base_url %q: %v", data.BaseURL, err)
+	}
+	creds := adapters.EdgeGridCreds{
+		Host:          base.Host,
+		ClientToken:   data.ClientToken,
+		ClientSecret:  data.ClientSecret,
+		AccessToken:   data.AccessToken,
+		MaxBody:       data.MaxBody,
+		HeadersToSign: data.HeadersToSign,
+	}
+
+	checked := 0
+	for _, test := range data.Tests {
+		if test.ExpectedAuthorization == "" {
+			log.Printf("skip (%s): expects %q", test.TestName, test.FailsWithMessage)
+			continue
+		}
+		u, err := base.Parse(test.Request.Path)
+		if err != nil {
+			log.Fatalf("FAIL (%s): path %q: %v", test.TestName, test.Request.Path, err)
+		}
+		header := http.Header{}
+		for _, h := range test.Request.Headers {
+			for name, value := range h {
+				header.Add(name, value)
+			}
+		}
+		got := creds.Authorization(test.Request.Method, u, header, []byte(test.Request.Data), data.Timestamp, data.Nonce)
+		if got != test.ExpectedAuthorization {
+			log.Fatalf("FAIL (%s): got %s, want %s", test.TestName, got, test.ExpectedAuthorization)
+		}
+		log.Printf("ok (%s)", test.TestName)
+		checked++
+	}
+	if checked == 0 {
+		log.Fatalf("FAIL: %s has no signing tests", path)
+	}
+}
+
+// canonicalisation checks the body truncation and header canonicalisation rules against each other.
+func canonicalisation() {
+	creds := adapters.EdgeGridCreds{
+		Host:          "akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		ClientToken:   "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+		ClientSecret:  "SOMESECRET",
+		AccessToken:   "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+		MaxBody:       2048,
+		HeadersToSign: []string{"X-Test1", "X-Test2", "X-Test3"},
+	}
+	u, _ := url.Parse("https://" + creds.Host + "/testapi/v1/t3")
+	sign := func(method string, header http.Header, body string) string {
+		if header == nil {
+			header = http.Header{}
+		}
+		return creds.Authorization(method, u, header, []byte(body), timestamp, nonce)
+	}
+
+	atLimit := sign("POST", nil, strings.Repeat("d", 2048))
+	if sign("POST", nil, strings.Repeat("d", 3000)) != atLimit {
+		log.Fatalf("FAIL: a POST body beyond MaxBody must sign like its first MaxBody bytes")
+	}
+	if sign("POST", nil, strings.Repeat("d", 2047)) == atLimit {
+		log.Fatalf("FAIL: a POST body shorter than MaxBody must be hashed in full")
+	}
+	if sign("PUT", nil, "PUT test") != sign("PUT", nil, "") {
+		log.Fatalf("FAIL: PUT bodies must not be signed")
+	}
+	log.Println("ok: POST bodies are hashed up to MaxBody, other bodies not at all")
+
+	headers := func(kv ...string) http.Header {
+		h := http.Header{}
+		for i := 0; i < len(kv); i += 2 {
+			h.Set(kv[i], kv[i+1])
+		}
+		return h
+	}
+	plain := sign("GET", headers("X-Test1", "first-thing second-thing", "X-Test2", "t2"), "")
+	if sign("GET", headers("x-test1", "     first-thing \t    second-thing  ", "X-Test2", "t2"), "") != plain {
+		log.Fatalf("FAIL: signed header values must be compared with whitespace collapsed")
+	}
+	if sign("GET", headers("X-Test1", "first-thing second-thing", "X-Test2", "t2", "X-Extra", "this won't be included"), "") != plain {
+		log.Fatalf("FAIL: headers outside HeadersToSign must not be signed")
+	}
+	if sign("GET", headers("X-Test1", "first-thing second-thing"), "") == plain {
+		log.Fatalf("FAIL: headers in HeadersToSign must be signed")
+	}
+	log.Println("ok: signed headers are canonicalised, others ignored")
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
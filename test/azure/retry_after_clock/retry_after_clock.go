@@ -0,0 +1,62 @@
+// retry_after_clock.go
+//
+// Checks that the Azure adapter reads an HTTP-date Retry-After against the provider's Clock. With a
+// FakeClock set in 2100, a 429 whose Retry-After is 30 seconds after the fake time must switch the adapter
+// to the token bucket model (a headerless response then reports the 250-request read bucket), while one
+// 10 minutes after it must not. Against the real clock, both dates are decades away.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+var start = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func main() {
+	check("30s", 30*time.Second, true)
+	check("10m", 10*time.Minute, false)
+	log.Println("PASS: Azure Retry-After dates are read against the provider's clock")
+}
+
+// check sends one GET answered with a 429 whose Retry-After is start plus after, and fails unless the
+// adapter's token bucket mode is as wanted afterwards.
+func check(name string, after time.Duration, wantBucket bool) {
+	adapter := adapters.NewAzureAdapter("token")
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("azure", adapter, &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Retry-After", start.Add(after).Format(http.TimeFormat))
+			rec.WriteHeader(http.StatusTooManyRequests)
+			return rec.Result(), nil
+		})},
+		Clock:             resilientbridge.NewFakeClock(start),
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+	})
+	_, err := sdk.Request("azure", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/subscriptions/sub/resourcegroups"})
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL (%s): expected a *RateLimitError, got %v", name, err)
+	}
+
+	info, err := adapter.ParseRateLimitInfo(&resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}})
+	if err != nil {
+		log.Fatalf("FAIL (%s): ParseRateLimitInfo: %v", name, err)
+	}
+	if gotBucket := info != nil && info.MaxRequests != nil && *info.MaxRequests == 250; gotBucket != wantBucket {
+		log.Fatalf("FAIL (%s): token bucket mode = %v after a Retry-After %v past the fake clock, want %v", name, gotBucket, after, wantBucket)
+	}
+	log.Printf("ok (%s): token bucket mode = %v", name, wantBucket)
+}
@@ -0,0 +1,98 @@
+// spn_resources.go
+//
+// Checks that utils.AzureSPN caches tokens per resource. A stub AAD token endpoint issues ARM tokens
+// valid for an hour and Microsoft Graph tokens that are already expired. Goroutines acquiring the default
+// resource (AcquireTokenSilent) and Graph (AcquireTokenForResource, as a bare URI) concurrently must each
+// get their own resource's token; ARM must be fetched once and answered from the cache afterwards, while
+// every Graph acquisition fetches a new token without touching the ARM one.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/opengovern/resilient-bridge/utils"
+)
+
+const (
+	armScope   = "https://management.azure.com/.default"
+	graphScope = "https://graph.microsoft.com/.default"
+)
+
+func main() {
+	var mu sync.Mutex
+	issued := map[string]int{}
+	aad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.URL.Path != "/tenant/oauth2/v2.0/token" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		scope := r.PostForm.Get("scope")
+		mu.Lock()
+		issued[scope]++
+		n := issued[scope]
+		mu.Unlock()
+		expiresIn := 3600
+		if scope == graphScope {
+			expiresIn = 0
+		}
+		fmt.Fprintf(w, `{"token_type":"Bearer","expires_in":%d,"access_token":"%s#%d"}`, expiresIn, scope, n)
+	}))
+	defer aad.Close()
+
+	spn, err := utils.NewAzureSPN(&utils.AzureSPNConfig{
+		TenantID:      "tenant",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		AuthorityHost: aad.URL,
+	})
+	if err != nil {
+		log.Fatalf("FAIL: NewAzureSPN: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := spn.AcquireTokenSilent(ctx); err != nil {
+		log.Fatalf("FAIL: first ARM token: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tok, err := spn.AcquireTokenSilent(ctx)
+			if err == nil && tok.AccessToken != armScope+"#1" {
+				err = fmt.Errorf("ARM token %q, want the cached %s#1", tok.AccessToken, armScope)
+			}
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			tok, err := spn.AcquireTokenForResource(ctx, "https://graph.microsoft.com")
+			if err == nil && !strings.HasPrefix(tok.AccessToken, graphScope+"#") {
+				err = fmt.Errorf("Graph token %q is not for %s", tok.AccessToken, graphScope)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			log.Fatalf("FAIL: %v", err)
+		}
+	}
+
+	if issued[armScope] != 1 || issued[graphScope] != 20 {
+		log.Fatalf("FAIL: issued %d ARM and %d Graph tokens, want 1 and 20", issued[armScope], issued[graphScope])
+	}
+	log.Printf("ok: issued %v", issued)
+
+	log.Println("PASS: AzureSPN caches and refreshes each resource's token independently")
+}
@@ -0,0 +1,72 @@
+// rate_limit_headers.go
+//
+// Checks the Datadog adapter's parsing of the X-RateLimit-Limit/-Period/-Remaining/-Reset quartet.
+// Limit and Remaining must be reported as is and the reset placed Reset seconds from now. Reset wins over
+// Period when both are present, Period (the window length) is used once Remaining is 0 and Reset is
+// missing, and a response without the headers reports no limits at all.
+
+package main
+
+import (
+	"log"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+func main() {
+	adapter := adapters.NewDatadogAdapter("api-key", "app-key", "")
+
+	check(adapter, "quartet", map[string]string{
+		"x-ratelimit-limit":     "100",
+		"x-ratelimit-period":    "3600",
+		"x-ratelimit-remaining": "42",
+		"x-ratelimit-reset":     "120",
+	}, 100, 42, 120*time.Second)
+
+	check(adapter, "exhausted, Reset present", map[string]string{
+		"x-ratelimit-limit":     "100",
+		"x-ratelimit-period":    "3600",
+		"x-ratelimit-remaining": "0",
+		"x-ratelimit-reset":     "30",
+	}, 100, 0, 30*time.Second)
+
+	check(adapter, "exhausted, Reset missing", map[string]string{
+		"x-ratelimit-limit":     "100",
+		"x-ratelimit-period":    "60",
+		"x-ratelimit-remaining": "0",
+	}, 100, 0, time.Minute)
+
+	info, err := adapter.ParseRateLimitInfo(&resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}})
+	if err != nil || info != nil {
+		log.Fatalf("FAIL (no headers): got %+v, %v, want no limits", info, err)
+	}
+	log.Println("ok (no headers): no limits reported")
+
+	log.Println("PASS: Datadog rate limit headers are parsed")
+}
+
+// check parses headers and fails unless the limit, remaining count and reset (within a second of
+// now+reset) match.
+func check(adapter *adapters.DatadogAdapter, name string, headers map[string]string, limit, remaining int, reset time.Duration) {
+	before := time.Now()
+	info, err := adapter.ParseRateLimitInfo(&resilientbridge.NormalizedResponse{StatusCode: 200, Headers: headers})
+	if err != nil || info == nil {
+		log.Fatalf("FAIL (%s): got %+v, %v", name, info, err)
+	}
+	if info.MaxRequests == nil || *info.MaxRequests != limit {
+		log.Fatalf("FAIL (%s): MaxRequests = %v, want %d", name, info.MaxRequests, limit)
+	}
+	if info.RemainingRequests == nil || *info.RemainingRequests != remaining {
+		log.Fatalf("FAIL (%s): RemainingRequests = %v, want %d", name, info.RemainingRequests, remaining)
+	}
+	if info.ResetRequestsAt == nil {
+		log.Fatalf("FAIL (%s): no ResetRequestsAt, want now+%v", name, reset)
+	}
+	got := time.UnixMilli(*info.ResetRequestsAt).Sub(before)
+	if got < reset-time.Second || got > reset+time.Second {
+		log.Fatalf("FAIL (%s): reset in %v, want %v", name, got, reset)
+	}
+	log.Printf("ok (%s): %d/%d remaining, reset in %v", name, remaining, limit, got.Round(time.Second))
+}
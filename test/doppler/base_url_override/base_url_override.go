@@ -0,0 +1,82 @@
+// base_url_override.go
+//
+// Checks NormalizedRequest.BaseURLOverride. Requests go through the real Doppler adapter to a recording
+// transport (wired in through ProviderConfig.HTTPClient). A request with an override must reach the
+// override host, requests before and after it the default api.doppler.com, and an absolute endpoint its
+// own host with nothing prepended. The override request must still count against the provider's limits,
+// and carry the adapter's token like the default-host requests; the absolute endpoint on another host must
+// be sent without it.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// recorder answers every request with an empty JSON object and remembers the URLs it was sent to and
+// their Authorization headers.
+type recorder struct {
+	urls  []string
+	auths []string
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.urls = append(r.urls, req.URL.String())
+	r.auths = append(r.auths, req.Header.Get("Authorization"))
+	rec := httptest.NewRecorder()
+	rec.WriteString(`{}`)
+	return rec.Result(), nil
+}
+
+func main() {
+	transport := &recorder{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("doppler", &adapters.DopplerAdapter{APIToken: "token"}, &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		HTTPClient:        &http.Client{Transport: transport},
+	})
+
+	requests := []*resilientbridge.NormalizedRequest{
+		{Method: "GET", Endpoint: "/v3/projects"},
+		{Method: "GET", Endpoint: "/v3/projects", BaseURLOverride: "https://eu.doppler.example/"},
+		{Method: "GET", Endpoint: "/v3/projects"},
+		{Method: "GET", Endpoint: "https://status.doppler.example/v3/ping", BaseURLOverride: "https://eu.doppler.example"},
+	}
+	want := []string{
+		"https://api.doppler.com/v3/projects",
+		"https://eu.doppler.example/v3/projects",
+		"https://api.doppler.com/v3/projects",
+		"https://status.doppler.example/v3/ping",
+	}
+	wantAuth := []string{"Bearer token", "Bearer token", "Bearer token", ""}
+	for _, req := range requests {
+		if _, err := sdk.Request("doppler", req); err != nil {
+			log.Fatalf("FAIL: %s: %v", req.Endpoint, err)
+		}
+	}
+
+	if len(transport.urls) != len(want) {
+		log.Fatalf("FAIL: sent %d requests, want %d", len(transport.urls), len(want))
+	}
+	for i, u := range transport.urls {
+		if u != want[i] {
+			log.Fatalf("FAIL: request %d went to %s, want %s", i+1, u, want[i])
+		}
+		if transport.auths[i] != wantAuth[i] {
+			log.Fatalf("FAIL: request %d to %s sent Authorization %q, want %q", i+1, u, transport.auths[i], wantAuth[i])
+		}
+		log.Printf("ok: request %d -> %s (Authorization %q)", i+1, u, transport.auths[i])
+	}
+
+	status, err := sdk.RateLimitStatus("doppler")
+	if err != nil || status == nil || status.RemainingRequests == nil {
+		log.Fatalf("FAIL: no rate limit status recorded for the provider: %+v, %v", status, err)
+	}
+
+	log.Println("PASS: BaseURLOverride retargets a single request and leaves the others on the default host")
+}
@@ -0,0 +1,165 @@
+// count_graphql.go
+//
+// Checks that CountIssues, CountPullRequests, and CountCommits count with GraphQL totalCount and fall back
+// to the REST Link method. A stub GitHub has 10 issues and 15 pull requests (so its REST /issues listing
+// has 25 entries), 9 commits on the default branch and 7 on "release". Through GraphQL the counts must be
+// exact, with one GraphQL request each; with CountOptions.UseREST, and when GraphQL cannot answer (errors
+// for an undefined field, or the field left out), issues must be counted as /issues minus /pulls and the
+// other counts come from the Link headers. A 401 (ErrUnauthorized) or a rate limit from GraphQL must be returned,
+// without any REST request.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+type stubGitHub struct {
+	mu           sync.Mutex
+	graphQLCalls int
+	rest         int
+	graphQL      string // How /graphql answers: "up", "undefined field", "field missing", "unauthorized", "rate limited"
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec := httptest.NewRecorder()
+	last := func(n int) {
+		rec.Header().Set("Link", fmt.Sprintf(`<https://api.github.com%s?per_page=1&page=%d>; rel="last"`, req.URL.Path, n))
+	}
+
+	switch path := req.URL.Path; {
+	case path == "/graphql":
+		s.graphQLCalls++
+		switch s.graphQL {
+		case "undefined field":
+			rec.WriteString(`{"errors":[{"message":"Field 'history' doesn't exist on type 'Commit'"}]}`)
+			return rec.Result(), nil
+		case "field missing":
+			rec.WriteString(`{"data":{"repository":{"defaultBranchRef":{"target":{}},"object":{}}}}`)
+			return rec.Result(), nil
+		case "unauthorized":
+			rec.WriteHeader(http.StatusUnauthorized)
+			rec.WriteString(`{"message":"This endpoint requires you to be authenticated."}`)
+			return rec.Result(), nil
+		case "rate limited":
+			rec.Header().Set("X-RateLimit-Remaining", "0")
+			rec.WriteHeader(http.StatusForbidden)
+			rec.WriteString(`{"message":"API rate limit exceeded"}`)
+			return rec.Result(), nil
+		}
+		body, _ := io.ReadAll(req.Body)
+		query := string(body)
+		switch {
+		case strings.Contains(query, "issues {"):
+			rec.WriteString(`{"data":{"repository":{"issues":{"totalCount":10}}}}`)
+		case strings.Contains(query, "pullRequests {"):
+			rec.WriteString(`{"data":{"repository":{"pullRequests":{"totalCount":15}}}}`)
+		case strings.Contains(query, "object(expression"):
+			rec.WriteString(`{"data":{"repository":{"object":{"history":{"totalCount":7}}}}}`)
+		default:
+			rec.WriteString(`{"data":{"repository":{"defaultBranchRef":{"target":{"history":{"totalCount":9}}}}}}`)
+		}
+	case path == "/repos/acme/app":
+		rec.WriteString(`{"name":"app","full_name":"acme/app","archived":false,"disabled":false}`)
+	case path == "/repos/acme/app/issues":
+		s.rest++
+		last(25)
+	case path == "/repos/acme/app/pulls":
+		s.rest++
+		last(15)
+	case path == "/repos/acme/app/commits":
+		s.rest++
+		if req.URL.Query().Get("sha") == "release" {
+			last(7)
+		} else {
+			last(9)
+		}
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	}
+	return rec.Result(), nil
+}
+
+type counts struct {
+	issues, pulls, commits, release int
+}
+
+func main() {
+	want := counts{issues: 10, pulls: 15, commits: 9, release: 7}
+
+	stub := &stubGitHub{graphQL: "up"}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient:        &http.Client{Transport: stub},
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+	})
+
+	if got := count(sdk, nil); got != want || stub.graphQLCalls != 4 || stub.rest != 0 {
+		log.Fatalf("FAIL: GraphQL counts %+v with %d GraphQL and %d REST requests, want %+v with 4 and 0", got, stub.graphQLCalls, stub.rest, want)
+	}
+	log.Printf("ok: GraphQL counts %+v", want)
+
+	stub.graphQLCalls = 0
+	if got := count(sdk, &github.CountOptions{UseREST: true}); got != want || stub.graphQLCalls != 0 {
+		log.Fatalf("FAIL: UseREST counts %+v after %d GraphQL requests, want %+v and none", got, stub.graphQLCalls, want)
+	}
+	log.Println("ok: UseREST counts issues as /issues minus /pulls")
+
+	for _, mode := range []string{"undefined field", "field missing"} {
+		stub.graphQL, stub.graphQLCalls = mode, 0
+		if got := count(sdk, nil); got != want || stub.graphQLCalls != 4 {
+			log.Fatalf("FAIL (%s): fallback counts %+v after %d GraphQL requests, want %+v after 4", mode, got, stub.graphQLCalls, want)
+		}
+		log.Printf("ok (%s): counted with the REST method", mode)
+	}
+
+	stub.graphQL, stub.rest = "unauthorized", 0
+	_, err := github.CountIssues(sdk, "acme", "app", nil)
+	if !errors.Is(err, resilientbridge.ErrUnauthorized) || stub.rest != 0 {
+		log.Fatalf("FAIL (unauthorized): got %v after %d REST requests, want ErrUnauthorized and none", err, stub.rest)
+	}
+	log.Println("ok (unauthorized): ErrUnauthorized is returned")
+
+	stub.graphQL = "rate limited"
+	_, err = github.CountPullRequests(sdk, "acme", "app", nil)
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) || stub.rest != 0 {
+		log.Fatalf("FAIL (rate limited): got %v after %d REST requests, want a *RateLimitError and none", err, stub.rest)
+	}
+	log.Println("ok (rate limited): the rate limit is returned")
+
+	log.Println("PASS: counts come from GraphQL totalCount, with the REST method as a fallback when GraphQL cannot answer")
+}
+
+func count(sdk *resilientbridge.ResilientBridge, opts *github.CountOptions) counts {
+	must := func(n int, err error) int {
+		if err != nil {
+			log.Fatalf("FAIL: %v", err)
+		}
+		return n
+	}
+	release := &github.CountOptions{Ref: "release"}
+	if opts != nil {
+		release.UseREST = opts.UseREST
+	}
+	return counts{
+		issues:  must(github.CountIssues(sdk, "acme", "app", opts)),
+		pulls:   must(github.CountPullRequests(sdk, "acme", "app", opts)),
+		commits: must(github.CountCommits(sdk, "acme", "app", opts)),
+		release: must(github.CountCommits(sdk, "acme", "app", release)),
+	}
+}
@@ -0,0 +1,87 @@
+// default_branch.go
+//
+// Checks github.DefaultBranch and its use by EnrichMetrics against a stub GitHub. acme/legacy defaults
+// to "master": DefaultBranch must return it and answer a second call from its cache, and EnrichMetrics,
+// given a listing without default_branch, must count commits with sha=master (a count against "main"
+// gets a 404). acme/bare has an empty default_branch in REST, so the name must come from GraphQL.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+type stubGitHub struct {
+	mu   sync.Mutex
+	hits map[string]int // request path -> count
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.hits[req.URL.Path]++
+	s.mu.Unlock()
+
+	rec := httptest.NewRecorder()
+	switch path := req.URL.Path; {
+	case path == "/repos/acme/legacy":
+		rec.WriteString(`{"name":"legacy","owner":{"login":"acme"},"default_branch":"master"}`)
+	case path == "/repos/acme/bare":
+		rec.WriteString(`{"name":"bare","owner":{"login":"acme"},"default_branch":""}`)
+	case path == "/graphql":
+		rec.WriteString(`{"data":{"repository":{"defaultBranchRef":{"name":"trunk"}}}}`)
+	case path == "/repos/acme/legacy/commits" && req.URL.Query().Get("sha") != "master":
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"No commit found for SHA: ` + req.URL.Query().Get("sha") + `"}`)
+	case strings.HasPrefix(path, "/repos/acme/legacy/"):
+		rec.Header().Set("Link", fmt.Sprintf(`<https://api.github.com%s?per_page=1&page=42>; rel="last"`, path))
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	stub := &stubGitHub{hits: map[string]int{}}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub},
+	})
+
+	for i := 0; i < 2; i++ {
+		branch, err := github.DefaultBranch(sdk, "acme", "legacy")
+		if err != nil || branch != "master" {
+			log.Fatalf("FAIL: DefaultBranch(acme/legacy) = %q, %v; want master", branch, err)
+		}
+	}
+	if n := stub.hits["/repos/acme/legacy"]; n != 1 {
+		log.Fatalf("FAIL: repository fetched %d times, want 1 (second call cached)", n)
+	}
+	log.Println("ok: acme/legacy defaults to master, resolved once")
+
+	branch, err := github.DefaultBranch(sdk, "acme", "bare")
+	if err != nil || branch != "trunk" {
+		log.Fatalf("FAIL: DefaultBranch(acme/bare) = %q, %v; want trunk from GraphQL", branch, err)
+	}
+	log.Println("ok: empty REST default_branch falls back to GraphQL defaultBranchRef")
+
+	repo := &github.RepoDetail{}
+	repo.Name, repo.Owner.Login = "legacy", "acme"
+	if errs := github.EnrichMetrics(sdk, []*github.RepoDetail{repo}, 2); errs != nil {
+		log.Fatalf("FAIL: EnrichMetrics: %v", errs[0])
+	}
+	if repo.Metrics.Commits != 42 {
+		log.Fatalf("FAIL: commits = %d, want 42 counted on master", repo.Metrics.Commits)
+	}
+
+	log.Println("PASS: default branches are resolved, cached, and used for commit counts")
+}
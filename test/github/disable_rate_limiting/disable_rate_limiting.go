@@ -0,0 +1,90 @@
+// disable_rate_limiting.go
+//
+// Checks ProviderConfig.DisableRateLimiting with the GitHub adapter. Its local REST window is set to 1
+// request per minute and an EndpointLimit allows 1 request per minute as well, so without the option the
+// second request already gets a synthetic 429 (reported by RateLimitFailFast). With the option, five
+// requests must all reach a stub server, and a real 429 from the server (Retry-After: 1) must still be
+// retried.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// stubGitHub answers every request with 200, except the ones listed in tooMany, which get a 429.
+type stubGitHub struct {
+	sent    int
+	tooMany map[int]bool
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.sent++
+	rec := httptest.NewRecorder()
+	if s.tooMany[s.sent] {
+		rec.Header().Set("Retry-After", "1")
+		rec.WriteHeader(http.StatusTooManyRequests)
+		rec.WriteString(`{"message":"API rate limit exceeded"}`)
+		return rec.Result(), nil
+	}
+	rec.WriteString(`{"id":1}`)
+	return rec.Result(), nil
+}
+
+func newSDK(stub *stubGitHub, disable bool) *resilientbridge.ResilientBridge {
+	window := int64(60)
+	behavior := resilientbridge.RateLimitBlock
+	if !disable {
+		behavior = resilientbridge.RateLimitFailFast // report the first local refusal instead of waiting a minute
+	}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		MaxRetries:          2,
+		BaseBackoff:         resilientbridge.NoBackoff,
+		MaxRequestsOverride: resilientbridge.IntPtr(1),
+		WindowSecsOverride:  &window,
+		EndpointLimits: []resilientbridge.EndpointLimit{
+			{Pattern: regexp.MustCompile(`^/repos/`), Max: 1, WindowSecs: 60},
+		},
+		RateLimitBehavior:   behavior,
+		DisableRateLimiting: disable,
+		HTTPClient:          &http.Client{Transport: stub},
+	})
+	return sdk
+}
+
+func main() {
+	req := &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/apache/airflow"}
+
+	limited := &stubGitHub{}
+	sdk := newSDK(limited, false)
+	if _, err := sdk.Request("github", req); err != nil {
+		log.Fatalf("FAIL: first request with rate limiting: %v", err)
+	}
+	_, err := sdk.Request("github", req)
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) || limited.sent != 1 {
+		log.Fatalf("FAIL: second request with rate limiting should be refused locally, got %v after %d sent", err, limited.sent)
+	}
+	log.Printf("ok: with rate limiting, the second request is refused locally: %v", err)
+
+	unlimited := &stubGitHub{tooMany: map[int]bool{3: true}}
+	sdk = newSDK(unlimited, true)
+	for i := 0; i < 5; i++ {
+		if _, err := sdk.Request("github", req); err != nil {
+			log.Fatalf("FAIL: request %d without rate limiting: %v", i+1, err)
+		}
+	}
+	if unlimited.sent != 6 {
+		log.Fatalf("FAIL: stub saw %d requests, want 6 (5 plus one retry of the real 429)", unlimited.sent)
+	}
+
+	log.Println("PASS: DisableRateLimiting sends every request and still retries real 429s")
+}
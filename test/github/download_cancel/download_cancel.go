@@ -0,0 +1,89 @@
+// download_cancel.go
+//
+// Checks that github.Download honors its context. A stub transport behind the GitHub adapter serves a
+// run's logs but breaks the connection after the first bytes, so the download waits before resuming with
+// a Range request. With a BaseBackoff of 10s, cancelling the context during that wait must end the
+// download within milliseconds with context.Canceled. With NoBackoff, the resumed attempt must complete
+// the download from where the first one stopped.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+const logs = "2024-05-01T10:00:00Z build started\n2024-05-01T10:05:00Z build passed\n"
+
+// brokenBody returns its data, then fails as a dropped connection would.
+type brokenBody struct {
+	io.Reader
+}
+
+func (b *brokenBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *brokenBody) Close() error { return nil }
+
+// flaky drops the first attempt after 10 bytes and serves Range requests completely.
+type flaky struct {
+	calls int
+}
+
+func (f *flaky) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.calls++
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}, Request: req}
+	if rng := req.Header.Get("Range"); rng != "" {
+		resp.StatusCode = http.StatusPartialContent
+		resp.Body = io.NopCloser(strings.NewReader(logs[10:]))
+		return resp, nil
+	}
+	resp.Body = &brokenBody{Reader: strings.NewReader(logs[:10])}
+	return resp, nil
+}
+
+func newSDK(stub *flaky, backoff time.Duration) *resilientbridge.ResilientBridge {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		BaseBackoff: backoff,
+		HTTPClient:  &http.Client{Transport: stub},
+	})
+	return sdk
+}
+
+func main() {
+	stub := &flaky{}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	var out strings.Builder
+	err := github.Download(ctx, newSDK(stub, 10*time.Second), "/repos/acme/app/actions/runs/1/logs", &out)
+	elapsed := time.Since(start)
+	if !errors.Is(err, context.Canceled) || elapsed > time.Second || stub.calls != 1 {
+		log.Fatalf("FAIL: cancelled download returned %v after %v and %d calls, want context.Canceled within milliseconds", err, elapsed, stub.calls)
+	}
+	log.Printf("ok: cancelled during the resume wait, returned after %v", elapsed.Round(time.Millisecond))
+
+	stub = &flaky{}
+	out.Reset()
+	err = github.DownloadRunLogs(context.Background(), newSDK(stub, resilientbridge.NoBackoff), "acme", "app", 1, &out)
+	if err != nil || out.String() != logs || stub.calls != 2 {
+		log.Fatalf("FAIL: resumed download = %q after %d calls (%v)", out.String(), stub.calls, err)
+	}
+
+	log.Println("PASS: downloads resume with the provider's backoff and stop when cancelled")
+}
@@ -0,0 +1,91 @@
+// empty_repo.go
+//
+// Checks that the github package treats a repository without commits as empty rather than failing. A stub
+// in front of the GitHub adapter answers like GitHub does for a freshly created repository: 409 Conflict
+// with "Git Repository is empty." for commits and branches (and a body-less 409 for HEAD requests), and
+// 404 "This repository is empty." for contents. ListCommits, ListBranches, ListContents, and CountCommits
+// (with the REST method) must all return empty results without an error, while an unrelated 409 or 404 must still fail.
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+const (
+	emptyRepoBody    = `{"message":"Git Repository is empty.","documentation_url":"https://docs.github.com/rest/commits/commits#list-commits","status":"409"}`
+	emptyContentBody = `{"message":"This repository is empty.","documentation_url":"https://docs.github.com/rest/repos/contents#get-repository-content","status":"404"}`
+)
+
+// stubGitHub answers requests for the "empty" repository like GitHub does; everything else is the real adapter.
+type stubGitHub struct {
+	*adapters.GitHubAdapter
+}
+
+func (s *stubGitHub) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	respond := func(status int, body string) (*resilientbridge.NormalizedResponse, error) {
+		if req.Method == "HEAD" {
+			body = ""
+		}
+		return &resilientbridge.NormalizedResponse{StatusCode: status, Headers: map[string]string{}, Data: []byte(body)}, nil
+	}
+	switch {
+	case req.Endpoint == "/repos/acme/empty":
+		return respond(200, `{"name":"empty","full_name":"acme/empty","archived":false,"disabled":false}`)
+	case strings.HasPrefix(req.Endpoint, "/repos/acme/empty/contents"):
+		return respond(404, emptyContentBody)
+	case strings.HasPrefix(req.Endpoint, "/repos/acme/empty/"):
+		return respond(409, emptyRepoBody)
+	case strings.HasPrefix(req.Endpoint, "/repos/acme/busy/"):
+		return respond(409, `{"message":"Merge conflict"}`)
+	}
+	return respond(404, `{"message":"Not Found"}`)
+}
+
+func (s *stubGitHub) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return s.ExecuteRequest(req)
+}
+
+func main() {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, &stubGitHub{GitHubAdapter: adapters.NewGitHubAdapter("")}, &resilientbridge.ProviderConfig{MaxRetries: 0})
+
+	if !github.IsEmptyRepoResponse(&resilientbridge.NormalizedResponse{StatusCode: 409, Data: []byte(emptyRepoBody)}) {
+		log.Fatalf("FAIL: IsEmptyRepoResponse rejected GitHub's 409 empty repository body")
+	}
+	if github.IsEmptyRepoResponse(&resilientbridge.NormalizedResponse{StatusCode: 409, Data: []byte(`{"message":"Merge conflict"}`)}) {
+		log.Fatalf("FAIL: IsEmptyRepoResponse accepted an unrelated 409")
+	}
+
+	commits, err := github.ListCommits(sdk, "acme", "empty", nil)
+	if err != nil || len(commits) != 0 {
+		log.Fatalf("FAIL: ListCommits on an empty repository = %d commits, %v", len(commits), err)
+	}
+	branches, err := github.ListBranches(sdk, "acme", "empty")
+	if err != nil || len(branches) != 0 {
+		log.Fatalf("FAIL: ListBranches on an empty repository = %d branches, %v", len(branches), err)
+	}
+	contents, err := github.ListContents(sdk, "acme", "empty", "", "")
+	if err != nil || len(contents) != 0 {
+		log.Fatalf("FAIL: ListContents on an empty repository = %d entries, %v", len(contents), err)
+	}
+	count, err := github.CountCommits(sdk, "acme", "empty", &github.CountOptions{UseREST: true})
+	if err != nil || count != 0 {
+		log.Fatalf("FAIL: CountCommits on an empty repository = %d, %v", count, err)
+	}
+
+	if _, err := github.ListCommits(sdk, "acme", "busy", nil); err == nil {
+		log.Fatalf("FAIL: ListCommits swallowed an unrelated 409")
+	}
+	if _, err := github.ListContents(sdk, "acme", "missing", "README.md", ""); err == nil {
+		log.Fatalf("FAIL: ListContents swallowed a plain 404")
+	}
+
+	log.Println("PASS: empty repositories yield empty results, other 409/404 answers still fail")
+}
@@ -0,0 +1,102 @@
+// follow_location.go
+//
+// Checks NormalizedRequest.FollowLocationStripAuth with the GitHub adapter and a stub transport serving
+// two hosts: api.github.com answers with a 302 to a signed URL on storage.actions.githubusercontent.com, which
+// rejects requests carrying an Authorization header the way signed storage does. The client's
+// CheckRedirect forwards the original headers on every hop (as older Go releases did for subdomains), so
+// the plain request must fail with 403; with the option, the download must succeed, both buffered and
+// streamed, and the token must never reach storage.
+
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+const blob = "zip-bytes"
+
+type stubHosts struct {
+	storageAuth []string // Authorization seen by storage, per request
+}
+
+func (s *stubHosts) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	switch req.URL.Host {
+	case "api.github.com":
+		rec.Header().Set("Location", "https://storage.actions.githubusercontent.com/blob?sig=abc")
+		rec.WriteHeader(http.StatusFound)
+	case "storage.actions.githubusercontent.com":
+		auth := req.Header.Get("Authorization")
+		s.storageAuth = append(s.storageAuth, auth)
+		if auth != "" {
+			rec.WriteHeader(http.StatusForbidden)
+			rec.WriteString(`{"message":"Only one auth mechanism allowed"}`)
+			break
+		}
+		rec.WriteString(blob)
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+	}
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// forwardHeaders copies every header of the original request onto each redirect.
+func forwardHeaders(req *http.Request, via []*http.Request) error {
+	for k, v := range via[0].Header {
+		req.Header[k] = v
+	}
+	return nil
+}
+
+func main() {
+	stub := &stubHosts{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("secret-token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub, CheckRedirect: forwardHeaders},
+	})
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "/repos/acme/app/actions/artifacts/1/zip",
+	}
+
+	if _, err := sdk.Request("github", req); err == nil {
+		log.Fatalf("FAIL: without FollowLocationStripAuth the token should reach storage and be rejected")
+	}
+	if len(stub.storageAuth) != 1 || stub.storageAuth[0] == "" {
+		log.Fatalf("FAIL: expected storage to see the token once without the option, saw %q", stub.storageAuth)
+	}
+	log.Println("ok: without the option the token follows the redirect and is rejected")
+	stub.storageAuth = nil
+
+	req.FollowLocationStripAuth = true
+	resp, err := sdk.Request("github", req)
+	if err != nil || string(resp.Data) != blob {
+		log.Fatalf("FAIL: buffered download with FollowLocationStripAuth: %v", err)
+	}
+
+	stream, err := sdk.RequestStream(context.Background(), "github", req)
+	if err != nil {
+		log.Fatalf("FAIL: streamed download with FollowLocationStripAuth: %v", err)
+	}
+	data, err := io.ReadAll(stream.Body)
+	stream.Body.Close()
+	if err != nil || string(data) != blob {
+		log.Fatalf("FAIL: streamed body = %q, %v", data, err)
+	}
+
+	for _, auth := range stub.storageAuth {
+		if auth != "" {
+			log.Fatalf("FAIL: storage received Authorization %q", auth)
+		}
+	}
+	log.Println("PASS: redirects to signed storage are followed without credentials")
+}
@@ -0,0 +1,122 @@
+// graphql_rate_limited.go
+//
+// Checks that GraphQL rate limits reported in a 200 body ({"errors":[{"type":"RATE_LIMITED",...}]})
+// are treated as rate limit errors rather than successes. A stub in front of the GitHub adapter answers
+// the first requests with such a body: with RateLimitFailFast, github.GraphQL must return a
+// *RateLimitError carrying the secondary limit wait; with retries on a FakeClock, the query must wait,
+// be retried, and return the data of the next response. An ordinary GraphQL error (NOT_FOUND) must
+// still come back as GraphQLErrors after a single request.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+const (
+	rateLimited = `{"data":null,"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded for user ID 1."}]}`
+	notFound    = `{"data":{"repository":null},"errors":[{"type":"NOT_FOUND","path":["repository"],"message":"Could not resolve to a Repository with the name 'apache/nope'."}]}`
+	found       = `{"data":{"viewer":{"login":"octocat"}}}`
+)
+
+// stubGitHub answers requests with the queued bodies (repeating the last one); everything else is the
+// real adapter.
+type stubGitHub struct {
+	*adapters.GitHubAdapter
+
+	mu     sync.Mutex
+	bodies []string
+	calls  int
+}
+
+func (s *stubGitHub) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body := s.bodies[len(s.bodies)-1]
+	if s.calls < len(s.bodies) {
+		body = s.bodies[s.calls]
+	}
+	s.calls++
+	return &resilientbridge.NormalizedResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{"x-ratelimit-remaining": "4321", "x-ratelimit-resource": "graphql"},
+		Data:       []byte(body),
+	}, nil
+}
+
+func (s *stubGitHub) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return s.ExecuteRequest(req)
+}
+
+const viewerQuery = `query { viewer { login } }`
+
+func main() {
+	// Fail fast: the rate limit is reported, not decoded as an empty result
+	stub := &stubGitHub{GitHubAdapter: adapters.NewGitHubAdapter(""), bodies: []string{rateLimited}}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, stub, &resilientbridge.ProviderConfig{
+		MaxRetries:        3,
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+	})
+	err := github.GraphQL(sdk, viewerQuery, nil, nil)
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: expected a *RateLimitError for RATE_LIMITED, got %v", err)
+	}
+	if rlErr.RetryAfter != time.Minute {
+		log.Fatalf("FAIL: RetryAfter = %v, want %v", rlErr.RetryAfter, time.Minute)
+	}
+	log.Printf("ok: fail fast: %v", err)
+
+	// Retry: the query waits on the clock and succeeds with the next response
+	clock := resilientbridge.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	stub = &stubGitHub{GitHubAdapter: adapters.NewGitHubAdapter(""), bodies: []string{rateLimited, found}}
+	sdk = resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, stub, &resilientbridge.ProviderConfig{
+		MaxRetries: 3,
+		Clock:      clock,
+	})
+	var data struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- github.GraphQL(sdk, viewerQuery, nil, &data)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Minute)
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Fatalf("FAIL: retried query: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		log.Fatalf("FAIL: query still blocked after advancing the clock")
+	}
+	if data.Viewer.Login != "octocat" || stub.calls != 2 {
+		log.Fatalf("FAIL: login %q after %d calls, want \"octocat\" after 2", data.Viewer.Login, stub.calls)
+	}
+	log.Printf("ok: retried after RATE_LIMITED, login %q", data.Viewer.Login)
+
+	// Other GraphQL errors are not rate limits
+	stub = &stubGitHub{GitHubAdapter: adapters.NewGitHubAdapter(""), bodies: []string{notFound}}
+	sdk = resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, stub, &resilientbridge.ProviderConfig{MaxRetries: 3})
+	err = github.GraphQL(sdk, viewerQuery, nil, nil)
+	var gqlErrs github.GraphQLErrors
+	if !errors.As(err, &gqlErrs) || stub.calls != 1 {
+		log.Fatalf("FAIL: NOT_FOUND: got %v after %d calls, want GraphQLErrors after 1", err, stub.calls)
+	}
+
+	log.Println("PASS: GraphQL RATE_LIMITED bodies are rate limit errors")
+}
@@ -0,0 +1,87 @@
+// list_pull_requests.go
+//
+// Checks github.ListPullRequests against a stubbed transport serving two pages of pull requests linked
+// with rel="next". The state, base, sort, and direction options must reach the query string, both pages
+// must be followed, and the typed fields (number, state, refs, user, created and merged dates) must be
+// decoded. With Max below the page size, per_page must shrink and the second page must not be fetched.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+const pull = `{"number":%d,"state":"closed","title":"Change %d","user":{"login":"octocat"},` +
+	`"base":{"ref":"main","sha":"aaa"},"head":{"ref":"feature-%d","sha":"bbb"},` +
+	`"created_at":"2024-05-01T10:00:00Z","merged_at":%s}`
+
+// pages serves /repos/acme/widgets/pulls: page 1 holds #1 and #2 and links to page 2, which holds #3.
+type pages struct {
+	queries []string
+}
+
+func (p *pages) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.queries = append(p.queries, req.URL.RawQuery)
+	rec := httptest.NewRecorder()
+	if req.URL.Path != "/repos/acme/widgets/pulls" {
+		rec.WriteHeader(404)
+		rec.WriteString(`{"message":"Not Found"}`)
+		return rec.Result(), nil
+	}
+	if req.URL.Query().Get("page") == "" {
+		rec.Header().Set("Link", `<https://api.github.com/repos/acme/widgets/pulls?page=2>; rel="next", <https://api.github.com/repos/acme/widgets/pulls?page=2>; rel="last"`)
+		rec.WriteString("[" + fmt.Sprintf(pull, 1, 1, 1, `"2024-05-02T10:00:00Z"`) + "," + fmt.Sprintf(pull, 2, 2, 2, "null") + "]")
+	} else {
+		rec.WriteString("[" + fmt.Sprintf(pull, 3, 3, 3, "null") + "]")
+	}
+	return rec.Result(), nil
+}
+
+func newSDK(stub *pages) *resilientbridge.ResilientBridge {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub},
+	})
+	return sdk
+}
+
+func main() {
+	stub := &pages{}
+	pulls, err := github.ListPullRequests(newSDK(stub), "acme", "widgets", &github.ListPullRequestsOptions{
+		State: "closed", Base: "main", Sort: "updated", Direction: "asc",
+	})
+	if err != nil || len(pulls) != 3 || len(stub.queries) != 2 {
+		log.Fatalf("FAIL: listed %d pull requests in %d requests (%v), want 3 in 2", len(pulls), len(stub.queries), err)
+	}
+	if want := "base=main&direction=asc&per_page=100&sort=updated&state=closed"; stub.queries[0] != want {
+		log.Fatalf("FAIL: query %q, want %q", stub.queries[0], want)
+	}
+	log.Println("ok: options reach the query and every page is followed")
+
+	first := pulls[0]
+	if first.Number != 1 || first.State != "closed" || first.Title != "Change 1" || first.Base.Ref != "main" || first.Head.Ref != "feature-1" {
+		log.Fatalf("FAIL: first pull request decoded as %+v", first)
+	}
+	if first.User == nil || first.User.Login != "octocat" || first.CreatedAt.IsZero() || first.MergedAt == nil {
+		log.Fatalf("FAIL: user, created, or merged date missing: %+v", first)
+	}
+	if pulls[1].MergedAt != nil || pulls[2].Number != 3 {
+		log.Fatalf("FAIL: later pull requests decoded as %+v, %+v", pulls[1], pulls[2])
+	}
+	log.Println("ok: typed fields are decoded")
+
+	stub = &pages{}
+	pulls, err = github.ListPullRequests(newSDK(stub), "acme", "widgets", &github.ListPullRequestsOptions{Max: 1})
+	if err != nil || len(pulls) != 1 || len(stub.queries) != 1 || stub.queries[0] != "per_page=1" {
+		log.Fatalf("FAIL: Max=1 listed %d pull requests with queries %q (%v)", len(pulls), stub.queries, err)
+	}
+
+	log.Println("PASS: ListPullRequests lists and pages through pull requests")
+}
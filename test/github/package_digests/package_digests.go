@@ -0,0 +1,112 @@
+// package_digests.go
+//
+// Checks github.ResolvePackageVersionDigests against a stub GitHub and ghcr.io. Two versions of
+// acme/app share a digest and must be merged, their tags collected; an untagged version must be
+// addressed by digest; and only the one version whose name is not a digest may reach the registry, with
+// an anonymous pull token rather than the API token.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+var (
+	digestA = "sha256:" + strings.Repeat("a", 64)
+	digestB = "sha256:" + strings.Repeat("b", 64)
+	digestC = "sha256:" + strings.Repeat("c", 64)
+)
+
+type stub struct {
+	mu          sync.Mutex
+	registry    []string // ghcr.io paths requested
+	leakedToken bool
+}
+
+func (s *stub) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	if req.URL.Host == "ghcr.io" {
+		s.mu.Lock()
+		s.registry = append(s.registry, req.URL.Path)
+		if strings.Contains(req.Header.Get("Authorization"), "api-token") {
+			s.leakedToken = true
+		}
+		s.mu.Unlock()
+		switch req.URL.Path {
+		case "/token":
+			rec.WriteString(`{"token":"anon"}`)
+		case "/v2/acme/app/manifests/legacy":
+			if req.Header.Get("Authorization") != "Bearer anon" {
+				rec.WriteHeader(http.StatusUnauthorized)
+				break
+			}
+			rec.Header().Set("Docker-Content-Digest", digestC)
+			rec.WriteString(`{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"size":10},"layers":[{"size":100},{"size":1000}]}`)
+		default:
+			rec.WriteHeader(http.StatusNotFound)
+		}
+		return rec.Result(), nil
+	}
+
+	switch req.URL.Path {
+	case "/orgs/acme/packages/container/app/versions":
+		rec.WriteString(`[
+			{"id":4,"name":"` + digestA + `","metadata":{"container":{"tags":["latest","v2"]}}},
+			{"id":3,"name":"` + digestB + `","metadata":{"container":{"tags":[]}}},
+			{"id":2,"name":"` + digestA + `","metadata":{"container":{"tags":["v2.0"]}}},
+			{"id":1,"name":"legacy","metadata":{"container":{"tags":["v1"]}}}
+		]`)
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	s := &stub{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("api-token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: s},
+	})
+
+	versions, err := github.ResolvePackageVersionDigests(sdk, "acme", "app")
+	if err != nil {
+		log.Fatalf("FAIL: ResolvePackageVersionDigests: %v", err)
+	}
+	if len(versions) != 3 {
+		log.Fatalf("FAIL: got %d images, want 3 (two versions share a digest)", len(versions))
+	}
+
+	a, b, c := versions[0], versions[1], versions[2]
+	if a.Digest != digestA || a.PackageURI != "ghcr.io/acme/app:latest" || strings.Join(a.AdditionalPackageURIs, ",") != "ghcr.io/acme/app:v2,ghcr.io/acme/app:v2.0" {
+		log.Fatalf("FAIL: merged image = %s %s %v", a.Digest, a.PackageURI, a.AdditionalPackageURIs)
+	}
+	if b.Digest != digestB || b.PackageURI != "ghcr.io/acme/app@"+digestB {
+		log.Fatalf("FAIL: untagged image = %s %s, want it addressed by digest", b.Digest, b.PackageURI)
+	}
+	if a.Manifest != nil || b.Manifest != nil {
+		log.Fatal("FAIL: manifests set for versions named by digest")
+	}
+	log.Println("ok: digests and tags from the package API, shared digests merged")
+
+	if c.Digest != digestC || c.TotalSize != 1110 || c.MediaType != "application/vnd.oci.image.manifest.v1+json" {
+		log.Fatalf("FAIL: registry-resolved image = %s, size %d, %q", c.Digest, c.TotalSize, c.MediaType)
+	}
+	if strings.Join(s.registry, ",") != "/token,/v2/acme/app/manifests/legacy" {
+		log.Fatalf("FAIL: registry requests %v, want one token and one manifest", s.registry)
+	}
+	if s.leakedToken {
+		log.Fatal("FAIL: the API token was sent to ghcr.io")
+	}
+
+	log.Println("PASS: package versions resolve to digests, with the registry asked only when needed")
+}
@@ -0,0 +1,70 @@
+// preview_accept.go
+//
+// Checks github.Preview and github.WithAccept: feature names and codenames map to the same preview media
+// type, the Accept header reaching GitHub (a stub transport) carries the preview ahead of the standard
+// media type, and GetRepoDetail asks for the topics preview on its own.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+// stubGitHub records the Accept header of the last request.
+type stubGitHub struct {
+	accept string
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.accept = req.Header.Get("Accept")
+	rec := httptest.NewRecorder()
+	rec.WriteString(`{"name":"airflow","topics":["python"]}`)
+	return rec.Result(), nil
+}
+
+func main() {
+	const mercy = "application/vnd.github.mercy-preview+json"
+	if got := github.Preview("topics"); got != mercy {
+		log.Fatalf("FAIL: Preview(topics) = %q, want %q", got, mercy)
+	}
+	if got := github.Preview("mercy"); got != mercy {
+		log.Fatalf("FAIL: Preview(mercy) = %q, want %q", got, mercy)
+	}
+	if got := github.Preview("luke-cage"); got != "application/vnd.github.luke-cage-preview+json" {
+		log.Fatalf("FAIL: Preview(luke-cage) = %q", got)
+	}
+
+	stub := &stubGitHub{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub},
+	})
+
+	req := github.NewRequest("GET", "/repos/apache/airflow/issues/1/reactions", github.WithAccept(github.Preview("reactions")))
+	if _, err := sdk.Request(github.ProviderName, req); err != nil {
+		log.Fatalf("FAIL: request: %v", err)
+	}
+	if want := "application/vnd.github.squirrel-girl-preview+json, application/vnd.github+json"; stub.accept != want {
+		log.Fatalf("FAIL: Accept = %q, want %q", stub.accept, want)
+	}
+	log.Printf("ok: WithAccept sent %q", stub.accept)
+
+	repo, err := github.GetRepoDetail(sdk, "apache", "airflow")
+	if err != nil {
+		log.Fatalf("FAIL: GetRepoDetail: %v", err)
+	}
+	if want := mercy + ", application/vnd.github+json"; stub.accept != want {
+		log.Fatalf("FAIL: GetRepoDetail Accept = %q, want %q", stub.accept, want)
+	}
+	if len(repo.Topics) != 1 {
+		log.Fatalf("FAIL: topics = %v", repo.Topics)
+	}
+
+	log.Println("PASS: preview media types are resolved and sent in Accept")
+}
@@ -0,0 +1,115 @@
+// repo_active.go
+//
+// Checks github.IsRepoActive against a stub GitHub. Listing the acme organization returns acme/live and
+// the archived acme/old: both must then be answered from the cache without a request (in any letter
+// case). acme/gone is a 404, reported as inactive rather than an error and looked up once; BypassCache
+// must fetch it again. The cache belongs to its SDK: a clone must look acme/gone up for itself, and after
+// github.ClearCaches, or once RepositoryStatusTTL has passed, the SDK must fetch it again too.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+type stubGitHub struct {
+	mu   sync.Mutex
+	hits map[string]int // request path -> count
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.hits[req.URL.Path]++
+	s.mu.Unlock()
+
+	rec := httptest.NewRecorder()
+	switch req.URL.Path {
+	case "/orgs/acme/repos":
+		rec.WriteString(`[{"name":"live","full_name":"acme/live"},{"name":"old","full_name":"acme/old","archived":true}]`)
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	}
+	return rec.Result(), nil
+}
+
+func (s *stubGitHub) total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, c := range s.hits {
+		n += c
+	}
+	return n
+}
+
+func main() {
+	stub := &stubGitHub{hits: map[string]int{}}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub},
+	})
+
+	if _, err := github.ListOrgRepos(sdk, "acme", github.ListReposOptions{}); err != nil {
+		log.Fatalf("FAIL: ListOrgRepos: %v", err)
+	}
+	listed := stub.total()
+	for repo, want := range map[string]bool{"live": true, "old": false, "Live": true} {
+		active, err := github.IsRepoActive(sdk, "acme", repo)
+		if err != nil || active != want {
+			log.Fatalf("FAIL: IsRepoActive(acme/%s) = %v, %v; want %v", repo, active, err, want)
+		}
+	}
+	if n := stub.total() - listed; n != 0 {
+		log.Fatalf("FAIL: %d requests for repositories already listed, want 0", n)
+	}
+	log.Println("ok: listed repositories answered from the cache")
+
+	for i := 0; i < 2; i++ {
+		active, err := github.IsRepoActive(sdk, "acme", "gone")
+		if err != nil || active {
+			log.Fatalf("FAIL: IsRepoActive(acme/gone) = %v, %v; want false, nil", active, err)
+		}
+	}
+	if n := stub.hits["/repos/acme/gone"]; n != 1 {
+		log.Fatalf("FAIL: acme/gone fetched %d times, want 1", n)
+	}
+	if _, err := github.IsRepoActive(sdk, "acme", "gone", github.BypassCache()); err != nil {
+		log.Fatalf("FAIL: IsRepoActive with BypassCache: %v", err)
+	}
+	if n := stub.hits["/repos/acme/gone"]; n != 2 {
+		log.Fatalf("FAIL: acme/gone fetched %d times after BypassCache, want 2", n)
+	}
+
+	fetches := func(sdk *resilientbridge.ResilientBridge, want int, step string) {
+		if _, err := github.IsRepoActive(sdk, "acme", "gone"); err != nil {
+			log.Fatalf("FAIL (%s): IsRepoActive: %v", step, err)
+		}
+		if n := stub.hits["/repos/acme/gone"]; n != want {
+			log.Fatalf("FAIL (%s): acme/gone fetched %d times, want %d", step, n, want)
+		}
+		log.Printf("ok (%s): acme/gone fetched %d times", step, want)
+	}
+	clone := sdk.Clone()
+	fetches(clone, 3, "clone has its own cache")
+	fetches(clone, 3, "clone cached it")
+	fetches(sdk, 3, "original still cached")
+	github.ClearCaches(sdk)
+	fetches(sdk, 4, "ClearCaches")
+	fetches(clone, 4, "ClearCaches left the clone alone")
+
+	github.RepositoryStatusTTL = 20 * time.Millisecond
+	fetches(sdk, 4, "short TTL, still fresh")
+	time.Sleep(30 * time.Millisecond)
+	fetches(sdk, 5, "expired")
+
+	log.Println("PASS: repository status is cached per SDK from listings, 404s are inactive, BypassCache, ClearCaches and expiry refetch")
+}
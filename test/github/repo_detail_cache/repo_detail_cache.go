@@ -0,0 +1,106 @@
+// repo_detail_cache.go
+//
+// Checks that commit enrichment through a github.RepoDetailCache fetches the repository once. 25 commits
+// of acme/app are enriched by 8 goroutines sharing one cache, each asking for its commit's Repository; the
+// stub GitHub answers /repos/acme/app slowly, so lookups overlap the first one. Exactly one repository
+// request must be made, every commit must get its ID, and a failed lookup must not be cached.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+type stubGitHub struct {
+	mu        sync.Mutex
+	repoHits  map[string]int
+	failFirst bool
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	switch path := strings.ToLower(req.URL.Path); {
+	case path == "/repos/acme/app" || path == "/repos/acme/flaky":
+		s.mu.Lock()
+		s.repoHits[path]++
+		fail := path == "/repos/acme/flaky" && s.repoHits[path] == 1
+		s.mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		if fail {
+			rec.WriteHeader(http.StatusForbidden)
+			rec.WriteString(`{"message":"Repository access blocked"}`)
+			break
+		}
+		name := strings.TrimPrefix(path, "/repos/acme/")
+		fmt.Fprintf(rec, `{"id":42,"node_id":"R_42","name":%q,"full_name":"acme/%s","owner":{"login":"acme"}}`, name, name)
+	case strings.HasPrefix(path, "/repos/acme/app/commits/"):
+		fmt.Fprintf(rec, `{"sha":%q}`, strings.TrimPrefix(path, "/repos/acme/app/commits/"))
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	stub := &stubGitHub{repoHits: map[string]int{}}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub},
+	})
+
+	repos := github.NewRepoDetailCache(sdk)
+	shas := make(chan string)
+	errs := make(chan error, 25)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sha := range shas {
+				commit, err := repos.GetCommitDetail("acme", "app", sha)
+				if err == nil {
+					var repo *github.Repository
+					if repo, err = commit.Repository(); err == nil && (repo.ID != 42 || commit.SHA != sha) {
+						err = fmt.Errorf("commit %s placed in repository %d", commit.SHA, repo.ID)
+					}
+				}
+				errs <- err
+			}
+		}()
+	}
+	for i := 0; i < 25; i++ {
+		shas <- fmt.Sprintf("%040x", i)
+	}
+	close(shas)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			log.Fatalf("FAIL: %v", err)
+		}
+	}
+	if n := stub.repoHits["/repos/acme/app"]; n != 1 {
+		log.Fatalf("FAIL: repository fetched %d times for 25 commits, want 1", n)
+	}
+	log.Println("ok: 25 commits, 1 repository request")
+
+	if _, err := repos.Get("acme", "flaky"); err == nil {
+		log.Fatalf("FAIL: first flaky lookup succeeded")
+	}
+	if repo, err := repos.Get("ACME", "Flaky"); err != nil || repo.Name != "flaky" || stub.repoHits["/repos/acme/flaky"] != 2 {
+		log.Fatalf("FAIL: retried lookup: %v after %d requests", err, stub.repoHits["/repos/acme/flaky"])
+	}
+
+	log.Println("PASS: a RepoDetailCache fetches each repository once per session")
+}
@@ -0,0 +1,97 @@
+// request_accounting.go
+//
+// Checks that the GitHub adapter's local windows count exactly the requests that reached GitHub. A stub
+// transport behind the adapter fails the first round trip at the network level, answers the next with a
+// 500 and everything after with 200. The failed round trip must be released, the 500 and 200s kept, and
+// once the window is full the adapter's synthetic 429 (reported at once under RateLimitFailFast) must
+// neither reach the transport nor be recorded.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// transport fails its first round trip, answers the second with 500 and the rest with 200.
+type transport struct {
+	calls     int
+	responses int
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	if t.calls == 1 {
+		return nil, errors.New("connection reset by peer")
+	}
+	t.responses++
+	rec := httptest.NewRecorder()
+	if t.calls == 2 {
+		rec.WriteHeader(http.StatusInternalServerError)
+	}
+	rec.WriteString(`{}`)
+	return rec.Result(), nil
+}
+
+func main() {
+	stub := &transport{}
+	adapter := adapters.NewGitHubAdapter("token")
+	maxRequests := 3
+
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapter, &resilientbridge.ProviderConfig{
+		MaxRetries:          2,
+		BaseBackoff:         resilientbridge.NoBackoff,
+		MaxRequestsOverride: &maxRequests,
+		RateLimitBehavior:   resilientbridge.RateLimitFailFast,
+		HTTPClient:          &http.Client{Transport: stub},
+	})
+
+	req := &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/acme/widgets"}
+	resp, err := sdk.Request("github", req)
+	if err != nil || resp.StatusCode != 200 {
+		log.Fatalf("FAIL: first request: status %v, err %v", status(resp), err)
+	}
+	check(adapter, stub, 2, "network error, 500, 200")
+
+	resp, err = sdk.Request("github", req)
+	if err != nil || resp.StatusCode != 200 {
+		log.Fatalf("FAIL: second request: status %v, err %v", status(resp), err)
+	}
+	check(adapter, stub, 3, "200 fills the window")
+
+	calls := stub.calls
+	resp, err = sdk.Request("github", req)
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: request past the full window: expected a *RateLimitError, got status %v, err %v", status(resp), err)
+	}
+	if stub.calls != calls {
+		log.Fatalf("FAIL: synthetic 429 reached the transport: %d round trips, want %d", stub.calls, calls)
+	}
+	check(adapter, stub, 3, "synthetic 429")
+
+	log.Println("PASS: GitHub windows count only requests that reached GitHub")
+}
+
+// check fails unless the REST window has recorded exactly the round trips that got a response, and
+// that this is want.
+func check(adapter *adapters.GitHubAdapter, stub *transport, want int, step string) {
+	used := adapter.RateLimitWindows()["rest"].Used
+	if used != stub.responses || used != want {
+		log.Fatalf("FAIL (%s): window recorded %d requests, transport answered %d, want %d", step, used, stub.responses, want)
+	}
+	log.Printf("ok (%s): %d recorded, %d answered", step, used, stub.responses)
+}
+
+func status(resp *resilientbridge.NormalizedResponse) interface{} {
+	if resp == nil {
+		return nil
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,101 @@
+// retry_config.go
+//
+// Checks ProviderConfig.Retry against the GitHub adapter and a stub transport:
+//   - an attempt that hangs past RequestTimeout is retried, then a 502 is retried with jittered backoff,
+//     and the third attempt succeeds, although the individual MaxRetries field is 0;
+//   - cancelling the caller's context aborts a hanging attempt well before its RequestTimeout;
+//   - MaxRetryElapsed ends a run of 503s with a *RetryBudgetError before MaxRetries is reached.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// stubGitHub answers request n (from 1) with statuses[n-1]; 0 hangs until the request is cancelled.
+// Requests past the end of statuses get the last status.
+type stubGitHub struct {
+	sent     int32
+	statuses []int
+}
+
+func (s *stubGitHub) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := int(atomic.AddInt32(&s.sent, 1))
+	if n > len(s.statuses) {
+		n = len(s.statuses)
+	}
+	status := s.statuses[n-1]
+	if status == 0 {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(status)
+	rec.WriteString(`{"id":1}`)
+	return rec.Result(), nil
+}
+
+func newSDK(stub *stubGitHub, retry *resilientbridge.RetryConfig) *resilientbridge.ResilientBridge {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		MaxRetries: 0, // ignored: Retry is set
+		Retry:      retry,
+		HTTPClient: &http.Client{Transport: stub},
+	})
+	return sdk
+}
+
+func main() {
+	req := &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/apache/airflow"}
+
+	retry := resilientbridge.DefaultRetryConfig()
+	retry.BaseBackoff = 10 * time.Millisecond
+	retry.MaxBackoff = 50 * time.Millisecond
+	retry.RequestTimeout = 100 * time.Millisecond
+	stub := &stubGitHub{statuses: []int{0, 502, 200}}
+	start := time.Now()
+	if _, err := newSDK(stub, retry).Request("github", req); err != nil {
+		log.Fatalf("FAIL: expected the third attempt to succeed, got %v", err)
+	}
+	if stub.sent != 3 {
+		log.Fatalf("FAIL: stub saw %d attempts, want 3", stub.sent)
+	}
+	log.Printf("ok: timed-out attempt and 502 retried, succeeded after %v", time.Since(start).Round(time.Millisecond))
+
+	retry = resilientbridge.DefaultRetryConfig()
+	retry.RequestTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err := newSDK(&stubGitHub{statuses: []int{0}}, retry).RequestWithContext(ctx, "github", req)
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, resilientbridge.ErrRequestTimeout) {
+		log.Fatalf("FAIL: expected the caller's deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		log.Fatalf("FAIL: cancellation took %v", elapsed)
+	}
+	log.Printf("ok: caller's context cancelled the request: %v", err)
+
+	retry = resilientbridge.DefaultRetryConfig()
+	retry.MaxRetries = 100
+	retry.BaseBackoff = 100 * time.Millisecond
+	retry.MaxBackoff = 100 * time.Millisecond
+	retry.MaxRetryElapsed = 250 * time.Millisecond
+	stub = &stubGitHub{statuses: []int{503}}
+	_, err = newSDK(stub, retry).Request("github", req)
+	if !errors.Is(err, resilientbridge.ErrRetryBudgetExceeded) {
+		log.Fatalf("FAIL: expected ErrRetryBudgetExceeded, got %v", err)
+	}
+	log.Printf("ok: gave up after %d attempts: %v", stub.sent, err)
+
+	log.Println("PASS: RetryConfig applies per-attempt timeouts, jittered backoff, budget, and cancellation")
+}
@@ -0,0 +1,97 @@
+// secondary_limit.go
+//
+// Checks the GitHub adapter's handling of secondary rate limit 403s that carry no Retry-After header.
+// A stub in front of the adapter answers every request with such a 403 (quota still remaining), and
+// RateLimitFailFast makes the SDK report the wait it would have taken instead of sleeping. The wait
+// must be GitHub's documented minute (or SecondaryLimitBackoff when larger), not exponential backoff;
+// a 403 with X-RateLimit-Remaining: 0 must keep waiting for the primary reset instead. A permission-denied
+// 403 ("Resource not accessible by integration") with quota remaining is not a rate limit: it must fail
+// at once with an *HTTPError, without being retried.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// secondaryLimitBody is GitHub's message for a secondary rate limit 403.
+var secondaryLimitBody = []byte(`{"message":"You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`)
+
+// stubGitHub answers every request with a fixed 403, counting calls; everything else is the real adapter.
+type stubGitHub struct {
+	*adapters.GitHubAdapter
+	headers map[string]string
+	data    []byte
+	calls   int
+}
+
+func (s *stubGitHub) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	s.calls++
+	return &resilientbridge.NormalizedResponse{StatusCode: 403, Headers: s.headers, Data: s.data}, nil
+}
+
+func (s *stubGitHub) ExecuteRequestWithContext(ctx context.Context, req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	return s.ExecuteRequest(req)
+}
+
+func main() {
+	headerless := map[string]string{"x-ratelimit-remaining": "4321"}
+	check("default", adapters.NewGitHubAdapter(""), headerless, time.Minute)
+
+	custom := adapters.NewGitHubAdapter("")
+	custom.SecondaryLimitBackoff = 2 * time.Minute
+	check("SecondaryLimitBackoff=2m", custom, headerless, 2*time.Minute)
+
+	tooShort := adapters.NewGitHubAdapter("")
+	tooShort.SecondaryLimitBackoff = 5 * time.Second
+	check("SecondaryLimitBackoff=5s", tooShort, headerless, time.Minute)
+
+	primary := adapters.NewGitHubAdapter("")
+	if wait := primary.RateLimitWait(&resilientbridge.NormalizedResponse{StatusCode: 403, Headers: map[string]string{"x-ratelimit-remaining": "0"}}); wait != 0 {
+		log.Fatalf("FAIL: primary limit 403 should leave the wait to X-RateLimit-Reset, got %v", wait)
+	}
+
+	denied := &stubGitHub{
+		GitHubAdapter: adapters.NewGitHubAdapter(""),
+		headers:       headerless,
+		data:          []byte(`{"message":"Resource not accessible by integration","documentation_url":"https://docs.github.com/rest"}`),
+	}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", denied, &resilientbridge.ProviderConfig{MaxRetries: 3})
+	start := time.Now()
+	_, err := sdk.Request("github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/apache/airflow/actions/secrets"})
+	var httpErr *resilientbridge.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 403 {
+		log.Fatalf("FAIL (permission denied): expected a 403 *HTTPError, got %v", err)
+	}
+	if denied.calls != 1 || time.Since(start) > time.Second {
+		log.Fatalf("FAIL (permission denied): %d calls in %v, want 1 and no wait", denied.calls, time.Since(start))
+	}
+	log.Printf("ok (permission denied): %v", err)
+
+	log.Println("PASS: header-less secondary limits wait at least a minute and other 403s fail at once")
+}
+
+func check(name string, adapter *adapters.GitHubAdapter, headers map[string]string, want time.Duration) {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", &stubGitHub{GitHubAdapter: adapter, headers: headers, data: secondaryLimitBody}, &resilientbridge.ProviderConfig{
+		MaxRetries:        3,
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+	})
+
+	_, err := sdk.Request("github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/apache/airflow"})
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL (%s): expected a *RateLimitError, got %v", name, err)
+	}
+	if rlErr.RetryAfter != want {
+		log.Fatalf("FAIL (%s): RetryAfter = %v, want %v", name, rlErr.RetryAfter, want)
+	}
+	log.Printf("ok (%s): %v", name, err)
+}
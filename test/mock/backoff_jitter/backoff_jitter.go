@@ -0,0 +1,90 @@
+// backoff_jitter.go
+//
+// Checks that the default exponential backoff spreads retries. 200 goroutines hit a mock adapter that
+// always fails with a network error, so every request retries at the same moment. The waits reported to
+// OnRetry for the first retry must be spread over [0, BaseBackoff] (full jitter) rather than clustered,
+// and no wait may exceed MaxBackoff.
+
+package main
+
+import (
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+const (
+	goroutines  = 200
+	baseBackoff = 20 * time.Millisecond
+	maxBackoff  = 40 * time.Millisecond
+	buckets     = 10
+)
+
+func main() {
+	var mu sync.Mutex
+	waitsByAttempt := map[int][]time.Duration{}
+
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", &mock.MockAdapter{RandomErrorChance: 1}, &resilientbridge.ProviderConfig{
+		MaxRetries:  3,
+		BaseBackoff: baseBackoff,
+		MaxBackoff:  maxBackoff,
+		OnRetry: func(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse, attempt int, wait time.Duration) {
+			mu.Lock()
+			waitsByAttempt[attempt] = append(waitsByAttempt[attempt], wait)
+			mu.Unlock()
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"})
+		}()
+	}
+	wg.Wait()
+
+	for attempt, waits := range waitsByAttempt {
+		for _, w := range waits {
+			if w < 0 || w > maxBackoff {
+				log.Fatalf("FAIL: retry %d waited %v, outside [0, %v]", attempt, w, maxBackoff)
+			}
+		}
+	}
+
+	// First retries are drawn from [0, baseBackoff]: every tenth of the range should get a fair share,
+	// and the spread should be close to that of a uniform distribution (range/sqrt(12)).
+	first := waitsByAttempt[1]
+	if len(first) != goroutines {
+		log.Fatalf("FAIL: expected %d first retries, got %d", goroutines, len(first))
+	}
+	counts := make([]int, buckets)
+	var sum, sumSq float64
+	for _, w := range first {
+		b := int(float64(w) / float64(baseBackoff) * buckets)
+		if b == buckets {
+			b--
+		}
+		counts[b]++
+		sum += float64(w)
+		sumSq += float64(w) * float64(w)
+	}
+	for b, c := range counts {
+		if c < goroutines/buckets/4 {
+			log.Fatalf("FAIL: bucket %d of the first retry waits holds only %d of %d waits: %v", b, c, goroutines, counts)
+		}
+	}
+	mean := sum / float64(len(first))
+	stddev := math.Sqrt(sumSq/float64(len(first)) - mean*mean)
+	uniform := float64(baseBackoff) / math.Sqrt(12)
+	if stddev < uniform*0.7 {
+		log.Fatalf("FAIL: first retry waits too clustered: stddev %v, expected about %v", time.Duration(stddev), time.Duration(uniform))
+	}
+	log.Printf("PASS: first retry waits spread over %v: buckets %v, stddev %v (uniform %v)", baseBackoff, counts, time.Duration(stddev), time.Duration(uniform))
+}
@@ -0,0 +1,98 @@
+// cancel_backoff.go
+//
+// Checks that cancelling a request's context aborts the wait between retries. An adapter answering 500
+// (or 429 with "Retry-After: 60") makes the request back off for seconds; the context is cancelled 20ms
+// into the first wait. RequestWithContext must return context.Canceled within a few milliseconds of the
+// cancellation, for both the exponential backoff and the Retry-After wait, without another attempt.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// failing answers every request with status and headers.
+type failing struct {
+	status  int
+	headers map[string]string
+	calls   int
+}
+
+func (f *failing) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	f.calls++
+	return &resilientbridge.NormalizedResponse{StatusCode: f.status, Headers: f.headers, Data: []byte(`{"message":"try later"}`)}, nil
+}
+
+func (f *failing) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (f *failing) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (f *failing) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (f *failing) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// cancelMidWait sends a request to adapter and cancels it 20ms into the first wait before a retry,
+// returning the error, the time from cancellation to return, and the first wait.
+func cancelMidWait(adapter *failing) (error, time.Duration, time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var once sync.Once
+	var mu sync.Mutex
+	var cancelledAt time.Time
+	var firstWait time.Duration
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", adapter, &resilientbridge.ProviderConfig{
+		MaxRetries:  10,
+		BaseBackoff: 5 * time.Second,
+		OnRetry: func(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse, attempt int, wait time.Duration) {
+			once.Do(func() {
+				firstWait = wait
+				time.AfterFunc(20*time.Millisecond, func() {
+					mu.Lock()
+					cancelledAt = time.Now()
+					mu.Unlock()
+					cancel()
+				})
+			})
+		},
+	})
+	_, err := sdk.RequestWithContext(ctx, "mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"})
+	mu.Lock()
+	defer mu.Unlock()
+	if cancelledAt.IsZero() {
+		return err, 0, firstWait
+	}
+	return err, time.Since(cancelledAt), firstWait
+}
+
+func main() {
+	backoff := &failing{status: 500, headers: map[string]string{}}
+	err, sinceCancel, wait := cancelMidWait(backoff)
+	if !errors.Is(err, context.Canceled) || sinceCancel > 50*time.Millisecond {
+		log.Fatalf("FAIL: backoff wait: returned %v %v after cancellation, want context.Canceled within milliseconds", err, sinceCancel)
+	}
+	log.Printf("ok: backoff wait of %v aborted %v after cancellation", wait.Round(time.Millisecond), sinceCancel.Round(time.Millisecond))
+
+	retryAfter := &failing{status: 429, headers: map[string]string{"retry-after": "60"}}
+	err, sinceCancel, wait = cancelMidWait(retryAfter)
+	if !errors.Is(err, context.Canceled) || sinceCancel > 50*time.Millisecond || retryAfter.calls != 1 {
+		log.Fatalf("FAIL: Retry-After wait of %v: returned %v %v after cancellation and %d calls, want context.Canceled within milliseconds after 1", wait, err, sinceCancel, retryAfter.calls)
+	}
+	log.Printf("ok: Retry-After wait of %v aborted %v after cancellation", wait.Round(time.Second), sinceCancel.Round(time.Millisecond))
+
+	log.Println("PASS: cancelling the context aborts backoff and Retry-After waits")
+}
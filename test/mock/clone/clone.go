@@ -0,0 +1,63 @@
+// clone.go
+//
+// Checks sdk.Clone against the mock adapter. The original SDK allows 2 requests per minute to /items
+// through an EndpointLimit and counts requests in OnRequest. A clone gets its own config (fail fast on
+// rate limits, a separate OnRequest counter) but must share the original's windows: after one request
+// through each, a third through the clone must be refused, and the original's config must be unchanged.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"regexp"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+func main() {
+	var originalSent, cloneSent int
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", &mock.MockAdapter{}, &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		MaxRetries:        3,
+		RateLimitBehavior: resilientbridge.RateLimitBlock,
+		EndpointLimits: []resilientbridge.EndpointLimit{
+			{Pattern: regexp.MustCompile(`^/items`), Max: 2, WindowSecs: 60},
+		},
+		OnRequest: func(*resilientbridge.NormalizedRequest) { originalSent++ },
+	})
+
+	clone := sdk.Clone()
+	config := clone.GetProviderConfig("mock")
+	config.MaxRetries = 0
+	config.RateLimitBehavior = resilientbridge.RateLimitFailFast
+	config.OnRequest = func(*resilientbridge.NormalizedRequest) { cloneSent++ }
+	if err := clone.SetProviderConfig("mock", config); err != nil {
+		log.Fatalf("FAIL: SetProviderConfig: %v", err)
+	}
+
+	req := &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"}
+	if _, err := sdk.Request("mock", req); err != nil {
+		log.Fatalf("FAIL: original request: %v", err)
+	}
+	if _, err := clone.Request("mock", req); err != nil {
+		log.Fatalf("FAIL: first clone request: %v", err)
+	}
+	_, err := clone.Request("mock", req)
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: third request should hit the shared endpoint window and fail fast, got %v", err)
+	}
+	log.Printf("ok: clone refused at the shared window: %v", err)
+
+	if originalSent != 1 || cloneSent != 1 {
+		log.Fatalf("FAIL: OnRequest counts original=%d clone=%d, want 1 and 1", originalSent, cloneSent)
+	}
+	if original := sdk.GetProviderConfig("mock"); original.MaxRetries != 3 || original.RateLimitBehavior != resilientbridge.RateLimitBlock {
+		log.Fatalf("FAIL: the clone's config change leaked into the original: %+v", original)
+	}
+
+	log.Println("PASS: clones have their own config and share rate limit windows")
+}
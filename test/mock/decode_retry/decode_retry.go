@@ -0,0 +1,98 @@
+// decode_retry.go
+//
+// Checks ProviderConfig.RetryOnDecodeError against an adapter answering with queued bodies. A truncated
+// 200 followed by a valid one must be retried by RequestJSON and decoded; an empty 200 is retried too,
+// while an empty 202 is accepted as is. Without the option, and for the raw Request, the truncated body
+// must come back after a single attempt. A body that stays truncated must fail with ErrMalformedBody
+// once MaxRetries is used up.
+
+package main
+
+import (
+	"errors"
+	"log"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// bodies answers with the queued responses in turn, repeating the last one.
+type bodies struct {
+	status []int
+	data   []string
+	calls  int
+}
+
+func (b *bodies) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	i := b.calls
+	if i >= len(b.data) {
+		i = len(b.data) - 1
+	}
+	b.calls++
+	return &resilientbridge.NormalizedResponse{StatusCode: b.status[i], Headers: map[string]string{}, Data: []byte(b.data[i])}, nil
+}
+
+func (b *bodies) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (b *bodies) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (b *bodies) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (b *bodies) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+type item struct {
+	Name string `json:"name"`
+}
+
+// run sends GET /item through RequestJSON to an adapter answering with the given responses.
+func run(retryOnDecode bool, status []int, data ...string) (*bodies, item, error) {
+	adapter := &bodies{status: status, data: data}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", adapter, &resilientbridge.ProviderConfig{
+		MaxRetries:         2,
+		BaseBackoff:        resilientbridge.NoBackoff,
+		RetryOnDecodeError: retryOnDecode,
+	})
+	var out item
+	_, err := sdk.RequestJSON("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/item"}, &out)
+	return adapter, out, err
+}
+
+func main() {
+	adapter, out, err := run(true, []int{200, 200}, `{"name":"wid`, `{"name":"widget"}`)
+	if err != nil || out.Name != "widget" || adapter.calls != 2 {
+		log.Fatalf("FAIL: truncated then valid: %+v after %d calls (%v)", out, adapter.calls, err)
+	}
+	adapter, out, err = run(true, []int{200, 200}, ``, `{"name":"widget"}`)
+	if err != nil || out.Name != "widget" || adapter.calls != 2 {
+		log.Fatalf("FAIL: empty then valid: %+v after %d calls (%v)", out, adapter.calls, err)
+	}
+	if adapter, _, err = run(true, []int{202}, ``); err != nil || adapter.calls != 1 {
+		log.Fatalf("FAIL: empty 202 took %d calls (%v), want 1 and no error", adapter.calls, err)
+	}
+	log.Println("ok: truncated and empty 200s are retried")
+
+	if adapter, _, err = run(false, []int{200, 200}, `{"name":"wid`, `{"name":"widget"}`); err == nil || adapter.calls != 1 {
+		log.Fatalf("FAIL: without RetryOnDecodeError: %d calls (%v), want 1 and a decode error", adapter.calls, err)
+	}
+	raw := &bodies{status: []int{200, 200}, data: []string{`{"name":"wid`, `{"name":"widget"}`}}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", raw, &resilientbridge.ProviderConfig{MaxRetries: 2, RetryOnDecodeError: true})
+	if resp, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/item"}); err != nil || raw.calls != 1 || string(resp.Data) != `{"name":"wid` {
+		log.Fatalf("FAIL: raw Request took %d calls (%v), want the truncated body after 1", raw.calls, err)
+	}
+	log.Println("ok: only RequestJSON with the option retries")
+
+	adapter, _, err = run(true, []int{200}, `[1, 2`)
+	if !errors.Is(err, resilientbridge.ErrMalformedBody) || adapter.calls != 3 {
+		log.Fatalf("FAIL: persistently truncated body: %d calls (%v), want 3 and ErrMalformedBody", adapter.calls, err)
+	}
+
+	log.Println("PASS: RetryOnDecodeError retries empty and truncated JSON bodies")
+}
@@ -0,0 +1,96 @@
+// default_backoff.go
+//
+// Checks the backoff used when BaseBackoff is left at zero. An adapter answers 429 without Retry-After,
+// then 200. With the zero value, the retry must wait on the provider's clock for a non-zero duration of at
+// most DefaultBaseBackoff (the request stays blocked on a FakeClock until it is advanced). With NoBackoff,
+// the retry must be sent immediately, without waiting on the clock at all.
+
+package main
+
+import (
+	"log"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// limited answers 429 to the first request and 200 afterwards.
+type limited struct {
+	calls int
+}
+
+func (l *limited) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	l.calls++
+	if l.calls == 1 {
+		return &resilientbridge.NormalizedResponse{StatusCode: 429, Headers: map[string]string{}, Data: []byte(`{}`)}, nil
+	}
+	return &resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}, Data: []byte(`{}`)}, nil
+}
+
+func (l *limited) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (l *limited) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (l *limited) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (l *limited) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// send starts GET /items against a 429-then-200 adapter on a FakeClock, returning the adapter, the clock,
+// the wait reported to OnRetry, and a channel delivering the request's error.
+func send(backoff time.Duration) (*limited, *resilientbridge.FakeClock, *time.Duration, <-chan error) {
+	adapter := &limited{}
+	clock := resilientbridge.NewFakeClock(time.Now())
+	wait := new(time.Duration)
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", adapter, &resilientbridge.ProviderConfig{
+		MaxRetries:  1,
+		BaseBackoff: backoff,
+		Clock:       clock,
+		OnRetry: func(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse, attempt int, d time.Duration) {
+			*wait = d
+		},
+	})
+	done := make(chan error, 1)
+	go func() {
+		_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"})
+		done <- err
+	}()
+	return adapter, clock, wait, done
+}
+
+func main() {
+	adapter, clock, wait, done := send(0)
+	clock.BlockUntil(1)
+	if *wait <= 0 || *wait > resilientbridge.DefaultBaseBackoff {
+		log.Fatalf("FAIL: default backoff waits %v, want (0, %v]", *wait, resilientbridge.DefaultBaseBackoff)
+	}
+	select {
+	case err := <-done:
+		log.Fatalf("FAIL: request finished before the backoff elapsed (%v)", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	clock.Advance(resilientbridge.DefaultBaseBackoff)
+	if err := <-done; err != nil || adapter.calls != 2 {
+		log.Fatalf("FAIL: default backoff: %d calls (%v), want 2 and success", adapter.calls, err)
+	}
+	log.Printf("ok: BaseBackoff 0 waited %v before retrying the 429", wait.Round(time.Millisecond))
+
+	adapter, clock, wait, done = send(resilientbridge.NoBackoff)
+	select {
+	case err := <-done:
+		if err != nil || adapter.calls != 2 || *wait != 0 || clock.Waiters() != 0 {
+			log.Fatalf("FAIL: NoBackoff: %d calls, wait %v (%v), want 2 immediate calls", adapter.calls, *wait, err)
+		}
+	case <-time.After(time.Second):
+		log.Fatalf("FAIL: NoBackoff retry is waiting (%d waiters on the clock)", clock.Waiters())
+	}
+
+	log.Println("PASS: BaseBackoff 0 backs off by default and NoBackoff retries immediately")
+}
@@ -0,0 +1,86 @@
+// default_headers.go
+//
+// Checks the precedence of ProviderConfig.DefaultHeaders: a header set on the request beats the same
+// default (whatever its case), defaults fill in every other header, and an Authorization default beats
+// the adapter's own token, which is only applied when no Authorization header is present at all.
+
+package main
+
+import (
+	"log"
+	"net/http"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+// headerAdapter records the headers it would send, applying its token the way real adapters do.
+type headerAdapter struct {
+	mock.MockAdapter
+	token string
+	sent  http.Header
+}
+
+func (a *headerAdapter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	a.sent = http.Header{}
+	for k, v := range req.Headers {
+		a.sent.Set(k, v)
+	}
+	if a.sent.Get("Authorization") == "" {
+		a.sent.Set("Authorization", "Bearer "+a.token)
+	}
+	return &resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}, Data: []byte(`{}`)}, nil
+}
+
+func main() {
+	sdk := resilientbridge.NewResilientBridge()
+
+	adapter := &headerAdapter{token: "adapter-token"}
+	sdk.RegisterProvider("plain", adapter, &resilientbridge.ProviderConfig{
+		DefaultHeaders: map[string]string{
+			"Accept":     "application/vnd.github+json",
+			"user-agent": "resilient-bridge-test",
+		},
+	})
+
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "/items",
+		Headers:  map[string]string{"accept": "application/vnd.github.v3+json"},
+	}
+	if _, err := sdk.Request("plain", req); err != nil {
+		log.Fatalf("FAIL: request failed: %v", err)
+	}
+	expect(adapter.sent, "Accept", "application/vnd.github.v3+json") // request beats default
+	expect(adapter.sent, "User-Agent", "resilient-bridge-test")      // default fills the gap
+	expect(adapter.sent, "Authorization", "Bearer adapter-token")    // adapter token when unset
+	if len(req.Headers) != 1 {
+		log.Fatalf("FAIL: the caller's request was modified: %v", req.Headers)
+	}
+
+	authAdapter := &headerAdapter{token: "adapter-token"}
+	sdk.RegisterProvider("auth", authAdapter, &resilientbridge.ProviderConfig{
+		DefaultHeaders: map[string]string{"Authorization": "Bearer default-token"},
+	})
+	if _, err := sdk.Request("auth", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"}); err != nil {
+		log.Fatalf("FAIL: request failed: %v", err)
+	}
+	expect(authAdapter.sent, "Authorization", "Bearer default-token") // default beats adapter token
+
+	if _, err := sdk.Request("auth", &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "/items",
+		Headers:  map[string]string{"Authorization": "Bearer request-token"},
+	}); err != nil {
+		log.Fatalf("FAIL: request failed: %v", err)
+	}
+	expect(authAdapter.sent, "Authorization", "Bearer request-token") // request beats default
+
+	log.Println("PASS: request headers > DefaultHeaders > adapter token")
+}
+
+func expect(h http.Header, key, want string) {
+	if got := h.Get(key); got != want {
+		log.Fatalf("FAIL: %s = %q, want %q", key, got, want)
+	}
+}
@@ -0,0 +1,90 @@
+// endpoint_limit_keys.go
+//
+// Checks that EndpointLimits windows follow their rule rather than its position in the list. An SDK with
+// limits for /search and /actions uses up the /search window and exports its rate limit state; a second
+// SDK configured with the same limits in the opposite order, plus a new one in front, imports it. /search
+// must still be refused there and /actions still allowed. A limit with Method "POST" must count only POSTs:
+// GETs to its endpoint stay unlimited while the second POST is refused.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"regexp"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+var (
+	search  = resilientbridge.EndpointLimit{Pattern: regexp.MustCompile(`^/search`), Max: 2, WindowSecs: 3600}
+	actions = resilientbridge.EndpointLimit{Pattern: regexp.MustCompile(`^/actions`), Max: 2, WindowSecs: 3600}
+	issues  = resilientbridge.EndpointLimit{Pattern: regexp.MustCompile(`^/issues`), Method: "post", Max: 1, WindowSecs: 3600}
+)
+
+func newSDK(limits ...resilientbridge.EndpointLimit) *resilientbridge.ResilientBridge {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", &mock.MockAdapter{}, &resilientbridge.ProviderConfig{
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+		EndpointLimits:    limits,
+	})
+	return sdk
+}
+
+// allowed sends one request and reports whether it got through, failing on anything but a *RateLimitError.
+func allowed(sdk *resilientbridge.ResilientBridge, method, endpoint string) bool {
+	_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: method, Endpoint: endpoint})
+	if err == nil {
+		return true
+	}
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: %s %s: expected a *RateLimitError, got %v", method, endpoint, err)
+	}
+	return false
+}
+
+func main() {
+	first := newSDK(search, actions)
+	for i := 1; i <= 2; i++ {
+		if !allowed(first, "GET", "/search/items") {
+			log.Fatalf("FAIL: /search request %d refused", i)
+		}
+	}
+	if allowed(first, "GET", "/search/items") {
+		log.Fatalf("FAIL: third /search request allowed with Max 2")
+	}
+	state, err := first.ExportRateLimitState()
+	if err != nil {
+		log.Fatalf("FAIL: export: %v", err)
+	}
+	log.Println("ok: /search window used up and exported")
+
+	second := newSDK(issues, actions, search)
+	if err := second.ImportRateLimitState(state); err != nil {
+		log.Fatalf("FAIL: import: %v", err)
+	}
+	if allowed(second, "GET", "/search/items") {
+		log.Fatalf("FAIL: /search allowed after importing a full window under reordered limits")
+	}
+	if !allowed(second, "GET", "/actions/runs") {
+		log.Fatalf("FAIL: /actions refused after importing the /search window under reordered limits")
+	}
+	log.Println("ok: reordered limits keep each window with its rule")
+
+	for i := 1; i <= 3; i++ {
+		if !allowed(second, "GET", "/issues/1") {
+			log.Fatalf("FAIL: GET /issues request %d refused by a POST-only limit", i)
+		}
+	}
+	if !allowed(second, "POST", "/issues") {
+		log.Fatalf("FAIL: first POST /issues refused")
+	}
+	if allowed(second, "POST", "/issues") {
+		log.Fatalf("FAIL: second POST /issues allowed with Max 1")
+	}
+	log.Println("ok: a Method limit counts only requests with that method")
+
+	log.Println("PASS: EndpointLimits windows are keyed by method and pattern")
+}
@@ -0,0 +1,69 @@
+// endpoint_limit_unsent.go
+//
+// Checks that an EndpointLimits slot is only used by requests that are sent. A provider allows 2 requests
+// to /search per hour and paces all requests through a token bucket of one token per minute, failing fast.
+// After the first request, two more are refused by the empty bucket; they must give their endpoint slots
+// back, so once the bucket refills a second request to /search still gets through. A third one, a minute
+// later again, must then be refused by the endpoint limit.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+func main() {
+	clock := resilientbridge.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	sent := 0
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", &mock.MockAdapter{Clock: clock}, &resilientbridge.ProviderConfig{
+		Clock:              clock,
+		OnRequest:          func(*resilientbridge.NormalizedRequest) { sent++ },
+		RateLimitBehavior:  resilientbridge.RateLimitFailFast,
+		RateLimitAlgorithm: resilientbridge.LimiterTokenBucket,
+		TokenBucketRate:    1.0 / 60,
+		TokenBucketBurst:   1,
+		EndpointLimits: []resilientbridge.EndpointLimit{
+			{Pattern: regexp.MustCompile(`^/search`), Max: 2, WindowSecs: 3600},
+		},
+	})
+
+	search := func() error {
+		_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/search/items"})
+		return err
+	}
+	refused := func(step string) {
+		var rlErr *resilientbridge.RateLimitError
+		if err := search(); !errors.As(err, &rlErr) {
+			log.Fatalf("FAIL (%s): expected a *RateLimitError, got %v", step, err)
+		}
+	}
+
+	if err := search(); err != nil {
+		log.Fatalf("FAIL: first request: %v", err)
+	}
+	refused("empty bucket")
+	refused("empty bucket again")
+	log.Println("ok: the empty token bucket refuses requests")
+
+	clock.Advance(time.Minute)
+	if err := search(); err != nil {
+		log.Fatalf("FAIL: second request after the bucket refilled: %v", err)
+	}
+	log.Println("ok: requests refused by the bucket left their endpoint slots free")
+
+	clock.Advance(time.Minute)
+	refused("endpoint limit")
+	if sent != 2 {
+		log.Fatalf("FAIL: %d requests sent, want 2", sent)
+	}
+	log.Println("ok: the endpoint limit still holds at 2")
+
+	log.Println("PASS: EndpointLimits count only requests that are sent")
+}
@@ -0,0 +1,46 @@
+// error_preview.go
+//
+// Checks that error body previews are cut on a character boundary. A non-JSON body of 199 ASCII bytes
+// followed by "é" characters (two bytes each) puts the 200-byte limit in the middle of the first "é":
+// ExtractErrorMessage must return the ASCII part and "..." as valid UTF-8, and a NormalizedResponse.JSON
+// error must quote the same preview without a stray byte. A body within the limit is returned whole.
+
+package main
+
+import (
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+func main() {
+	ascii := strings.Repeat("x", 199)
+	body := ascii + strings.Repeat("é", 50)
+
+	got := resilientbridge.ExtractErrorMessage([]byte(body))
+	if !utf8.ValidString(got) || got != ascii+"..." {
+		log.Fatalf("FAIL: ExtractErrorMessage = %q, want the 199 ASCII bytes and \"...\"", got)
+	}
+	log.Println("ok: ExtractErrorMessage backs up to the start of the split character")
+
+	resp := &resilientbridge.NormalizedResponse{StatusCode: 502, Data: []byte(body)}
+	var out map[string]any
+	err := resp.JSON(&out)
+	if err == nil {
+		log.Fatalf("FAIL: JSON decoded a non-JSON body")
+	}
+	if msg := err.Error(); !strings.Contains(msg, `"`+ascii+`..."`) || strings.Contains(msg, `\x`) {
+		log.Fatalf("FAIL: JSON error %q does not quote the cut preview", msg)
+	}
+	log.Println("ok: JSON errors quote the same preview")
+
+	short := "bad gateway: é"
+	if got := resilientbridge.ExtractErrorMessage([]byte(short)); got != short {
+		log.Fatalf("FAIL: ExtractErrorMessage = %q, want %q", got, short)
+	}
+	log.Println("ok: short bodies are returned whole")
+
+	log.Println("PASS: error previews never split a character")
+}
@@ -0,0 +1,64 @@
+// fake_clock.go
+//
+// Checks ProviderConfig.Clock with a FakeClock and the mock adapter, the way a downstream test of crawl
+// logic would use it: with a window of 2 requests per 60 seconds, the third request must wait on the
+// clock (not on real time), stay blocked until the clock is advanced past the window, and then succeed
+// at once, with the mock's window started over.
+
+package main
+
+import (
+	"log"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+func main() {
+	clock := resilientbridge.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	window := int64(60)
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", &mock.MockAdapter{RequestsUntilRateLimit: 2, Clock: clock}, &resilientbridge.ProviderConfig{
+		MaxRequestsOverride: resilientbridge.IntPtr(2),
+		WindowSecsOverride:  &window,
+		MaxRetries:          1,
+		Clock:               clock,
+	})
+	req := &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"}
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := sdk.Request("mock", req); err != nil {
+			log.Fatalf("FAIL: request %d: %v", i+1, err)
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := sdk.Request("mock", req)
+		done <- err
+	}()
+	clock.BlockUntil(1)
+	select {
+	case err := <-done:
+		log.Fatalf("FAIL: third request returned (%v) before the window was over", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	log.Printf("ok: third request waits on the clock, %d waiter(s)", clock.Waiters())
+
+	clock.Advance(61 * time.Second)
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Fatalf("FAIL: third request after advancing: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		log.Fatalf("FAIL: third request still blocked after advancing the clock")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		log.Fatalf("FAIL: took %v of real time", elapsed)
+	}
+
+	log.Printf("PASS: the window reset on the fake clock after %v of real time", time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,84 @@
+// has_more.go
+//
+// Checks PaginateOptions.HasMoreExtractor against an adapter whose pages always carry a rel="next" Link
+// header, so only the body's flag can end the listing: NotLastPage must stop at the page with
+// isLastPage=true, MoreFlag at the first more=false, HasAdditional at once when the flag is missing, and
+// without an extractor pagination must keep following the links (until MaxPages).
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// pages answers /items?page=N with a body built by body(N) and a Link to page N+1.
+type pages struct {
+	body func(page int) string
+}
+
+func (p *pages) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	u, err := url.Parse(req.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	page, _ := strconv.Atoi(u.Query().Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	return &resilientbridge.NormalizedResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{"link": fmt.Sprintf(`</items?page=%d>; rel="next"`, page+1)},
+		Data:       []byte(p.body(page)),
+	}, nil
+}
+
+func (p *pages) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (p *pages) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (p *pages) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (p *pages) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+func main() {
+	check("NotLastPage", func(page int) string { return fmt.Sprintf(`{"values":[],"isLastPage":%t}`, page == 3) },
+		&resilientbridge.PaginateOptions{HasMoreExtractor: resilientbridge.NotLastPage}, 3)
+	check("MoreFlag", func(page int) string { return fmt.Sprintf(`{"incidents":[],"more":%t}`, page < 2) },
+		&resilientbridge.PaginateOptions{HasMoreExtractor: resilientbridge.MoreFlag}, 2)
+	check("HasAdditional (missing)", func(page int) string { return `{"repositories":[]}` },
+		&resilientbridge.PaginateOptions{HasMoreExtractor: resilientbridge.HasAdditional}, 1)
+	check("nested path", func(page int) string { return fmt.Sprintf(`{"meta":{"has_more":%t}}`, page < 4) },
+		&resilientbridge.PaginateOptions{HasMoreExtractor: resilientbridge.JSONHasMore("meta", "has_more")}, 4)
+	check("no extractor", func(page int) string { return `{"more":false}` },
+		&resilientbridge.PaginateOptions{MaxPages: 5}, 5)
+
+	log.Println("PASS: body flags end pagination regardless of Link headers")
+}
+
+func check(name string, body func(page int) string, opts *resilientbridge.PaginateOptions, want int) {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("pages", &pages{body: body}, &resilientbridge.ProviderConfig{})
+
+	seen := 0
+	err := sdk.Paginate(context.Background(), "pages", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items?page=1"}, opts, func(resp *resilientbridge.NormalizedResponse) error {
+		seen++
+		return nil
+	})
+	if err != nil || seen != want {
+		log.Fatalf("FAIL (%s): %d pages, %v; want %d", name, seen, err, want)
+	}
+	log.Printf("ok (%s): %d pages", name, seen)
+}
@@ -0,0 +1,90 @@
+// link_header.go
+//
+// Checks the RFC 8288 Link header parser. ParseLinkHeader must keep commas and semicolons inside target
+// URIs and quoted values, unescape quoted pairs, and read unquoted and multi-valued rel parameters;
+// NormalizedResponse.Link must match relation types case-insensitively. github.ParseLastPage must find a
+// rel="last" listed after other relations in the same entry. Finally, Paginate must follow a rel="next"
+// sent in the second of two Link headers of a real round trip.
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+const header = `<https://example.com/a,b;c>; title="one, two; \"three\""; rel="Prev First", ` +
+	`<https://example.com/items?page=3&per_page=1>;rel=next ; rel="ignored", ` +
+	`garbage; rel="broken", <https://example.com/items?page=9&per_page=1>; type="text/html"; rel="prev last"`
+
+// twoHeaders answers page 1 with its links split over two Link headers, and page 2 without links.
+type twoHeaders struct {
+	calls int
+}
+
+func (t *twoHeaders) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	rec := httptest.NewRecorder()
+	if req.URL.Query().Get("page") == "" {
+		rec.Header().Add("Link", `<https://api.github.com/items?page=2>; rel="prev"; title="a, b"`)
+		rec.Header().Add("Link", `<https://api.github.com/items?page=2>; rel="next"`)
+	}
+	rec.WriteString(`[]`)
+	return rec.Result(), nil
+}
+
+func main() {
+	links := resilientbridge.ParseLinkHeader(header)
+	if len(links) != 3 {
+		log.Fatalf("FAIL: parsed %d links, want 3: %+v", len(links), links)
+	}
+	if links[0].URL != "https://example.com/a,b;c" || links[0].Params["title"] != `one, two; "three"` {
+		log.Fatalf("FAIL: first link %+v", links[0])
+	}
+	if !links[0].HasRel("first") || !links[0].HasRel("PREV") || links[1].Params["rel"] != "next" {
+		log.Fatalf("FAIL: relation types %v and %q", links[0].Rel, links[1].Params["rel"])
+	}
+	log.Println("ok: quoted values, escapes, and multi-valued rel parameters")
+
+	resp := &resilientbridge.NormalizedResponse{Headers: map[string]string{"link": header}}
+	if next, ok := resp.Link("Next"); !ok || next != "https://example.com/items?page=3&per_page=1" {
+		log.Fatalf("FAIL: Link(\"Next\") = %q, %v", next, ok)
+	}
+	if _, ok := resp.Link("broken"); ok {
+		log.Fatalf("FAIL: an entry without a target URI was matched")
+	}
+	if last, err := github.ParseLastPage(header); err != nil || last != 9 {
+		log.Fatalf("FAIL: ParseLastPage = %d, %v, want 9", last, err)
+	}
+	if pages, err := resilientbridge.LinkPageCount(resp); err != nil || pages != 9 {
+		log.Fatalf("FAIL: LinkPageCount = %d, %v, want 9", pages, err)
+	}
+	log.Println("ok: lookups by relation type")
+
+	stub := &twoHeaders{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient: &http.Client{Transport: stub},
+	})
+	var seen []string
+	err := sdk.Paginate(context.Background(), "github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"}, nil,
+		func(page *resilientbridge.NormalizedResponse) error {
+			seen = append(seen, page.Headers["link"])
+			return nil
+		})
+	if err != nil || stub.calls != 2 {
+		log.Fatalf("FAIL: paginated %d pages (%v), want 2", stub.calls, err)
+	}
+	if !strings.Contains(seen[0], `rel="prev"`) || !strings.Contains(seen[0], `rel="next"`) {
+		log.Fatalf("FAIL: Link headers not joined: %q", seen[0])
+	}
+
+	log.Println("PASS: Link headers are parsed per RFC 8288")
+}
@@ -0,0 +1,98 @@
+// ndjson.go
+//
+// Checks sdk.StreamNDJSON against an adapter serving three pages linked by rel="next" headers. Array
+// bodies (pretty-printed over several lines) must come out as one compact item per line, in page order;
+// with ItemsPath the items must be taken from {"data": [...]}, a page without the field contributing
+// none; and each page must be written before the next one is requested.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// pages answers /items?page=N (N = 1..3) with body(N), linking to the next page.
+type pages struct {
+	body    func(page int) string
+	written func() int // Lines on the output so far, recorded per request
+	seen    []int
+}
+
+func (p *pages) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	u, err := url.Parse(req.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	page, _ := strconv.Atoi(u.Query().Get("page"))
+	p.seen = append(p.seen, p.written())
+	headers := map[string]string{}
+	if page < 3 {
+		headers["link"] = fmt.Sprintf(`</items?page=%d>; rel="next"`, page+1)
+	}
+	return &resilientbridge.NormalizedResponse{StatusCode: 200, Headers: headers, Data: []byte(p.body(page))}, nil
+}
+
+func (p *pages) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (p *pages) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (p *pages) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (p *pages) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+func main() {
+	arrays := func(page int) string {
+		return fmt.Sprintf("[\n  {\"id\": %d,\n   \"name\": \"a b\"},\n  {\"id\": %d}\n]", 2*page-1, 2*page)
+	}
+	out := stream(arrays, nil)
+	want := `{"id":1,"name":"a b"}` + "\n" + `{"id":2}` + "\n" + `{"id":3,"name":"a b"}` + "\n" + `{"id":4}` + "\n" + `{"id":5,"name":"a b"}` + "\n" + `{"id":6}` + "\n"
+	if out != want {
+		log.Fatalf("FAIL: array pages written as\n%s\nwant\n%s", out, want)
+	}
+	log.Printf("ok: %d lines from array pages", strings.Count(out, "\n"))
+
+	objects := func(page int) string {
+		if page == 2 {
+			return `{"meta":{}}`
+		}
+		return fmt.Sprintf(`{"data":[{"id":%d}],"meta":{"page":%d}}`, page, page)
+	}
+	out = stream(objects, &resilientbridge.PaginateOptions{ItemsPath: []string{"data"}})
+	if out != "{\"id\":1}\n{\"id\":3}\n" {
+		log.Fatalf("FAIL: ItemsPath pages written as %q", out)
+	}
+
+	log.Println("PASS: StreamNDJSON writes every item as one line, page by page")
+}
+
+// stream runs StreamNDJSON over the pages and returns the output, checking that the lines of each page
+// were written before the next page was requested.
+func stream(body func(page int) string, opts *resilientbridge.PaginateOptions) string {
+	var out strings.Builder
+	p := &pages{body: body, written: func() int { return strings.Count(out.String(), "\n") }}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("pages", p, &resilientbridge.ProviderConfig{})
+
+	n, err := sdk.StreamNDJSON(context.Background(), &out, "pages", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items?page=1"}, opts)
+	if err != nil || n != strings.Count(out.String(), "\n") {
+		log.Fatalf("FAIL: StreamNDJSON returned %d, %v for %d lines", n, err, strings.Count(out.String(), "\n"))
+	}
+	if len(p.seen) != 3 || p.seen[1] == 0 || p.seen[2] < p.seen[1] {
+		log.Fatalf("FAIL: lines written before each request: %v", p.seen)
+	}
+	return out.String()
+}
@@ -0,0 +1,105 @@
+// request_validation.go
+//
+// Checks that malformed NormalizedRequests are rejected before reaching the adapter. An empty, unknown, or
+// lower-case method, an empty endpoint, one without a leading "/" (including a URL missing its scheme), an
+// absolute URL without a host, and a nil request must each fail with a *RequestError matching
+// ErrInvalidRequest and naming the problem, without a call to the adapter; RequestStream must reject them
+// the same way. Well-formed paths and absolute URLs must still be sent, and an absolute URL to an adapter
+// that reports it cannot send one must match both ErrInvalidRequest and ErrUnsupportedCapability.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// counter answers every request with 200 and counts them.
+type counter struct {
+	calls int
+}
+
+func (c *counter) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	c.calls++
+	return &resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}, Data: []byte(`{}`)}, nil
+}
+
+func (c *counter) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (c *counter) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (c *counter) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (c *counter) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+func main() {
+	adapter := &counter{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", adapter, &resilientbridge.ProviderConfig{MaxRetries: 1})
+
+	invalid := []struct {
+		method, endpoint, reason string
+	}{
+		{"", "/items", "method is empty"},
+		{"FETCH", "/items", "unknown HTTP method"},
+		{"get", "/items", "use GET"},
+		{"GET", "", "endpoint is empty"},
+		{"GET", "items", `must start with "/"`},
+		{"GET", "api.github.com/repos/acme/widgets", `must start with "/"`},
+		{"GET", "ftp://example.com/file", "only http and https"},
+		{"GET", "https:///items", "has no host"},
+		{"GET", "/items/%zz", "invalid endpoint"},
+		{"GET", "/items\n", "invalid endpoint"},
+	}
+	for _, c := range invalid {
+		_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: c.method, Endpoint: c.endpoint})
+		var reqErr *resilientbridge.RequestError
+		if !errors.As(err, &reqErr) || !errors.Is(err, resilientbridge.ErrInvalidRequest) {
+			log.Fatalf("FAIL: %q %q returned %v, want a *RequestError", c.method, c.endpoint, err)
+		}
+		if !strings.Contains(reqErr.Reason, c.reason) || reqErr.Provider != "mock" || reqErr.Endpoint != c.endpoint {
+			log.Fatalf("FAIL: %q %q rejected with %+v, want reason containing %q", c.method, c.endpoint, reqErr, c.reason)
+		}
+	}
+	if _, err := sdk.Request("mock", nil); !errors.Is(err, resilientbridge.ErrInvalidRequest) {
+		log.Fatalf("FAIL: nil request returned %v", err)
+	}
+	if adapter.calls != 0 {
+		log.Fatalf("FAIL: the adapter was called %d times for malformed requests", adapter.calls)
+	}
+	log.Println("ok: malformed requests fail before reaching the adapter")
+
+	for _, endpoint := range []string{"/items?page=2", "https://uploads.example.com/items"} {
+		if _, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: endpoint}); err != nil {
+			log.Fatalf("FAIL: GET %s: %v", endpoint, err)
+		}
+	}
+	if adapter.calls != 2 {
+		log.Fatalf("FAIL: %d well-formed requests reached the adapter, want 2", adapter.calls)
+	}
+	log.Println("ok: paths and absolute URLs are sent")
+
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{})
+	if _, err := sdk.RequestStream(context.Background(), "github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "repos"}); !errors.Is(err, resilientbridge.ErrInvalidRequest) {
+		log.Fatalf("FAIL: RequestStream returned %v for an endpoint without a leading slash", err)
+	}
+	sdk.RegisterProvider("jira", adapters.NewJiraAdapter("https://acme.atlassian.net", "me@acme.example", "token"), &resilientbridge.ProviderConfig{})
+	_, err := sdk.Request("jira", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "https://example.com/rest/api/3/myself"})
+	if !errors.Is(err, resilientbridge.ErrInvalidRequest) || !errors.Is(err, resilientbridge.ErrUnsupportedCapability) {
+		log.Fatalf("FAIL: absolute URL to Jira returned %v, want ErrInvalidRequest and ErrUnsupportedCapability", err)
+	}
+
+	log.Println("PASS: NormalizedRequests are validated before they are sent")
+}
@@ -0,0 +1,147 @@
+// response_validator.go
+//
+// Checks ProviderConfig.ResponseValidator against an adapter answering with queued 200s. With SlackOK, an
+// {"ok": false, "error": "internal_error"} must be retried until {"ok": true} is accepted, invalid_auth
+// must fail after a single call, and a persistent internal_error must fail once MaxRetries is used up.
+// With CloudflareSuccess, "success": false must fail at once with ErrUnsuccessfulResponse and the
+// provider's message. A validator returning Retryable with an After wait must have the wait honored.
+// RequestStream must not run the validator on its unbuffered bodies, and must return the stream intact.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// bodies answers 200 with the queued bodies in turn, repeating the last one.
+type bodies struct {
+	data  []string
+	calls int
+}
+
+func (b *bodies) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	i := b.calls
+	if i >= len(b.data) {
+		i = len(b.data) - 1
+	}
+	b.calls++
+	return &resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}, Data: []byte(b.data[i])}, nil
+}
+
+func (b *bodies) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (b *bodies) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (b *bodies) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (b *bodies) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+// run sends GET /item to an adapter answering with the given bodies, validated by validator.
+func run(validator resilientbridge.ResponseValidator, data ...string) (*bodies, *resilientbridge.NormalizedResponse, error) {
+	adapter := &bodies{data: data}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", adapter, &resilientbridge.ProviderConfig{
+		MaxRetries:        2,
+		BaseBackoff:       resilientbridge.NoBackoff,
+		ResponseValidator: validator,
+	})
+	resp, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/item"})
+	return adapter, resp, err
+}
+
+func main() {
+	adapter, resp, err := run(resilientbridge.SlackOK, `{"ok":false,"error":"internal_error"}`, `{"ok":true,"channel":"C1"}`)
+	if err != nil || adapter.calls != 2 || !strings.Contains(string(resp.Data), "C1") {
+		log.Fatalf("FAIL: internal_error then ok took %d calls (%v), want 2 and no error", adapter.calls, err)
+	}
+	log.Println("ok: a transient Slack error is retried")
+
+	adapter, resp, err = run(resilientbridge.SlackOK, `{"ok":false,"error":"invalid_auth"}`, `{"ok":true}`)
+	if !errors.Is(err, resilientbridge.ErrUnsuccessfulResponse) || adapter.calls != 1 || resp == nil {
+		log.Fatalf("FAIL: invalid_auth took %d calls (%v), want 1 and ErrUnsuccessfulResponse with the response", adapter.calls, err)
+	}
+	if !strings.Contains(err.Error(), "invalid_auth") {
+		log.Fatalf("FAIL: error %q lacks the Slack error code", err)
+	}
+	adapter, _, err = run(resilientbridge.SlackOK, `{"ok":false,"error":"internal_error"}`)
+	if !errors.Is(err, resilientbridge.ErrUnsuccessfulResponse) || adapter.calls != 3 {
+		log.Fatalf("FAIL: persistent internal_error took %d calls (%v), want 3", adapter.calls, err)
+	}
+	var retryable *resilientbridge.RetryableError
+	if errors.As(err, &retryable) {
+		log.Fatalf("FAIL: the final error is still marked retryable: %v", err)
+	}
+	log.Println("ok: terminal and exhausted Slack errors fail")
+
+	adapter, _, err = run(resilientbridge.CloudflareSuccess,
+		`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"result":null}`, `{"success":true}`)
+	if !errors.Is(err, resilientbridge.ErrUnsuccessfulResponse) || adapter.calls != 1 || !strings.Contains(err.Error(), "Authentication error") {
+		log.Fatalf("FAIL: Cloudflare failure took %d calls (%v), want 1 and the provider's message", adapter.calls, err)
+	}
+	if adapter, _, err = run(resilientbridge.CloudflareSuccess, `{"result":[]}`); err != nil || adapter.calls != 1 {
+		log.Fatalf("FAIL: a body without the flag took %d calls (%v), want it accepted", adapter.calls, err)
+	}
+	log.Println("ok: CloudflareSuccess fails on success=false")
+
+	wait := &resilientbridge.RetryableError{Err: errors.New("not ready"), After: 50 * time.Millisecond}
+	pending := func(resp *resilientbridge.NormalizedResponse) error {
+		if strings.Contains(string(resp.Data), "pending") {
+			return wait
+		}
+		return nil
+	}
+	start := time.Now()
+	adapter, _, err = run(pending, `{"state":"pending"}`, `{"state":"done"}`)
+	if err != nil || adapter.calls != 2 || time.Since(start) < wait.After {
+		log.Fatalf("FAIL: pending then done took %d calls in %v (%v), want 2 after %v", adapter.calls, time.Since(start), err, wait.After)
+	}
+
+	log.Println("ok: Retryable waits are honored")
+
+	validated := 0
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		MaxRetries: 2,
+		HTTPClient: &http.Client{Transport: roundTripper(func(req *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteString("log line\n")
+			return rec.Result(), nil
+		})},
+		ResponseValidator: func(resp *resilientbridge.NormalizedResponse) error {
+			validated++
+			return errors.New("always rejected")
+		},
+	})
+	s, err := sdk.RequestStream(context.Background(), "github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/acme/widgets/actions/runs/1/logs"})
+	if err != nil || validated != 0 {
+		log.Fatalf("FAIL: RequestStream ran the validator %d times (%v), want none", validated, err)
+	}
+	data, _ := io.ReadAll(s.Body)
+	s.Body.Close()
+	if string(data) != "log line\n" {
+		log.Fatalf("FAIL: stream reads %q", data)
+	}
+
+	log.Println("PASS: ResponseValidator retries or fails 2xx responses by their body")
+}
+
+type roundTripper func(req *http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
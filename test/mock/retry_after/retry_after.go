@@ -0,0 +1,99 @@
+// retry_after.go
+//
+// Checks Retry-After parsing. ParseRetryAfter must turn delta-seconds and HTTP-dates into waits relative
+// to the given time, yield 0 for dates in the past and negative seconds, and reject anything else. An
+// adapter answering 429 with "Retry-After: 3600", then 200, checks the MaxRetryAfter cap: the retry must
+// wait the cap (plus at most 10% jitter) on the provider's clock, not the hour the header asked for.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// limited answers 429 with Retry-After: 3600 to the first request and 200 afterwards.
+type limited struct {
+	calls int
+}
+
+func (l *limited) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	l.calls++
+	if l.calls == 1 {
+		return &resilientbridge.NormalizedResponse{StatusCode: 429, Headers: map[string]string{"retry-after": "3600"}, Data: []byte(`{}`)}, nil
+	}
+	return &resilientbridge.NormalizedResponse{StatusCode: 200, Headers: map[string]string{}, Data: []byte(`{}`)}, nil
+}
+
+func (l *limited) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (l *limited) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (l *limited) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (l *limited) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+func main() {
+	now := time.Date(2015, time.October, 21, 7, 28, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"-5", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{"Wednesday, 21-Oct-15 07:30:00 GMT", 2 * time.Minute, true},
+		{now.Add(-time.Hour).Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"1.5", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := resilientbridge.ParseRetryAfter(c.value, now)
+		if got != c.want || ok != c.ok {
+			log.Fatalf("FAIL: ParseRetryAfter(%q) = %v, %v, want %v, %v", c.value, got, ok, c.want, c.ok)
+		}
+		log.Printf("ok: ParseRetryAfter(%q) = %v, %v", c.value, got, ok)
+	}
+
+	adapter := &limited{}
+	clock := resilientbridge.NewFakeClock(now)
+	var wait time.Duration
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", adapter, &resilientbridge.ProviderConfig{
+		MaxRetries:    1,
+		MaxRetryAfter: 2 * time.Second,
+		Clock:         clock,
+		OnRetry: func(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse, attempt int, d time.Duration) {
+			wait = d
+		},
+	})
+	done := make(chan error, 1)
+	go func() {
+		_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"})
+		done <- err
+	}()
+	clock.BlockUntil(1)
+	if wait < 2*time.Second || wait > 2200*time.Millisecond {
+		log.Fatalf("FAIL: Retry-After 3600 with MaxRetryAfter 2s waits %v, want 2s plus at most 10%% jitter", wait)
+	}
+	clock.Advance(2200 * time.Millisecond)
+	if err := <-done; err != nil || adapter.calls != 2 {
+		log.Fatalf("FAIL: capped retry: %d calls (%v), want 2 and success", adapter.calls, err)
+	}
+	log.Printf("ok: MaxRetryAfter capped Retry-After 3600 to %v", wait.Round(time.Millisecond))
+
+	log.Println("PASS: Retry-After seconds, dates and the MaxRetryAfter cap")
+}
@@ -0,0 +1,45 @@
+// retry_budget.go
+//
+// Checks ProviderConfig.MaxRetryElapsed against the mock adapter: every request is answered with a 429
+// asking to retry in 30 seconds, while the retry budget is 2 seconds. The request must give up at once
+// with a *RetryBudgetError wrapping a *RateLimitError, instead of waiting out MaxRetries Retry-Afters.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+func main() {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.SetDebug(true)
+
+	sdk.RegisterProvider("mock", &mock.MockAdapter{
+		ShouldReturn429Always: true,
+		RetryAfterSecs:        30,
+	}, &resilientbridge.ProviderConfig{
+		MaxRetries:      5,
+		MaxRetryElapsed: 2 * time.Second,
+	})
+
+	start := time.Now()
+	_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/items"})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, resilientbridge.ErrRetryBudgetExceeded) {
+		log.Fatalf("FAIL: expected ErrRetryBudgetExceeded, got %v", err)
+	}
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: expected the budget error to wrap a *RateLimitError, got %v", err)
+	}
+	if elapsed > time.Second {
+		log.Fatalf("FAIL: request took %v, expected it to return before the 30s Retry-After", elapsed)
+	}
+	log.Printf("PASS: gave up after %v: %v", elapsed.Round(time.Millisecond), err)
+}
@@ -0,0 +1,137 @@
+// retry_classifier.go
+//
+// Checks ProviderConfig.RetryableErrorClassifier against a scripted adapter that answers each request
+// with the next response (or network error) of its script. The classifier retries bodies carrying a
+// GCP-style "backendError" reason (even a POST's 503, which RetryPolicy would not resend), fails 200s
+// carrying GraphQL errors, accepts 404s, and leaves the rest to the built-in handling. It must never be
+// consulted for a 429, which stays a rate limit.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+type step struct {
+	status int
+	body   string
+	err    error
+}
+
+// scripted answers requests with the steps of its script, in order.
+type scripted struct {
+	mu     sync.Mutex
+	script []step
+	calls  int
+}
+
+func (s *scripted) ExecuteRequest(req *resilientbridge.NormalizedRequest) (*resilientbridge.NormalizedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.script[s.calls]
+	s.calls++
+	if st.err != nil {
+		return nil, st.err
+	}
+	return &resilientbridge.NormalizedResponse{StatusCode: st.status, Headers: map[string]string{"retry-after": "0"}, Data: []byte(st.body)}, nil
+}
+
+func (s *scripted) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return nil, nil
+}
+
+func (s *scripted) IsRateLimitError(resp *resilientbridge.NormalizedResponse) bool {
+	return resp.StatusCode == 429
+}
+
+func (s *scripted) SetRateLimitDefaultsForType(requestType string, maxRequests int, windowSecs int64) {
+}
+
+func (s *scripted) IdentifyRequestType(req *resilientbridge.NormalizedRequest) string {
+	return "rest"
+}
+
+var errReset = errors.New("connection reset by peer")
+
+func main() {
+	consulted := map[int]int{} // status (0 = network error) -> classifier calls
+	classifier := func(resp *resilientbridge.NormalizedResponse, err error) resilientbridge.RetryDecision {
+		if resp == nil {
+			consulted[0]++
+			return resilientbridge.Retry{After: time.Millisecond}
+		}
+		consulted[resp.StatusCode]++
+		switch {
+		case bytes.Contains(resp.Data, []byte(`"reason":"backendError"`)):
+			return resilientbridge.Retry{After: time.Millisecond}
+		case resp.StatusCode == 200 && bytes.Contains(resp.Data, []byte(`"errors"`)):
+			return resilientbridge.Fail{}
+		case resp.StatusCode == 404:
+			return resilientbridge.Success{}
+		}
+		return nil
+	}
+
+	run := func(name, method string, script ...step) (*resilientbridge.NormalizedResponse, *scripted, []time.Duration, error) {
+		adapter := &scripted{script: script}
+		var waits []time.Duration
+		sdk := resilientbridge.NewResilientBridge()
+		sdk.RegisterProvider("scripted", adapter, &resilientbridge.ProviderConfig{
+			MaxRetries:               3,
+			BaseBackoff:              resilientbridge.NoBackoff,
+			RetryableErrorClassifier: classifier,
+			OnRetry: func(req *resilientbridge.NormalizedRequest, resp *resilientbridge.NormalizedResponse, attempt int, wait time.Duration) {
+				waits = append(waits, wait)
+			},
+		})
+		resp, err := sdk.Request("scripted", &resilientbridge.NormalizedRequest{Method: method, Endpoint: "/" + name})
+		return resp, adapter, waits, err
+	}
+
+	backendError := `{"error":{"errors":[{"reason":"backendError"}]}}`
+	resp, adapter, waits, err := run("retry", "GET", step{status: 400, body: backendError}, step{status: 200, body: `{}`})
+	if err != nil || resp.StatusCode != 200 || adapter.calls != 2 || len(waits) != 1 || waits[0] != time.Millisecond {
+		log.Fatalf("FAIL: Retry: %v after %d calls, waits %v; want success after 2 calls, one 1ms wait", err, adapter.calls, waits)
+	}
+	_, adapter, _, err = run("retry-post", "POST", step{status: 503, body: backendError}, step{status: 201, body: `{}`})
+	if err != nil || adapter.calls != 2 {
+		log.Fatalf("FAIL: Retry of a POST: %v after %d calls, want success after 2", err, adapter.calls)
+	}
+	_, adapter, _, err = run("retry-network", "GET", step{err: errReset}, step{status: 200, body: `{}`})
+	if err != nil || adapter.calls != 2 {
+		log.Fatalf("FAIL: Retry after a network error: %v after %d calls, want success after 2", err, adapter.calls)
+	}
+	_, adapter, _, err = run("retry-exhausted", "GET", step{status: 400, body: backendError}, step{status: 400, body: backendError}, step{status: 400, body: backendError}, step{status: 400, body: backendError})
+	var httpErr *resilientbridge.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 400 || adapter.calls != 4 {
+		log.Fatalf("FAIL: Retry past MaxRetries: %v after %d calls, want a 400 *HTTPError after 4", err, adapter.calls)
+	}
+	log.Println("ok: Retry resends responses and network errors up to MaxRetries")
+
+	_, _, _, err = run("fail", "POST", step{status: 200, body: `{"data":null,"errors":[{"type":"INTERNAL"}]}`})
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 200 {
+		log.Fatalf("FAIL: Fail on a 200: got %v, want a 200 *HTTPError", err)
+	}
+	resp, _, _, err = run("success", "GET", step{status: 404, body: `{"message":"Not Found"}`})
+	if err != nil || resp.StatusCode != 404 {
+		log.Fatalf("FAIL: Success on a 404: got %v, want the 404 response without error", err)
+	}
+	_, adapter, _, err = run("builtin", "GET", step{status: 400, body: `{"message":"Bad Request"}`})
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 400 || adapter.calls != 1 {
+		log.Fatalf("FAIL: nil decision on a 400: %v after %d calls, want a 400 *HTTPError after 1", err, adapter.calls)
+	}
+	log.Println("ok: Fail, Success, and nil decisions")
+
+	_, adapter, _, err = run("rate-limited", "GET", step{status: 429, body: backendError}, step{status: 200, body: `{}`})
+	if err != nil || adapter.calls != 2 || consulted[429] != 0 {
+		log.Fatalf("FAIL: 429: %v after %d calls, classifier consulted %d times; want success after 2, never consulted", err, adapter.calls, consulted[429])
+	}
+
+	log.Println("PASS: the classifier decides every attempt but rate limits")
+}
@@ -0,0 +1,109 @@
+// stream_retry.go
+//
+// Checks that RequestStream releases the streamed bodies of attempts the RetryableErrorClassifier
+// rejects. A stub transport behind the GitHub adapter answers 200 with bodies that record being closed.
+// A classifier deciding Retry on the first attempt must get the earlier body closed and the second one
+// returned open; one deciding Retry on every attempt must give up after MaxRetries with an error and no
+// body left open; one deciding Fail must return its error rather than the stream.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// body records whether it was closed.
+type body struct {
+	io.Reader
+	closed bool
+}
+
+func (b *body) Close() error {
+	b.closed = true
+	return nil
+}
+
+// transport answers every request with 200 and a fresh recording body.
+type transport struct {
+	bodies []*body
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := &body{Reader: strings.NewReader("log line\n")}
+	t.bodies = append(t.bodies, b)
+	return &http.Response{StatusCode: 200, Header: http.Header{}, Body: b, Request: req}, nil
+}
+
+// open counts the bodies not closed yet.
+func (t *transport) open() int {
+	n := 0
+	for _, b := range t.bodies {
+		if !b.closed {
+			n++
+		}
+	}
+	return n
+}
+
+// stream sends a streamed GET through an SDK whose classifier decides with decide on the nth attempt.
+func stream(decide func(n int) resilientbridge.RetryDecision) (*transport, *resilientbridge.StreamResponse, error) {
+	stub := &transport{}
+	attempts := 0
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("github", adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		MaxRetries:  2,
+		BaseBackoff: resilientbridge.NoBackoff,
+		HTTPClient:  &http.Client{Transport: stub},
+		RetryableErrorClassifier: func(resp *resilientbridge.NormalizedResponse, err error) resilientbridge.RetryDecision {
+			attempts++
+			return decide(attempts)
+		},
+	})
+	s, err := sdk.RequestStream(context.Background(), "github", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/repos/acme/widgets/actions/runs/1/logs"})
+	return stub, s, err
+}
+
+func main() {
+	stub, s, err := stream(func(n int) resilientbridge.RetryDecision {
+		if n == 1 {
+			return resilientbridge.Retry{}
+		}
+		return nil
+	})
+	if err != nil || s == nil || len(stub.bodies) != 2 || !stub.bodies[0].closed || stub.bodies[1].closed {
+		log.Fatalf("FAIL: retry then accept: %d attempts, %d open (%v), want 2 with only the last open", len(stub.bodies), stub.open(), err)
+	}
+	if data, _ := io.ReadAll(s.Body); string(data) != "log line\n" {
+		log.Fatalf("FAIL: returned stream reads %q", data)
+	}
+	s.Body.Close()
+	log.Println("ok: a retried attempt's body is closed before the next attempt")
+
+	stub, s, err = stream(func(int) resilientbridge.RetryDecision { return resilientbridge.Retry{} })
+	if err == nil || len(stub.bodies) != 3 || stub.open() != 0 {
+		log.Fatalf("FAIL: retry forever: %d attempts, %d open (%v), want 3, none open, and an error", len(stub.bodies), stub.open(), err)
+	}
+	if s != nil {
+		s.Body.Close()
+	}
+	log.Println("ok: exhausted retries return an error and close every body")
+
+	rejected := errors.New("rejected by classifier")
+	stub, s, err = stream(func(int) resilientbridge.RetryDecision { return resilientbridge.Fail{Err: rejected} })
+	if !errors.Is(err, rejected) || len(stub.bodies) != 1 || stub.open() != 0 {
+		log.Fatalf("FAIL: fail: %d attempts, %d open (%v), want 1, none open, and the classifier's error", len(stub.bodies), stub.open(), err)
+	}
+	if s != nil {
+		s.Body.Close()
+	}
+
+	log.Println("PASS: RequestStream releases the streams of rejected attempts")
+}
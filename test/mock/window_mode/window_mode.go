@@ -0,0 +1,99 @@
+// window_mode.go
+//
+// Checks ProviderConfig.WindowMode for EndpointLimits. The mock adapter reports a reset time 1.5 seconds
+// in the future, and an EndpointLimit allows 2 requests per hour. With WindowSliding the third request must
+// be refused for about an hour; with WindowFixedReset the window is aligned to the reported reset, so the
+// third request is refused only until the reset, after which a new window starts with its full budget.
+
+package main
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/mock"
+)
+
+// resetMock is the mock adapter reporting plenty of remaining requests and a fixed reset time.
+type resetMock struct {
+	*mock.MockAdapter
+	resetAt int64 // Unix ms
+}
+
+func (m *resetMock) ParseRateLimitInfo(resp *resilientbridge.NormalizedResponse) (*resilientbridge.NormalizedRateLimitInfo, error) {
+	return &resilientbridge.NormalizedRateLimitInfo{
+		MaxRequests:       resilientbridge.IntPtr(5000),
+		RemainingRequests: resilientbridge.IntPtr(4000),
+		ResetRequestsAt:   &m.resetAt,
+	}, nil
+}
+
+func newSDK(mode resilientbridge.WindowMode, resetAt time.Time) *resilientbridge.ResilientBridge {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("mock", &resetMock{MockAdapter: &mock.MockAdapter{}, resetAt: resetAt.UnixMilli()}, &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		RateLimitBehavior: resilientbridge.RateLimitFailFast,
+		EndpointLimits: []resilientbridge.EndpointLimit{
+			{Pattern: regexp.MustCompile(`^/search`), Max: 2, WindowSecs: 3600},
+		},
+		WindowMode: mode,
+	})
+	return sdk
+}
+
+// send makes one request and returns the wait of the *RateLimitError it failed with, or 0 on success.
+func send(sdk *resilientbridge.ResilientBridge) time.Duration {
+	_, err := sdk.Request("mock", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/search/items"})
+	if err == nil {
+		return 0
+	}
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) {
+		log.Fatalf("FAIL: expected a *RateLimitError, got %v", err)
+	}
+	return rlErr.RetryAfter
+}
+
+func main() {
+	// Sliding: the third request waits for the first one to leave the hour-long window
+	sliding := newSDK(resilientbridge.WindowSliding, time.Now().Add(1500*time.Millisecond))
+	for i := 1; i <= 2; i++ {
+		if wait := send(sliding); wait != 0 {
+			log.Fatalf("FAIL: sliding request %d refused for %v", i, wait)
+		}
+	}
+	if wait := send(sliding); wait < 59*time.Minute {
+		log.Fatalf("FAIL: sliding third request refused for %v, want about an hour", wait)
+	} else {
+		log.Printf("ok: sliding window refuses the third request for %v", wait.Round(time.Second))
+	}
+
+	// FixedReset: the third request waits only until the reported reset
+	resetAt := time.Now().Add(1500 * time.Millisecond)
+	fixed := newSDK(resilientbridge.WindowFixedReset, resetAt)
+	for i := 1; i <= 2; i++ {
+		if wait := send(fixed); wait != 0 {
+			log.Fatalf("FAIL: fixed request %d refused for %v", i, wait)
+		}
+	}
+	wait := send(fixed)
+	if wait <= 0 || wait > 1500*time.Millisecond {
+		log.Fatalf("FAIL: fixed third request refused for %v, want until the reset in at most 1.5s", wait)
+	}
+	log.Printf("ok: fixed window refuses the third request for %v", wait.Round(time.Millisecond))
+
+	time.Sleep(time.Until(resetAt) + 100*time.Millisecond)
+	for i := 1; i <= 2; i++ {
+		if wait := send(fixed); wait != 0 {
+			log.Fatalf("FAIL: request %d after the reset refused for %v", i, wait)
+		}
+	}
+	if wait := send(fixed); wait < 59*time.Minute {
+		log.Fatalf("FAIL: third request after the reset refused for %v, want until the next reset", wait)
+	}
+
+	log.Println("PASS: sliding windows roll per request, fixed windows reset with the provider")
+}
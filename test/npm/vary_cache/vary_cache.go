@@ -0,0 +1,103 @@
+// vary_cache.go
+//
+// Checks that the npm adapter's ETag cache keeps different representations of one endpoint apart. A stub
+// registry (wired in through ProviderConfig.HTTPClient) serves the abbreviated install document for
+// "Accept: application/vnd.npm.install-v1+json" and the full packument otherwise, with "Vary: Accept" and,
+// like a registry that tags document revisions rather than representations, the same ETag for both. An
+// abbreviated request must never be answered from the full document's cache entry (or vice versa), while
+// repeating either request must still be a cache hit. A "Vary: *" response must not be cached at all.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+const abbreviated = "application/vnd.npm.install-v1+json"
+
+// registryStub answers requests in-process instead of sending them to registry.npmjs.org.
+type registryStub struct {
+	conditional int // requests that carried If-None-Match
+}
+
+func (s *registryStub) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	if req.Header.Get("If-None-Match") != "" {
+		s.conditional++
+	}
+
+	if req.URL.Path == "/uncacheable" {
+		rec.Header().Set("ETag", `"rev-1"`)
+		rec.Header().Set("Vary", "*")
+		rec.WriteString(`{"name":"uncacheable"}`)
+		return rec.Result(), nil
+	}
+
+	rec.Header().Set("ETag", `"rev-1"`)
+	rec.Header().Set("Vary", "Accept")
+	if req.Header.Get("If-None-Match") == `"rev-1"` {
+		rec.WriteHeader(http.StatusNotModified)
+		return rec.Result(), nil
+	}
+	if req.Header.Get("Accept") == abbreviated {
+		rec.WriteString(`{"name":"left-pad","doc":"abbreviated"}`)
+	} else {
+		rec.WriteString(`{"name":"left-pad","doc":"full"}`)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	stub := &registryStub{}
+
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("npm", adapters.NewNPMAdapter(), &resilientbridge.ProviderConfig{
+		MaxRetries: 0,
+		HTTPClient: &http.Client{Transport: stub},
+	})
+
+	expect(sdk, "application/json", `{"name":"left-pad","doc":"full"}`, false)
+	expect(sdk, abbreviated, `{"name":"left-pad","doc":"abbreviated"}`, false)
+	expect(sdk, "application/json", `{"name":"left-pad","doc":"full"}`, true)
+	expect(sdk, abbreviated, `{"name":"left-pad","doc":"abbreviated"}`, true)
+
+	before := stub.conditional
+	for i := 0; i < 2; i++ {
+		resp, err := sdk.Request("npm", &resilientbridge.NormalizedRequest{Method: "GET", Endpoint: "/uncacheable"})
+		if err != nil {
+			log.Fatalf("FAIL: /uncacheable: %v", err)
+		}
+		if resp.Headers["x-npm-cache"] != "" {
+			log.Fatalf("FAIL: a Vary: * response was served from the cache")
+		}
+	}
+	if stub.conditional != before {
+		log.Fatalf("FAIL: a Vary: * response was cached and revalidated")
+	}
+
+	log.Println("PASS: the npm ETag cache keys on Accept and honors Vary")
+}
+
+// expect requests /left-pad with accept and checks the body and whether it came from the cache.
+func expect(sdk *resilientbridge.ResilientBridge, accept, body string, hit bool) {
+	resp, err := sdk.Request("npm", &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "/left-pad",
+		Headers:  map[string]string{"Accept": accept},
+	})
+	if err != nil {
+		log.Fatalf("FAIL (%s): %v", accept, err)
+	}
+	if string(resp.Data) != body {
+		log.Fatalf("FAIL (%s): got %s, want %s", accept, resp.Data, body)
+	}
+	if gotHit := resp.Headers["x-npm-cache"] == "hit"; gotHit != hit {
+		log.Fatalf("FAIL (%s): cache hit = %v, want %v", accept, gotHit, hit)
+	}
+	log.Printf("ok (%s): %s (cache hit: %v)", accept, resp.Data, hit)
+}
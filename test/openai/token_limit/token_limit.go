@@ -0,0 +1,88 @@
+// token_limit.go
+//
+// Checks that the SDK throttles on whichever limit of the OpenAI adapter is scarcer. A stub answers the
+// first completion with plenty of requests left but no tokens, resetting in 1.2 seconds, so the next
+// request must wait for the token reset even though the request limit is fine. It then answers once with
+// a 429 and "Retry-After: 1", which must be retried after the second instead of failing.
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// stubOpenAI answers chat completions and records when each one arrived.
+type stubOpenAI struct {
+	sent []time.Time
+}
+
+func (s *stubOpenAI) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.sent = append(s.sent, time.Now())
+	rec := httptest.NewRecorder()
+	h := rec.Header()
+	h.Set("x-ratelimit-limit-requests", "500")
+	h.Set("x-ratelimit-remaining-requests", "450")
+	h.Set("x-ratelimit-reset-requests", "120ms")
+	h.Set("x-ratelimit-limit-tokens", "30000")
+	switch len(s.sent) {
+	case 1:
+		h.Set("x-ratelimit-remaining-tokens", "0")
+		h.Set("x-ratelimit-reset-tokens", "1.2s")
+	case 2:
+		h.Set("x-ratelimit-remaining-tokens", "30000")
+		h.Set("x-ratelimit-reset-tokens", "0s")
+		h.Set("Retry-After", "1")
+		rec.WriteHeader(http.StatusTooManyRequests)
+		rec.WriteString(`{"error":{"message":"Rate limit reached","type":"requests"}}`)
+		return rec.Result(), nil
+	default:
+		h.Set("x-ratelimit-remaining-tokens", "29000")
+		h.Set("x-ratelimit-reset-tokens", "2s")
+	}
+	rec.WriteString(`{"id":"chatcmpl-1","choices":[]}`)
+	return rec.Result(), nil
+}
+
+func main() {
+	stub := &stubOpenAI{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("openai", adapters.NewOpenAIAdapter("key", "https://llm.example.test"), &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		MaxRetries:        2,
+		HTTPClient:        &http.Client{Transport: stub},
+	})
+
+	req := &resilientbridge.NormalizedRequest{Method: "POST", Endpoint: "/v1/chat/completions", Body: []byte(`{"model":"m"}`)}
+	if _, err := sdk.Request("openai", req); err != nil {
+		log.Fatalf("FAIL: first request: %v", err)
+	}
+	info := sdk.GetRateLimitInfo("openai")
+	if info == nil || info.RemainingTokens == nil || *info.RemainingTokens != 0 || info.RemainingRequests == nil || *info.RemainingRequests != 450 {
+		log.Fatalf("FAIL: expected 450 requests and 0 tokens remaining, got %+v", info)
+	}
+
+	if _, err := sdk.Request("openai", req); err != nil {
+		log.Fatalf("FAIL: second request: %v", err)
+	}
+	if len(stub.sent) != 3 {
+		log.Fatalf("FAIL: sent %d requests, want 3 (the 429 retried once)", len(stub.sent))
+	}
+	if tokenWait := stub.sent[1].Sub(stub.sent[0]); tokenWait < time.Second {
+		log.Fatalf("FAIL: second request sent %v after the first, want it held until the token reset (1.2s)", tokenWait)
+	} else {
+		log.Printf("ok: waited %v for the token limit to reset", tokenWait.Round(10*time.Millisecond))
+	}
+	if retryWait := stub.sent[2].Sub(stub.sent[1]); retryWait < time.Second {
+		log.Fatalf("FAIL: 429 retried after %v, want Retry-After (1s) honored", retryWait)
+	} else {
+		log.Printf("ok: retried the 429 after %v", retryWait.Round(10*time.Millisecond))
+	}
+
+	log.Println("PASS: token limits hold requests back like request limits, and 429s honor Retry-After")
+}
@@ -0,0 +1,93 @@
+// detector_client.go
+//
+// Checks that the model detector's requests follow the SDK's configuration. Through SearchGitHub and
+// IsBinaryFileForItem, the Accept and User-Agent headers must come from the provider's DefaultHeaders and
+// UserAgent rather than being hardcoded. Through a github.Client with a GitHub Enterprise Server BaseURL,
+// the search and contents requests must go to that host, with the client's preview media type and the
+// adapter's token.
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+	"github.com/opengovern/resilient-bridge/utils"
+)
+
+// stub answers code searches and contents requests, recording the requests it saw.
+type stub struct {
+	mu   sync.Mutex
+	seen []*http.Request
+}
+
+func (s *stub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	s.seen = append(s.seen, req)
+	s.mu.Unlock()
+	rec := httptest.NewRecorder()
+	switch path := req.URL.Path; {
+	case strings.HasSuffix(path, "/search/code"):
+		rec.WriteString(`{"total_count":1,"items":[{"name":"m.pt","path":"models/m.pt","repository":{"full_name":"acme/ml"}}]}`)
+	case strings.HasSuffix(path, "/repos/acme/ml/contents/models/m.pt"):
+		content := base64.StdEncoding.EncodeToString([]byte("PK\x03\x04\x00\x00binary"))
+		fmt.Fprintf(rec, `{"content":%q,"encoding":"base64"}`, content)
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message":"Not Found"}`)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	s := &stub{}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), &resilientbridge.ProviderConfig{
+		HTTPClient:     &http.Client{Transport: s},
+		UserAgent:      "model-scanner/1.0",
+		DefaultHeaders: map[string]string{"Accept": "application/vnd.github.v3+json"},
+	})
+
+	result, err := utils.SearchGitHub(sdk, "extension:pt", 1)
+	if err != nil || len(result.Items) != 1 {
+		log.Fatalf("FAIL: SearchGitHub: %v (%d items)", err, len(result.Items))
+	}
+	if binary, err := utils.IsBinaryFileForItem(sdk, result.Items[0], false); err != nil || !binary {
+		log.Fatalf("FAIL: IsBinaryFileForItem = %v, %v", binary, err)
+	}
+	for _, req := range s.seen {
+		if req.URL.Host != "api.github.com" || req.Header.Get("Accept") != "application/vnd.github.v3+json" || req.Header.Get("User-Agent") != "model-scanner/1.0" {
+			log.Fatalf("FAIL: %s%s sent with Accept %q, User-Agent %q", req.URL.Host, req.URL.Path, req.Header.Get("Accept"), req.Header.Get("User-Agent"))
+		}
+	}
+	log.Println("ok: Accept and User-Agent come from the provider config")
+
+	s.seen = nil
+	client := &github.Client{SDK: sdk, BaseURL: "https://ghe.example.com/api/v3", Accept: []string{github.Preview("mercy")}}
+	result, err = utils.SearchGitHubWithClient(client, "extension:pt", 1)
+	if err != nil || len(result.Items) != 1 {
+		log.Fatalf("FAIL: SearchGitHubWithClient: %v", err)
+	}
+	if binary, err := utils.IsBinaryFileForItemWithClient(client, result.Items[0], false); err != nil || !binary {
+		log.Fatalf("FAIL: IsBinaryFileForItemWithClient = %v, %v", binary, err)
+	}
+	for _, req := range s.seen {
+		if req.URL.Host != "ghe.example.com" || !strings.HasPrefix(req.URL.Path, "/api/v3/") || !strings.HasPrefix(req.Header.Get("Accept"), github.Preview("mercy")) {
+			log.Fatalf("FAIL: %s%s sent with Accept %q", req.URL.Host, req.URL.Path, req.Header.Get("Accept"))
+		}
+		if auth := req.Header.Get("Authorization"); auth != "Bearer token" {
+			log.Fatalf("FAIL: %s%s sent with Authorization %q, want the adapter's token", req.URL.Host, req.URL.Path, auth)
+		}
+	}
+	log.Println("ok: GitHub Enterprise requests carry the client's media type and the adapter's token")
+
+	log.Println("PASS: the model detector follows the provider config and the github.Client's deployment")
+}
@@ -0,0 +1,102 @@
+// image_size.go
+//
+// Checks utils.ComputeImageSize against an in-memory registry. A two-platform index, whose arm64 image is
+// the amd64 one plus a layer, must report each platform's full size and a total counting the shared layers
+// once, without the attestation manifest it also carries. A plain image must report its own size (the
+// value single-manifest code got right, and returned as 0 for the index).
+
+package main
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/opengovern/resilient-bridge/utils"
+)
+
+func main() {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	amd64, err := random.Image(1024, 2)
+	must(err)
+	extra, err := random.Layer(512, "application/vnd.oci.image.layer.v1.tar")
+	must(err)
+	arm64, err := mutate.AppendLayers(amd64, extra)
+	must(err)
+	attestation, err := random.Image(64, 1)
+	must(err)
+
+	index := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}}},
+		mutate.IndexAddendum{Add: arm64, Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}}},
+		mutate.IndexAddendum{Add: attestation, Descriptor: v1.Descriptor{
+			Platform:    &v1.Platform{OS: "unknown", Architecture: "unknown"},
+			Annotations: map[string]string{"vnd.docker.reference.type": "attestation-manifest"},
+		}},
+	)
+	indexRef, err := name.ParseReference(host + "/acme/app:multi")
+	must(err)
+	must(remote.WriteIndex(indexRef, index))
+	imageRef, err := name.ParseReference(host + "/acme/app:single")
+	must(err)
+	must(remote.Write(imageRef, amd64))
+
+	amd64Size, arm64Size := size(amd64), size(arm64)
+	extraSize, err := extra.Size()
+	must(err)
+	wantTotal := amd64Size + configSize(arm64) + extraSize // arm64 shares amd64's layers
+
+	total, perPlatform, err := utils.ComputeImageSize(indexRef, nil)
+	if err != nil {
+		log.Fatalf("FAIL: index: %v", err)
+	}
+	if perPlatform["linux/amd64"] != amd64Size || perPlatform["linux/arm64/v8"] != arm64Size || len(perPlatform) != 2 {
+		log.Fatalf("FAIL: per platform %v, want linux/amd64=%d linux/arm64/v8=%d", perPlatform, amd64Size, arm64Size)
+	}
+	if total != wantTotal {
+		log.Fatalf("FAIL: index total %d, want %d (shared layers counted once)", total, wantTotal)
+	}
+	log.Printf("ok: index total %d, per platform %v", total, perPlatform)
+
+	total, perPlatform, err = utils.ComputeImageSize(imageRef, nil)
+	if err != nil || total != amd64Size || len(perPlatform) != 1 {
+		log.Fatalf("FAIL: image: %d %v %v, want %d for one platform", total, perPlatform, err, amd64Size)
+	}
+
+	log.Println("PASS: image sizes cover every platform of an index, shared layers once")
+}
+
+// size returns img's config plus layer sizes from its manifest.
+func size(img v1.Image) int64 {
+	manifest, err := img.Manifest()
+	must(err)
+	n := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		n += layer.Size
+	}
+	return n
+}
+
+func configSize(img v1.Image) int64 {
+	manifest, err := img.Manifest()
+	must(err)
+	return manifest.Config.Size
+}
+
+func must(err error) {
+	if err != nil {
+		log.Fatalf("FAIL: %v", err)
+	}
+}
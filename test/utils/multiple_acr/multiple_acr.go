@@ -0,0 +1,115 @@
+// multiple_acr.go
+//
+// Checks utils.GetAllCredentials with two Azure Container Registries configured at once, each behind its
+// own service principal. A stub replacing http.DefaultTransport plays Azure AD and both registries: AAD
+// issues a token per client, and each registry only exchanges the AAD token of its own client and only
+// accepts refresh tokens it issued itself. Both registries must end up in the result with their own
+// access tokens, each fetched through its own AAD token and refresh token exactly once, and a rejected
+// secret for one of them must fail naming that registry.
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/opengovern/resilient-bridge/utils"
+)
+
+// clients maps each registry to the service principal that may pull from it.
+var clients = map[string]string{
+	"first.azurecr.io":  "client-one",
+	"second.azurecr.io": "client-two",
+}
+
+// azure answers AAD token requests and the ACR token exchanges of the registries in clients.
+type azure struct {
+	mu    sync.Mutex
+	calls map[string]int // "host path" -> count
+}
+
+func (a *azure) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	form, _ := url.ParseQuery(string(body))
+	a.mu.Lock()
+	a.calls[req.URL.Host+" "+req.URL.Path]++
+	a.mu.Unlock()
+
+	rec := httptest.NewRecorder()
+	host := req.URL.Host
+	switch {
+	case host == "login.microsoftonline.com":
+		if form.Get("client_secret") != "secret-"+form.Get("client_id") {
+			rec.WriteHeader(http.StatusUnauthorized)
+			break
+		}
+		fmt.Fprintf(rec, `{"access_token":"aad-%s"}`, form.Get("client_id"))
+	case clients[host] == "":
+		rec.WriteHeader(http.StatusNotFound)
+	case req.URL.Path == "/oauth2/exchange":
+		if form.Get("service") != host || form.Get("access_token") != "aad-"+clients[host] {
+			rec.WriteHeader(http.StatusUnauthorized)
+			break
+		}
+		fmt.Fprintf(rec, `{"refresh_token":"refresh-%s"}`, host)
+	case req.URL.Path == "/oauth2/token":
+		if form.Get("service") != host || form.Get("refresh_token") != "refresh-"+host {
+			rec.WriteHeader(http.StatusUnauthorized)
+			break
+		}
+		fmt.Fprintf(rec, `{"access_token":"access-%s"}`, host)
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+	}
+	return rec.Result(), nil
+}
+
+func main() {
+	stub := &azure{calls: map[string]int{}}
+	http.DefaultTransport = stub
+
+	input := `{"azure_spn_password": [
+		{"tenant_id": "tenant", "client_id": "client-one", "client_secret": "secret-client-one", "registry": "first.azurecr.io"},
+		{"tenant_id": "tenant", "client_id": "client-two", "client_secret": "secret-client-two", "registry": "second.azurecr.io"}
+	]}`
+	creds, err := utils.GetAllCredentials([]byte(input), "repository:app:pull")
+	if err != nil {
+		log.Fatalf("FAIL: GetAllCredentials: %v", err)
+	}
+	if len(creds) != len(clients) {
+		log.Fatalf("FAIL: got credentials for %d registries, want %d: %v", len(creds), len(clients), creds)
+	}
+
+	for registry := range clients {
+		decoded, err := base64.StdEncoding.DecodeString(creds[registry])
+		if err != nil {
+			log.Fatalf("FAIL (%s): credential %q is not base64: %v", registry, creds[registry], err)
+		}
+		if want := "00000000-0000-0000-0000-000000000000:access-" + registry; string(decoded) != want {
+			log.Fatalf("FAIL (%s): credential %q, want %q", registry, decoded, want)
+		}
+		for _, path := range []string{"/oauth2/exchange", "/oauth2/token"} {
+			if n := stub.calls[registry+" "+path]; n != 1 {
+				log.Fatalf("FAIL (%s): %s called %d times, want once", registry, path, n)
+			}
+		}
+		log.Printf("ok (%s): %s", registry, decoded)
+	}
+	if n := stub.calls["login.microsoftonline.com /tenant/oauth2/v2.0/token"]; n != len(clients) {
+		log.Fatalf("FAIL: %d AAD token requests, want one per registry", n)
+	}
+
+	if _, err := utils.GetAllCredentials([]byte(strings.Replace(input, "secret-client-two", "wrong", 1)), ""); err == nil || !strings.Contains(err.Error(), "second.azurecr.io") {
+		log.Fatalf("FAIL: a bad secret for the second registry should fail naming it, got %v", err)
+	}
+	log.Println("ok: a rejected client is reported for its own registry")
+
+	log.Println("PASS: two ACR registries each get their own credentials")
+}
@@ -0,0 +1,109 @@
+// search_all.go
+//
+// Checks utils.SearchGitHubAll against a stubbed code search. A query with 250 results must take three
+// pages; one reporting 5000 results must stop at GitHub's cap of ten pages (1000 items); one whose second
+// page has incomplete_results must stop there, with IncompleteResults set; MaxPages must bound the pages.
+// Without DisableRateLimiting, the ten-page search uses up the adapter's code search window, and the next
+// search must fail with a *RateLimitError under RateLimitFailFast instead of reaching GitHub.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+	"github.com/opengovern/resilient-bridge/github"
+	"github.com/opengovern/resilient-bridge/utils"
+)
+
+// stub answers /search/code with pages of the total given by the query ("total:N"), flagging page
+// "incomplete:P" as incomplete, and counts the searches.
+type stub struct {
+	calls int
+}
+
+func (s *stub) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.calls++
+	q := req.URL.Query()
+	var total, incomplete int
+	for _, term := range strings.Fields(q.Get("q")) {
+		if v, ok := strings.CutPrefix(term, "total:"); ok {
+			total, _ = strconv.Atoi(v)
+		}
+		if v, ok := strings.CutPrefix(term, "incomplete:"); ok {
+			incomplete, _ = strconv.Atoi(v)
+		}
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	n := total - (page-1)*100
+	if n > 100 {
+		n = 100
+	}
+	if n < 0 || page > 10 {
+		n = 0
+	}
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"name":"f%d","path":"p/f%d","repository":{"full_name":"acme/repo"}}`, i, i)
+	}
+	rec := httptest.NewRecorder()
+	fmt.Fprintf(rec, `{"total_count":%d,"incomplete_results":%t,"items":[%s]}`, total, page == incomplete, strings.Join(items, ","))
+	return rec.Result(), nil
+}
+
+func register(s *stub, config *resilientbridge.ProviderConfig) *resilientbridge.ResilientBridge {
+	config.HTTPClient = &http.Client{Transport: s}
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider(github.ProviderName, adapters.NewGitHubAdapter("token"), config)
+	return sdk
+}
+
+func main() {
+	s := &stub{}
+	sdk := register(s, &resilientbridge.ProviderConfig{DisableRateLimiting: true})
+	cases := []struct {
+		query       string
+		cfg         utils.SearchConfig
+		items, reqs int
+		incomplete  bool
+	}{
+		{"total:250", utils.SearchConfig{}, 250, 3, false},
+		{"total:5000", utils.SearchConfig{}, 1000, 10, false},
+		{"total:5000", utils.SearchConfig{MaxPages: 20}, 1000, 10, false},
+		{"total:900 incomplete:2", utils.SearchConfig{}, 200, 2, true},
+		{"total:900", utils.SearchConfig{MaxPages: 4}, 400, 4, false},
+		{"total:200", utils.SearchConfig{}, 200, 2, false},
+	}
+	for _, c := range cases {
+		before := s.calls
+		result, err := utils.SearchGitHubAll(sdk, c.query, c.cfg)
+		if err != nil {
+			log.Fatalf("FAIL: %q: %v", c.query, err)
+		}
+		if len(result.Items) != c.items || s.calls-before != c.reqs || result.IncompleteResults != c.incomplete {
+			log.Fatalf("FAIL: %q (MaxPages %d): %d items in %d requests, incomplete %t; want %d in %d, %t",
+				c.query, c.cfg.MaxPages, len(result.Items), s.calls-before, result.IncompleteResults, c.items, c.reqs, c.incomplete)
+		}
+	}
+	log.Println("ok: pages aggregated up to the cap, incomplete results, and MaxPages")
+
+	s = &stub{}
+	sdk = register(s, &resilientbridge.ProviderConfig{RateLimitBehavior: resilientbridge.RateLimitFailFast})
+	if result, err := utils.SearchGitHubAll(sdk, "total:5000", utils.SearchConfig{}); err != nil || len(result.Items) != 1000 {
+		log.Fatalf("FAIL: ten pages within the search window: %d items (%v)", len(result.Items), err)
+	}
+	_, err := utils.SearchGitHubAll(sdk, "total:10", utils.SearchConfig{})
+	var rlErr *resilientbridge.RateLimitError
+	if !errors.As(err, &rlErr) || s.calls != 10 {
+		log.Fatalf("FAIL: search past the window: %v after %d requests, want a *RateLimitError after 10", err, s.calls)
+	}
+
+	log.Println("PASS: SearchGitHubAll pages through code search within its limits")
+}
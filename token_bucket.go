@@ -0,0 +1,126 @@
+// token_bucket.go
+// ---------------
+// This file defines TokenBucketLimiter, a smooth-pacing alternative to the rolling-window counting the
+// adapters use by default. A rolling window allows a full window's worth of requests to burst at once
+// (e.g., right at a window edge); a token bucket instead refills at a steady rate and caps bursts at its
+// capacity. With a small burst it behaves like a leaky bucket, spacing requests evenly.
+//
+// The SDK uses one bucket per provider and call type when ProviderConfig.RateLimitAlgorithm is
+// LimiterTokenBucket. Adapters can also embed a TokenBucketLimiter directly for provider-side models
+// such as Azure's token bucket or Shopify's leaky bucket.
+package resilientbridge
+
+import (
+	"sync"
+	"time"
+)
+
+// LimiterAlgorithm selects how the SDK paces requests for a provider.
+type LimiterAlgorithm int
+
+const (
+	// LimiterWindow relies on the adapter's rolling-window limits and reported rate limit info (default).
+	LimiterWindow LimiterAlgorithm = iota
+	// LimiterTokenBucket additionally paces every attempt through a token bucket
+	// (see ProviderConfig.TokenBucketRate and TokenBucketBurst).
+	LimiterTokenBucket
+)
+
+// TokenBucketLimiter refills tokens at a fixed rate up to a burst capacity. Each request takes one token.
+type TokenBucketLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	clock  Clock
+}
+
+// NewTokenBucketLimiter creates a full bucket refilling at rate tokens per second with the given burst
+// capacity. A burst below 1 is treated as 1.
+func NewTokenBucketLimiter(rate float64, burst int) *TokenBucketLimiter {
+	return newTokenBucketLimiter(rate, burst, realClock{})
+}
+
+func newTokenBucketLimiter(rate float64, burst int, clock Clock) *TokenBucketLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucketLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+		clock:  clock,
+	}
+}
+
+// Allow takes a token if one is available right now and reports whether it did.
+func (b *TokenBucketLimiter) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+	return false
+}
+
+// Reserve takes a token and returns how long the caller must wait before using it.
+// Concurrent callers receive increasing delays, so requests are spaced evenly instead of bursting.
+func (b *TokenBucketLimiter) Reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens--
+	if b.tokens >= 0 || b.rate <= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// Sync sets the number of available tokens from provider-reported state (e.g., a header reporting how much
+// of a leaky bucket is used), so local pacing follows the server's view. The value is clamped to [0, burst].
+func (b *TokenBucketLimiter) Sync(available int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens = float64(available)
+	if b.tokens < 0 {
+		b.tokens = 0
+	}
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Burst returns the bucket capacity.
+func (b *TokenBucketLimiter) Burst() int {
+	return int(b.burst)
+}
+
+// NextTokenIn returns how long until a token is available, without taking one.
+func (b *TokenBucketLimiter) NextTokenIn() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens >= 1 || b.rate <= 0 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// refill adds the tokens accrued since the last call. Callers must hold b.mu.
+func (b *TokenBucketLimiter) refill() {
+	now := b.clock.Now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
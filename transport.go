@@ -0,0 +1,84 @@
+// transport.go
+// ------------
+// This file defines DefaultTransport, the shared *http.Transport every adapter's requests go through.
+// Adapters build a fresh &http.Client{} per call; without a shared transport, how well connections were
+// reused depended on http.DefaultTransport's settings, which keep only two idle connections per host, so
+// concurrent crawls against one API kept opening new connections and repeating TLS handshakes.
+//
+// DoRoundTrip and DoStreamRoundTrip use, in order:
+//   - ProviderConfig.HTTPClient, when set (custom proxies, TLS settings, instrumented transports, stubs in tests);
+//   - the adapter's client, when it brings its own Transport;
+//   - otherwise the adapter's client with DefaultTransport.
+//
+// DefaultTransport is a clone of http.DefaultTransport (so proxy settings from the environment still apply)
+// with these values:
+//   - MaxIdleConns: DefaultMaxIdleConns (100)
+//   - MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost (32)
+//   - IdleConnTimeout: DefaultIdleConnTimeout (90s)
+//   - dial timeout DefaultDialTimeout (30s) with 30s TCP keep-alives
+//   - TLSHandshakeTimeout: DefaultTLSHandshakeTimeout (10s)
+//
+// There is no response header timeout: slow endpoints (large GraphQL queries, report exports) legitimately
+// take a while to answer. Use ProviderConfig.RequestTimeout to bound individual attempts.
+package resilientbridge
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultDialTimeout         = 30 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// DefaultTransport is shared by all adapters whose client has no Transport of its own. It may be replaced
+// before the SDK is used; per provider, prefer ProviderConfig.HTTPClient.
+var DefaultTransport http.RoundTripper = NewTransport()
+
+// NewTransport returns a new *http.Transport with the SDK's connection pooling and timeout defaults.
+func NewTransport() *http.Transport {
+	var t *http.Transport
+	if base, ok := http.DefaultTransport.(*http.Transport); ok {
+		t = base.Clone()
+	} else {
+		t = &http.Transport{Proxy: http.ProxyFromEnvironment, ForceAttemptHTTP2: true}
+	}
+	t.DialContext = (&net.Dialer{
+		Timeout:   DefaultDialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	t.MaxIdleConns = DefaultMaxIdleConns
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	t.IdleConnTimeout = DefaultIdleConnTimeout
+	t.TLSHandshakeTimeout = DefaultTLSHandshakeTimeout
+	return t
+}
+
+// clientFor returns the client a round trip of httpReq uses: the provider's ProviderConfig.HTTPClient if
+// set, otherwise client, with DefaultTransport filled in when client has no Transport. Requests with
+// FollowLocationStripAuth get a copy whose CheckRedirect strips credentials (see follow_location.go).
+func clientFor(httpReq *http.Request, client *http.Client) *http.Client {
+	if config := ProviderConfigFromContext(httpReq.Context()); config != nil && config.HTTPClient != nil {
+		client = config.HTTPClient
+	} else {
+		if client == nil {
+			client = &http.Client{}
+		}
+		if client.Transport == nil {
+			withTransport := *client
+			withTransport.Transport = DefaultTransport
+			client = &withTransport
+		}
+	}
+	if followLocationStripAuth(httpReq.Context()) {
+		following := *client
+		following.CheckRedirect = stripAuthCheckRedirect
+		client = &following
+	}
+	return client
+}
@@ -6,6 +6,10 @@
 // It provides functions to acquire tokens (such as AAD tokens) needed to call Azure Resource Manager or other Azure services.
 // The SPN authentication is done via client_id, client_secret, and tenant_id.
 // Example usage: Acquire a token for Azure Management endpoints or other Azure resource endpoints using SPN credentials.
+//
+// Tokens are cached per resource: AcquireTokenForResource keeps one token for each resource it is asked for
+// (ARM, Microsoft Graph, ACR, Key Vault, ...), each refreshed on its own expiry, and AcquireTokenSilent is
+// the same for the configured Resource. An AzureSPN is safe for concurrent use.
 package utils
 
 import (
@@ -18,6 +22,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -47,8 +52,10 @@ type azureTokenResponse struct {
 type AzureSPN struct {
 	config  *AzureSPNConfig
 	client  *http.Client
-	token   *oauth2.Token
 	baseURL string
+
+	mu     sync.Mutex
+	tokens map[string]*oauth2.Token // scope -> last token acquired for it
 }
 
 // NewAzureSPN creates a new AzureSPN instance.
@@ -66,14 +73,51 @@ func NewAzureSPN(cfg *AzureSPNConfig) (*AzureSPN, error) {
 		config:  cfg,
 		client:  &http.Client{Timeout: 30 * time.Second},
 		baseURL: fmt.Sprintf("%s/%s/oauth2/v2.0/token", cfg.AuthorityHost, cfg.TenantID),
+		tokens:  make(map[string]*oauth2.Token),
 	}, nil
 }
 
-// AcquireToken obtains a new token from AAD. If UseCertAuth is true, it uses client assertion (JWT).
-// Otherwise, it uses client_secret.
+// AcquireToken obtains a new token from AAD for the configured Resource. If UseCertAuth is true, it uses
+// client assertion (JWT). Otherwise, it uses client_secret.
 func (s *AzureSPN) AcquireToken(ctx context.Context) (*oauth2.Token, error) {
+	return s.acquireToken(ctx, resourceScope(s.config.Resource))
+}
+
+// AcquireTokenForResource returns a token for resource, such as "https://graph.microsoft.com/.default" or
+// "https://vault.azure.net" (a bare resource URI gets "/.default" appended). The token is cached for that
+// resource alone and reused until it expires, independently of the tokens of other resources.
+func (s *AzureSPN) AcquireTokenForResource(ctx context.Context, resource string) (*oauth2.Token, error) {
+	return s.acquireTokenSilent(ctx, resourceScope(resource))
+}
+
+// resourceScope turns a bare resource URI ("https://vault.azure.net") into its v2 ".default" scope, and
+// returns scopes as they are.
+func resourceScope(resource string) string {
+	u, err := url.Parse(resource)
+	if err != nil || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return resource
+	}
+	return strings.TrimRight(resource, "/") + "/.default"
+}
+
+// cachedToken returns the token last acquired for scope, or nil.
+func (s *AzureSPN) cachedToken(scope string) *oauth2.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[scope]
+}
+
+// cacheToken stores tok as the token for scope.
+func (s *AzureSPN) cacheToken(scope string, tok *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[scope] = tok
+}
+
+// acquireToken obtains a new token for scope and caches it.
+func (s *AzureSPN) acquireToken(ctx context.Context, scope string) (*oauth2.Token, error) {
 	form := url.Values{}
-	form.Set("scope", s.config.Resource)
+	form.Set("scope", scope)
 	form.Set("client_id", s.config.ClientID)
 
 	if s.config.UseCertAuth {
@@ -96,28 +140,34 @@ func (s *AzureSPN) AcquireToken(ctx context.Context) (*oauth2.Token, error) {
 	if err != nil {
 		return nil, err
 	}
-	s.token = tok
-	return s.token, nil
+	s.cacheToken(scope, tok)
+	return tok, nil
 }
 
 // AcquireTokenSilent returns a cached token if it's still valid, or tries to refresh it if supported.
 // If token is expired and cannot be refreshed (no refresh_token), it calls AcquireToken again.
 func (s *AzureSPN) AcquireTokenSilent(ctx context.Context) (*oauth2.Token, error) {
-	if s.token == nil {
-		return s.AcquireToken(ctx)
+	return s.acquireTokenSilent(ctx, resourceScope(s.config.Resource))
+}
+
+// acquireTokenSilent is AcquireTokenSilent for the token cached for scope.
+func (s *AzureSPN) acquireTokenSilent(ctx context.Context, scope string) (*oauth2.Token, error) {
+	token := s.cachedToken(scope)
+	if token == nil {
+		return s.acquireToken(ctx, scope)
 	}
 
-	if s.token.Valid() {
+	if token.Valid() {
 		// Token is still valid
-		return s.token, nil
+		return token, nil
 	}
 
 	// If we had a refresh token (not always available in client_credentials flow), attempt refresh.
 	// Generally, client_credentials flow doesn't return a refresh_token. But if it does:
-	if s.token.RefreshToken != "" {
+	if token.RefreshToken != "" {
 		form := url.Values{}
 		form.Set("grant_type", "refresh_token")
-		form.Set("refresh_token", s.token.RefreshToken)
+		form.Set("refresh_token", token.RefreshToken)
 		form.Set("client_id", s.config.ClientID)
 		if !s.config.UseCertAuth && s.config.ClientSecret != "" {
 			form.Set("client_secret", s.config.ClientSecret)
@@ -130,17 +180,17 @@ func (s *AzureSPN) AcquireTokenSilent(ctx context.Context) (*oauth2.Token, error
 			form.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
 			form.Set("client_assertion", assertion)
 		}
-		form.Set("scope", s.config.Resource)
+		form.Set("scope", scope)
 
 		newTok, err := s.doTokenRequest(ctx, form)
 		if err == nil {
-			s.token = newTok
-			return s.token, nil
+			s.cacheToken(scope, newTok)
+			return newTok, nil
 		}
 		// If refresh failed, fallback to full AcquireToken
 	}
 
-	return s.AcquireToken(ctx)
+	return s.acquireToken(ctx, scope)
 }
 
 // createClientAssertion creates a JWT-based client assertion for certificate authentication.
@@ -222,6 +272,22 @@ func (s *AzureSPN) Client(ctx context.Context) *http.Client {
 	}
 }
 
+// TokenSource returns an oauth2.TokenSource that acquires a new token from AAD on every call, for use
+// with adapters.NewAzureAdapterWithTokenSource. Wrap it in adapters.NewBackgroundTokenSource (or
+// oauth2.ReuseTokenSource) to cache tokens; the adapters do so themselves when given the bare source.
+func (s *AzureSPN) TokenSource(ctx context.Context) oauth2.TokenSource {
+	return spnTokenSource{spn: s, ctx: ctx}
+}
+
+type spnTokenSource struct {
+	spn *AzureSPN
+	ctx context.Context
+}
+
+func (t spnTokenSource) Token() (*oauth2.Token, error) {
+	return t.spn.AcquireToken(t.ctx)
+}
+
 type tokenTransport struct {
 	base   http.RoundTripper
 	spn    *AzureSPN
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+)
+
+// ClassifierCache memoizes per-file classification results (e.g. IsBinaryFileForItem) keyed by
+// repository full name, path, and blob sha. Because the sha changes whenever the file does, entries
+// never go stale and can be persisted between scans with Save and Load. Items without a sha are
+// memoized for the lifetime of the cache but not persisted.
+type ClassifierCache struct {
+	mu      sync.RWMutex
+	entries map[string]bool
+}
+
+// NewClassifierCache creates an empty ClassifierCache.
+func NewClassifierCache() *ClassifierCache {
+	return &ClassifierCache{entries: make(map[string]bool)}
+}
+
+func classifierCacheKey(item Item) string {
+	return item.Repository.FullName + "|" + item.Path + "@" + item.SHA
+}
+
+// Get returns the cached result for item and whether one was found.
+func (c *ClassifierCache) Get(item Item) (result bool, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result, ok = c.entries[classifierCacheKey(item)]
+	return result, ok
+}
+
+// Set stores the result for item.
+func (c *ClassifierCache) Set(item Item, result bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[classifierCacheKey(item)] = result
+}
+
+// Wrap returns a ClassifyFunc that consults the cache before calling classify. Errors are not cached.
+// A nil cache returns classify unchanged.
+func (c *ClassifierCache) Wrap(classify ClassifyFunc) ClassifyFunc {
+	if c == nil {
+		return classify
+	}
+	return func(sdk *resilientbridge.ResilientBridge, item Item, verbose bool) (bool, error) {
+		if result, ok := c.Get(item); ok {
+			return result, nil
+		}
+		result, err := classify(sdk, item, verbose)
+		if err != nil {
+			return false, err
+		}
+		c.Set(item, result)
+		return result, nil
+	}
+}
+
+// Save writes the entries that have a blob sha to w as JSON.
+func (c *ClassifierCache) Save(w io.Writer) error {
+	c.mu.RLock()
+	persisted := make(map[string]bool, len(c.entries))
+	for k, v := range c.entries {
+		if k[len(k)-1] != '@' {
+			persisted[k] = v
+		}
+	}
+	c.mu.RUnlock()
+	return json.NewEncoder(w).Encode(persisted)
+}
+
+// Load merges entries previously written by Save from r.
+func (c *ClassifierCache) Load(r io.Reader) error {
+	var loaded map[string]bool
+	if err := json.NewDecoder(r).Decode(&loaded); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, v := range loaded {
+		c.entries[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+// ---------------------------------------------------------------------
+// GitHub Resource Counting
+// ---------------------------------------------------------------------
+
+// CountResource returns the number of items behind a GitHub list endpoint (e.g.
+// "/repos/apache/airflow/commits") by requesting one item per page and reading the
+// page number of the rel="last" link. It is github.CountEndpointGet; for repositories,
+// the github.Count* helpers also handle archived and empty repositories.
+func CountResource(sdk *resilientbridge.ResilientBridge, endpoint string) (int, error) {
+	return github.CountEndpointGet(sdk, endpoint)
+}
+
+// CountResourceHead behaves like CountResource but issues a HEAD request first, reading
+// the count from the Link header without downloading a body. It is github.CountEndpoint.
+func CountResourceHead(sdk *resilientbridge.ResilientBridge, endpoint string) (int, error) {
+	return github.CountEndpoint(sdk, endpoint)
+}
+
+// ParseLastPage extracts the page number of the rel="last" link in a Link header.
+// A header without a rel="last" entry means there is only one page.
+func ParseLastPage(linkHeader string) (int, error) {
+	return github.ParseLastPage(linkHeader)
+}
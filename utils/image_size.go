@@ -0,0 +1,102 @@
+// image_size.go
+
+// This file provides ComputeImageSize, which computes the size of a container image from its manifests
+// alone, without downloading any layer. A plain image manifest's size is its config plus its layers; an
+// image index (a multi-arch image) has no layers of its own, so summing its manifest alone gives 0.
+// ComputeImageSize recurses into an index's child manifests instead, returning the size of each platform
+// and a total in which layers shared between platforms (same digest) are counted once.
+//
+// Attestation manifests that BuildKit adds to indexes (platform unknown/unknown) are not images users pull,
+// and are left out.
+package utils
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// attestationReferenceType is the annotation BuildKit sets on the attestation manifests of an index.
+const attestationReferenceType = "vnd.docker.reference.type"
+
+// ComputeImageSize returns the size in bytes (config and layers) of the image ref points at, and its size
+// per platform ("linux/amd64", "linux/arm64/v8", ...). For an image index, total counts every distinct
+// config and layer once across platforms. auth may be nil for anonymous access.
+func ComputeImageSize(ref name.Reference, auth authn.Authenticator) (total int64, perPlatform map[string]int64, err error) {
+	if auth == nil {
+		auth = authn.Anonymous
+	}
+	desc, err := remote.Get(ref, remote.WithAuth(auth))
+	if err != nil {
+		return 0, nil, fmt.Errorf("error fetching %s: %w", ref, err)
+	}
+
+	seen := make(map[v1.Hash]bool) // Configs and layers already counted in total
+	perPlatform = make(map[string]int64)
+	add := func(platform string, manifest *v1.Manifest) {
+		blobs := append([]v1.Descriptor{manifest.Config}, manifest.Layers...)
+		for _, blob := range blobs {
+			perPlatform[platform] += blob.Size
+			if !seen[blob.Digest] {
+				seen[blob.Digest] = true
+				total += blob.Size
+			}
+		}
+	}
+
+	if !desc.MediaType.IsIndex() {
+		img, err := desc.Image()
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading image %s: %w", ref, err)
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading manifest of %s: %w", ref, err)
+		}
+		config, err := img.ConfigFile()
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading config of %s: %w", ref, err)
+		}
+		add(platformName(config.Platform()), manifest)
+		return total, perPlatform, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading index %s: %w", ref, err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return 0, nil, fmt.Errorf("error reading index manifest of %s: %w", ref, err)
+	}
+	for _, child := range indexManifest.Manifests {
+		if !child.MediaType.IsImage() || child.Annotations[attestationReferenceType] != "" {
+			continue
+		}
+		img, err := index.Image(child.Digest)
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading %s of %s: %w", child.Digest, ref, err)
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			return 0, nil, fmt.Errorf("error reading manifest %s of %s: %w", child.Digest, ref, err)
+		}
+		add(platformName(child.Platform), manifest)
+	}
+	return total, perPlatform, nil
+}
+
+// platformName formats p as os/architecture[/variant], or "unknown" without one.
+func platformName(p *v1.Platform) string {
+	if p == nil || p.OS == "" {
+		return "unknown"
+	}
+	platform := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		platform += "/" + p.Variant
+	}
+	return platform
+}
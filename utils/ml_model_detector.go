@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -10,8 +11,10 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/github"
 )
 
 const (
@@ -35,6 +38,7 @@ type SearchResult struct {
 type Item struct {
 	Name       string     `json:"name"`
 	Path       string     `json:"path"`
+	SHA        string     `json:"sha"` // blob sha; keys ClassifierCache entries
 	HTMLURL    string     `json:"html_url"`
 	Repository Repository `json:"repository"`
 }
@@ -177,19 +181,24 @@ func IsBinaryData(data []byte) bool {
 // GitHub Searching
 // ---------------------------------------------------------------------
 
+// SearchGitHub runs one page of a code search. Searches are tracked by the GitHub adapter as their own
+// "code_search" request type, so the SDK paces them against the search limit (10/min) rather than the
+// much larger REST limit.
 func SearchGitHub(sdk *resilientbridge.ResilientBridge, query string, page int) (SearchResult, error) {
+	return searchGitHub(context.Background(), github.NewClient(sdk), query, page)
+}
+
+// SearchGitHubWithClient is SearchGitHub through client, whose base URL (GitHub Enterprise Server) and
+// Accept media types apply. Headers it doesn't set come from the provider's DefaultHeaders and UserAgent.
+func SearchGitHubWithClient(client *github.Client, query string, page int) (SearchResult, error) {
+	return searchGitHub(context.Background(), client, query, page)
+}
+
+func searchGitHub(ctx context.Context, client *github.Client, query string, page int) (SearchResult, error) {
 	var result SearchResult
 
 	endpoint := fmt.Sprintf("/search/code?q=%s&per_page=100&page=%d", url.QueryEscape(query), page)
-	req := &resilientbridge.NormalizedRequest{
-		Method:   "GET",
-		Endpoint: endpoint,
-		Headers: map[string]string{
-			"Accept":     "application/vnd.github.v3+json",
-			"User-Agent": "opencomply fetcher",
-		},
-	}
-	resp, err := sdk.Request("github", req)
+	resp, err := client.Do(ctx, client.NewRequest("GET", endpoint))
 	if err != nil {
 		return result, err
 	}
@@ -202,22 +211,70 @@ func SearchGitHub(sdk *resilientbridge.ResilientBridge, query string, page int)
 	return result, nil
 }
 
+// SearchConfig controls SearchGitHubAll.
+type SearchConfig struct {
+	Client   *github.Client // Sends the searches (see SearchGitHubWithClient); nil = github.NewClient(sdk)
+	MaxPages int            // Pages of 100 to fetch at most; defaults to (and is capped at) MAX_SEARCH_PAGES
+	Verbose  bool           // Log every page and the SDK's rate limit waits
+}
+
+// SearchGitHubAll runs a code search over all of its pages, up to GitHub's cap of 1000 results, and
+// returns the items of every page in one SearchResult. It stops at a short page, once TotalCount items
+// are in, or after a page flagged incomplete_results (the search timed out, so later pages would be
+// unreliable), in which case IncompleteResults is set. As with SearchGitHub, the pages are paced against
+// the code search limit, so running many searches waits instead of running into GitHub's 403s.
+func SearchGitHubAll(sdk *resilientbridge.ResilientBridge, query string, cfg SearchConfig) (SearchResult, error) {
+	client := cfg.Client
+	if client == nil {
+		client = github.NewClient(sdk)
+	}
+	return searchAll(client, query, cfg.MaxPages, cfg.Verbose)
+}
+
+// searchAll is SearchGitHubAll through client.
+func searchAll(client *github.Client, query string, maxPages int, verbose bool) (SearchResult, error) {
+	if maxPages <= 0 || maxPages > MAX_SEARCH_PAGES {
+		maxPages = MAX_SEARCH_PAGES
+	}
+	ctx := throttleLogContext(context.Background(), verbose)
+	var all SearchResult
+	for page := 1; page <= maxPages; page++ {
+		result, err := searchGitHub(ctx, client, query, page)
+		if err != nil {
+			return all, err
+		}
+		if verbose {
+			log.Printf("[verbose] Query %q page %d => %d items", query, page, len(result.Items))
+		}
+		if page == 1 {
+			all.TotalCount = result.TotalCount
+		}
+		all.Items = append(all.Items, result.Items...)
+		if result.IncompleteResults {
+			all.IncompleteResults = true
+			break
+		}
+		if len(result.Items) < 100 || len(all.Items) >= all.TotalCount {
+			break
+		}
+	}
+	return all, nil
+}
+
 // IsBinaryFileForItem fetches up to 10 KB of the file, checks if binary.
 func IsBinaryFileForItem(sdk *resilientbridge.ResilientBridge, item Item, verbose bool) (bool, error) {
+	return IsBinaryFileForItemWithClient(github.NewClient(sdk), item, verbose)
+}
+
+// IsBinaryFileForItemWithClient is IsBinaryFileForItem through client (see SearchGitHubWithClient).
+func IsBinaryFileForItemWithClient(client *github.Client, item Item, verbose bool) (bool, error) {
 	if verbose {
 		log.Printf("[verbose] Checking file content for %s (%s)", item.Path, item.Repository.FullName)
 	}
 
+	ctx := throttleLogContext(context.Background(), verbose)
 	endpoint := fmt.Sprintf("/repos/%s/contents/%s", item.Repository.FullName, EscapePath(item.Path))
-	req := &resilientbridge.NormalizedRequest{
-		Method:   "GET",
-		Endpoint: endpoint,
-		Headers: map[string]string{
-			"Accept":     "application/vnd.github.v3+json",
-			"User-Agent": "opencomply fetcher",
-		},
-	}
-	resp, err := sdk.Request("github", req)
+	resp, err := client.Do(ctx, client.NewRequest("GET", endpoint))
 	if err != nil {
 		return false, err
 	}
@@ -242,15 +299,9 @@ func IsBinaryFileForItem(sdk *resilientbridge.ResilientBridge, item Item, verbos
 			return false, err
 		}
 	} else if contentResp.DownloadURL != "" {
-		req2 := &resilientbridge.NormalizedRequest{
-			Method:   "GET",
-			Endpoint: contentResp.DownloadURL,
-			Headers: map[string]string{
-				"Range":      "bytes=0-10239",
-				"User-Agent": "opencomply fetcher",
-			},
-		}
-		resp2, err := sdk.Request("github", req2)
+		req2 := client.NewRequest("GET", contentResp.DownloadURL)
+		req2.Headers["Range"] = "bytes=0-10239"
+		resp2, err := client.Do(ctx, req2)
 		if err != nil {
 			return false, err
 		}
@@ -310,17 +361,22 @@ func GatherDirectories(items []Item, verbose bool) []DirGroup {
 // Dynamic Checking: Binary-likely vs. Text-based
 // ---------------------------------------------------------------------
 
+// ClassifyFunc decides whether a file that needs checking should be kept (e.g. IsBinaryFileForItem).
+type ClassifyFunc func(sdk *resilientbridge.ResilientBridge, item Item, verbose bool) (bool, error)
+
 func dynamicSampleDirectory(
 	sdk *resilientbridge.ResilientBridge,
 	group DirGroup,
 	verbose bool,
+	needsCheck map[string]bool,
+	classify ClassifyFunc,
 ) (confirmedBinaries []Item, textBased []Item) {
 
 	// We'll separate "binary-likely" items from "text-based" items
 	var binLikely []Item
 	for _, it := range group.AllItems {
 		ext := strings.TrimPrefix(filepath.Ext(it.Path), ".")
-		if ExpectedBinaryExt[ext] {
+		if needsCheck[ext] {
 			binLikely = append(binLikely, it)
 		} else {
 			// This is a text-based extension (e.g. .prototxt, .pmml, etc.)
@@ -346,7 +402,7 @@ func dynamicSampleDirectory(
 	if len(binLikely) <= INITIAL_SAMPLE_SIZE {
 		// just check them all at once
 		for _, it := range binLikely {
-			ok, err := IsBinaryFileForItem(sdk, it, verbose)
+			ok, err := classify(sdk, it, verbose)
 			if err == nil && ok {
 				foundAnyBinary = true
 				confirmed = append(confirmed, it)
@@ -357,7 +413,7 @@ func dynamicSampleDirectory(
 		sample1 := binLikely[:INITIAL_SAMPLE_SIZE]
 		var partialConfirmed []Item
 		for _, it := range sample1 {
-			ok, err := IsBinaryFileForItem(sdk, it, verbose)
+			ok, err := classify(sdk, it, verbose)
 			if err == nil && ok {
 				foundAnyBinary = true
 				partialConfirmed = append(partialConfirmed, it)
@@ -367,7 +423,7 @@ func dynamicSampleDirectory(
 			// Phase 2: check the rest
 			remainder := binLikely[INITIAL_SAMPLE_SIZE:]
 			for _, it := range remainder {
-				ok, err := IsBinaryFileForItem(sdk, it, verbose)
+				ok, err := classify(sdk, it, verbose)
 				if err == nil && ok {
 					partialConfirmed = append(partialConfirmed, it)
 				}
@@ -384,12 +440,41 @@ func dynamicSampleDirectory(
 // - We ALWAYS keep text-based files, even if the bin-likely check fails.
 //
 // So the final "kept" items from each directory = `confirmedBinaries + textBased`.
+//
+// maxParallel bounds how many directories are sampled at once. It does not need to be tuned to GitHub's
+// limits: the contents calls are paced by the SDK, and setting MaxConcurrency on the "github" provider
+// caps how many of them are in flight across all goroutines.
 func SampleAndFilterDirectories(
 	sdk *resilientbridge.ResilientBridge,
 	groups []DirGroup,
 	maxParallel int,
 	verbose bool,
 ) []Item {
+	return sampleAndFilterDirectories(sdk, groups, maxParallel, verbose, ExpectedBinaryExt, IsBinaryFileForItem)
+}
+
+// SampleAndFilterDirectoriesWithCache is SampleAndFilterDirectories with binary checks memoized in
+// cache, so files seen by earlier or overlapping searches are not fetched again.
+func SampleAndFilterDirectoriesWithCache(
+	sdk *resilientbridge.ResilientBridge,
+	groups []DirGroup,
+	maxParallel int,
+	verbose bool,
+	cache *ClassifierCache,
+) []Item {
+	return sampleAndFilterDirectories(sdk, groups, maxParallel, verbose, ExpectedBinaryExt, cache.Wrap(IsBinaryFileForItem))
+}
+
+// sampleAndFilterDirectories is SampleAndFilterDirectories with the set of extensions that need
+// checking and the per-file classifier supplied by the caller (see ScanRepoFiles).
+func sampleAndFilterDirectories(
+	sdk *resilientbridge.ResilientBridge,
+	groups []DirGroup,
+	maxParallel int,
+	verbose bool,
+	needsCheck map[string]bool,
+	classify ClassifyFunc,
+) []Item {
 
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, maxParallel)
@@ -406,7 +491,7 @@ func SampleAndFilterDirectories(
 			defer wg.Done()
 			defer func() { <-sem }() // Release slot
 
-			confirmedBin, textBased := dynamicSampleDirectory(sdk, group, verbose)
+			confirmedBin, textBased := dynamicSampleDirectory(sdk, group, verbose, needsCheck, classify)
 
 			// We always keep text-based items (like .prototxt).
 			// Also keep whichever binaries were truly confirmed as binary.
@@ -427,6 +512,17 @@ func SampleAndFilterDirectories(
 	return finalKept
 }
 
+// throttleLogContext returns ctx with a ThrottleFunc that logs SDK rate limit waits when verbose is set,
+// so long stalls during a scan are explained.
+func throttleLogContext(ctx context.Context, verbose bool) context.Context {
+	if !verbose {
+		return ctx
+	}
+	return resilientbridge.WithThrottleNotify(ctx, func(provider string, callType string, wait time.Duration) {
+		log.Printf("[verbose] %s %s rate limit reached, waiting %v", provider, callType, wait.Round(time.Second))
+	})
+}
+
 // ---------------------------------------------------------------------
 // Build final output: repository_id / name / full_name / extensions->paths
 // ---------------------------------------------------------------------
@@ -473,25 +569,42 @@ func CreateDetailedRepoExtensionMap(items []Item) map[string]*RepoOutput {
 }
 
 
-// BuildPythonAIQueries turns the pythonAIKeywords into a slice of chunked queries like:
-//   extension:py tensorflow OR extension:py torch ...
-// so we can search them in code.
+// BuildPythonAIQueries turns keywords (PythonAIKeywords if nil) into chunked queries over .py files;
+// it is BuildCodeSearchQueries with the "py" extension.
 func BuildPythonAIQueries(qualifier string, keywords []string, chunkSize int) []string {
-    // chunk the keywords to avoid overly long queries
-    var finalQueries []string
-    chunked := ChunkBySize(keywords, chunkSize)
-
-    for _, chunk := range chunked {
-        // Build a single query: "extension:py <kw1> OR extension:py <kw2> ..."
-        var parts []string
-        for _, kw := range chunk {
-            if qualifier != "" {
-                parts = append(parts, qualifier+" extension:py "+kw)
-            } else {
-                parts = append(parts, "extension:py "+kw)
-            }
+    if keywords == nil {
+        keywords = PythonAIKeywords
+    }
+    return BuildCodeSearchQueries(qualifier, "py", keywords, chunkSize)
+}
+
+// BuildCodeSearchQueries turns keywords into a slice of chunked queries like:
+//   extension:r keras OR extension:r torch ...
+// so we can search them in code. qualifier (e.g. "org:apache") is prefixed to each term.
+func BuildCodeSearchQueries(qualifier string, extension string, keywords []string, chunkSize int) []string {
+    return BuildCodeSearchQueriesForExtensions(qualifier, []string{extension}, keywords, chunkSize)
+}
+
+// BuildCodeSearchQueriesForExtensions is BuildCodeSearchQueries for several extensions at once: every
+// keyword is paired with every extension, and the pairs are chunked into queries like:
+//   extension:r keras OR extension:jl keras OR extension:r torch ...
+func BuildCodeSearchQueriesForExtensions(qualifier string, extensions []string, keywords []string, chunkSize int) []string {
+    prefix := ""
+    if qualifier != "" {
+        prefix = qualifier + " "
+    }
+
+    var terms []string
+    for _, kw := range keywords {
+        for _, ext := range extensions {
+            terms = append(terms, prefix+"extension:"+strings.TrimPrefix(ext, ".")+" "+kw)
         }
-        finalQueries = append(finalQueries, strings.Join(parts, " OR "))
+    }
+
+    // chunk the terms to avoid overly long queries
+    var finalQueries []string
+    for _, chunk := range ChunkBySize(terms, chunkSize) {
+        finalQueries = append(finalQueries, strings.Join(chunk, " OR "))
     }
     return finalQueries
 }
@@ -1,6 +1,6 @@
 // Package utils provides a utility function that accepts a JSON input containing one or more credential configurations
 // for different container registries (Azure ACR via SPN Password or SPN Certificate, GitHub Container Registry (GHCR),
-// DockerHub, and Google Container Registry (GCR) via a Google service account), and returns OCI-compatible (Docker) credentials.
+// DockerHub, Quay, GitLab Container Registry, and Google Container Registry (GCR) via a Google service account), and returns OCI-compatible (Docker) credentials.
 //
 // JSON Input Structure (example):
 //
@@ -29,10 +29,29 @@
 //    "google_service_account": {
 //      "service_account_json": "{...}", // the full JSON key for the GCP service account
 //      "registry": "gcr.io"
+//    },
+//    "quay": {
+//      "username": "myorg+myrobot", // robot account name, or "$oauthtoken" with an OAuth token
+//      "token": "robot-or-oauth-token",
+//      "registry": "quay.io"        // optional, for self-hosted Quay
+//    },
+//    "gitlab_registry": {
+//      "username": "your-gitlab-username", // or "gitlab-ci-token" with $CI_JOB_TOKEN in CI jobs
+//      "token": "your-gitlab-pat-or-deploy-token",
+//      "registry": "registry.gitlab.com"   // optional, for self-hosted GitLab registries
 //    }
 //  }
 //
 // Each field is optional, but if present, must provide the required sub-fields as noted above.
+// Any field may also be an array of such objects to configure several registries of the same type, e.g.
+//
+//  "azure_spn_password": [
+//    {"tenant_id": "...", "client_id": "...", "client_secret": "...", "registry": "first.azurecr.io"},
+//    {"tenant_id": "...", "client_id": "...", "client_secret": "...", "registry": "second.azurecr.io"}
+//  ]
+//
+// GitHub and DockerHub entries accept an optional "registry" (defaulting to ghcr.io / index.docker.io).
+// Two entries for the same registry host are rejected.
 // The returned map is a set of registry hostnames to base64-encoded "username:password" strings suitable
 // for inclusion in a Docker config.json or other OCI-compatible credential store.
 //
@@ -41,6 +60,8 @@
 //  "index.docker.io" -> base64("username:token")
 //  "<yourregistry>.azurecr.io" -> base64("00000000-0000-0000-0000-000000000000:<access_token>")
 //  "gcr.io" -> base64("oauth2accesstoken:<gcr_oauth2_token>")
+//  "quay.io" -> base64("myorg+myrobot:token")
+//  "registry.gitlab.com" -> base64("username:token")
 //
 // This utility replaces older files by consolidating all credential acquisition logic into a single entry point.
 // For Azure ACR, it uses a two-step approach:
@@ -48,7 +69,8 @@
 //   - Exchange that AAD token for an ACR refresh token
 //   - Exchange refresh token for an ACR access token with desired scope
 //
-// GitHub (GHCR), DockerHub are straightforward username/token pairs.
+// GitHub (GHCR), DockerHub, Quay, and GitLab are straightforward username/token pairs. In GitLab CI jobs,
+// use "gitlab-ci-token" as the username and the job's CI_JOB_TOKEN as the token.
 // Google Service Account credentials obtain an OAuth2 token suitable for GCR.
 //
 // Usage:
@@ -61,6 +83,10 @@
 //  }
 //
 //  // creds can now be used to populate Docker config.json or similar.
+//
+//  // Alternatively, read the same settings from environment variables
+//  // (see oci_registry_credentials_env.go for the variable names):
+//  creds, err = utils.GetAllCredentialsFromEnv()
 
 package utils
 
@@ -105,12 +131,14 @@ type AzureSPNCertificateCredentials struct {
 type GitHubCredentials struct {
 	Username string `json:"username"`
 	Token    string `json:"token"`
+	Registry string `json:"registry,omitempty"` // defaults to ghcr.io
 }
 
 // DockerHubCredentials for DockerHub registry.
 type DockerHubCredentials struct {
 	Username string `json:"username"`
 	Token    string `json:"token"`
+	Registry string `json:"registry,omitempty"` // defaults to index.docker.io
 }
 
 // GoogleServiceAccountCredentials represents the GCP service account JSON for GCR.
@@ -119,13 +147,59 @@ type GoogleServiceAccountCredentials struct {
 	Registry           string `json:"registry"`
 }
 
+// QuayCredentials for Quay (robot account, or "$oauthtoken" with an OAuth token).
+type QuayCredentials struct {
+	Username string `json:"username"`
+	Token    string `json:"token"`
+	Registry string `json:"registry,omitempty"` // defaults to quay.io
+}
+
+// GitLabRegistryCredentials for the GitLab Container Registry. For CI job tokens, the username is
+// "gitlab-ci-token" and the token is $CI_JOB_TOKEN.
+type GitLabRegistryCredentials struct {
+	Username string `json:"username"`
+	Token    string `json:"token"`
+	Registry string `json:"registry,omitempty"` // defaults to registry.gitlab.com
+}
+
+// CredentialsList holds one or more credentials of the same type. In JSON it accepts either a single
+// object (the original format) or an array of objects, so several registries of one type can be configured.
+type CredentialsList[T any] []T
+
+// UnmarshalJSON accepts a single object, an array of objects, or null.
+func (l *CredentialsList[T]) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	switch {
+	case trimmed == "null":
+		*l = nil
+		return nil
+	case strings.HasPrefix(trimmed, "["):
+		var items []T
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	default:
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		*l = CredentialsList[T]{item}
+		return nil
+	}
+}
+
 // CredentialsInput is the combined structure for all supported credential types.
+// Each field holds any number of entries; the returned map has one entry per registry host.
 type CredentialsInput struct {
-	AzureSPNPassword     *AzureSPNPasswordCredentials     `json:"azure_spn_password,omitempty"`
-	AzureSPNCertificate  *AzureSPNCertificateCredentials  `json:"azure_spn_certificate,omitempty"`
-	GitHub               *GitHubCredentials               `json:"github,omitempty"`
-	DockerHub            *DockerHubCredentials            `json:"dockerhub,omitempty"`
-	GoogleServiceAccount *GoogleServiceAccountCredentials `json:"google_service_account,omitempty"`
+	AzureSPNPassword     CredentialsList[AzureSPNPasswordCredentials]     `json:"azure_spn_password,omitempty"`
+	AzureSPNCertificate  CredentialsList[AzureSPNCertificateCredentials]  `json:"azure_spn_certificate,omitempty"`
+	GitHub               CredentialsList[GitHubCredentials]               `json:"github,omitempty"`
+	DockerHub            CredentialsList[DockerHubCredentials]            `json:"dockerhub,omitempty"`
+	GoogleServiceAccount CredentialsList[GoogleServiceAccountCredentials] `json:"google_service_account,omitempty"`
+	Quay                 CredentialsList[QuayCredentials]                 `json:"quay,omitempty"`
+	GitLabRegistry       CredentialsList[GitLabRegistryCredentials]       `json:"gitlab_registry,omitempty"`
 }
 
 // GetAllCredentials takes a JSON byte slice and a scope (e.g., `repository:myrepo:pull`).
@@ -137,27 +211,62 @@ func GetAllCredentials(jsonData []byte, scope string) (map[string]string, error)
 	if err := json.Unmarshal(jsonData, &input); err != nil {
 		return nil, fmt.Errorf("failed to decode input JSON: %w", err)
 	}
+	return getCredentials(&input, scope)
+}
 
+// getCredentials acquires credentials for every registry configured in input.
+// Two entries resolving to the same registry host are rejected.
+func getCredentials(input *CredentialsInput, scope string) (map[string]string, error) {
 	creds := make(map[string]string)
+	add := func(registry, encoded string) error {
+		if _, exists := creds[registry]; exists {
+			return fmt.Errorf("duplicate credentials for registry %s", registry)
+		}
+		creds[registry] = encoded
+		return nil
+	}
+	basicAuth := func(username, token string) string {
+		return base64.StdEncoding.EncodeToString([]byte(username + ":" + token))
+	}
 
 	// GitHub (GHCR)
-	if input.GitHub != nil {
-		if input.GitHub.Username == "" || input.GitHub.Token == "" {
+	for _, gh := range input.GitHub {
+		if gh.Username == "" || gh.Token == "" {
 			return nil, fmt.Errorf("GitHub username and token are required")
 		}
-		authStr := input.GitHub.Username + ":" + input.GitHub.Token
-		encoded := base64.StdEncoding.EncodeToString([]byte(authStr))
-		creds["ghcr.io"] = encoded
+		if err := add(registryOrDefault(gh.Registry, "ghcr.io"), basicAuth(gh.Username, gh.Token)); err != nil {
+			return nil, err
+		}
 	}
 
 	// DockerHub
-	if input.DockerHub != nil {
-		if input.DockerHub.Username == "" || input.DockerHub.Token == "" {
+	for _, dh := range input.DockerHub {
+		if dh.Username == "" || dh.Token == "" {
 			return nil, fmt.Errorf("DockerHub username and token are required")
 		}
-		authStr := input.DockerHub.Username + ":" + input.DockerHub.Token
-		encoded := base64.StdEncoding.EncodeToString([]byte(authStr))
-		creds["index.docker.io"] = encoded
+		if err := add(registryOrDefault(dh.Registry, "index.docker.io"), basicAuth(dh.Username, dh.Token)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Quay
+	for _, q := range input.Quay {
+		if q.Username == "" || q.Token == "" {
+			return nil, fmt.Errorf("Quay username and token are required")
+		}
+		if err := add(registryOrDefault(q.Registry, "quay.io"), basicAuth(q.Username, q.Token)); err != nil {
+			return nil, err
+		}
+	}
+
+	// GitLab Container Registry
+	for _, gl := range input.GitLabRegistry {
+		if gl.Username == "" || gl.Token == "" {
+			return nil, fmt.Errorf("GitLab registry username and token are required")
+		}
+		if err := add(registryOrDefault(gl.Registry, "registry.gitlab.com"), basicAuth(gl.Username, gl.Token)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Default scope for Azure if none provided
@@ -166,54 +275,68 @@ func GetAllCredentials(jsonData []byte, scope string) (map[string]string, error)
 	}
 
 	// Azure SPN (Password)
-	if input.AzureSPNPassword != nil {
+	for _, spn := range input.AzureSPNPassword {
 		spnCreds, err := getAzureACRResourceScopedToken(
-			input.AzureSPNPassword.TenantID,
-			input.AzureSPNPassword.ClientID,
-			input.AzureSPNPassword.ClientSecret,
+			spn.TenantID,
+			spn.ClientID,
+			spn.ClientSecret,
 			"", // no cert path
 			"", // no cert password
-			input.AzureSPNPassword.Registry,
+			spn.Registry,
 			scope,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get ACR token with SPN password: %w", err)
+			return nil, fmt.Errorf("failed to get ACR token with SPN password for %s: %w", spn.Registry, err)
+		}
+		if err := add(spn.Registry, spnCreds); err != nil {
+			return nil, err
 		}
-		creds[input.AzureSPNPassword.Registry] = spnCreds
 	}
 
 	// Azure SPN (Certificate)
-	if input.AzureSPNCertificate != nil {
+	for _, spn := range input.AzureSPNCertificate {
 		spnCreds, err := getAzureACRResourceScopedToken(
-			input.AzureSPNCertificate.TenantID,
-			input.AzureSPNCertificate.ClientID,
+			spn.TenantID,
+			spn.ClientID,
 			"", // no client secret
-			input.AzureSPNCertificate.CertPath,
-			input.AzureSPNCertificate.CertPassword,
-			input.AzureSPNCertificate.Registry,
+			spn.CertPath,
+			spn.CertPassword,
+			spn.Registry,
 			scope,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get ACR token with SPN certificate: %w", err)
+			return nil, fmt.Errorf("failed to get ACR token with SPN certificate for %s: %w", spn.Registry, err)
+		}
+		if err := add(spn.Registry, spnCreds); err != nil {
+			return nil, err
 		}
-		creds[input.AzureSPNCertificate.Registry] = spnCreds
 	}
 
 	// Google Service Account (GCR)
-	if input.GoogleServiceAccount != nil {
-		if input.GoogleServiceAccount.ServiceAccountJSON == "" || input.GoogleServiceAccount.Registry == "" {
+	for _, sa := range input.GoogleServiceAccount {
+		if sa.ServiceAccountJSON == "" || sa.Registry == "" {
 			return nil, fmt.Errorf("Google service account JSON and registry are required")
 		}
-		gcrCreds, err := getGCRCredentialsFromServiceAccount(input.GoogleServiceAccount.ServiceAccountJSON, input.GoogleServiceAccount.Registry)
+		gcrCreds, err := getGCRCredentialsFromServiceAccount(sa.ServiceAccountJSON, sa.Registry)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get GCR token from service account: %w", err)
+			return nil, fmt.Errorf("failed to get GCR token from service account for %s: %w", sa.Registry, err)
+		}
+		if err := add(sa.Registry, gcrCreds); err != nil {
+			return nil, err
 		}
-		creds[input.GoogleServiceAccount.Registry] = gcrCreds
 	}
 
 	return creds, nil
 }
 
+// registryOrDefault returns registry, or def when registry is empty.
+func registryOrDefault(registry, def string) string {
+	if registry == "" {
+		return def
+	}
+	return registry
+}
+
 // getGCRCredentialsFromServiceAccount obtains an access token for GCR using a Google service account's JSON key.
 // Scope: https://www.googleapis.com/auth/devstorage.read_write
 // Returns base64("oauth2accesstoken:access_token").
@@ -0,0 +1,113 @@
+// oci_registry_credentials_env.go
+
+// This file provides GetAllCredentialsFromEnv, which builds the same CredentialsInput as GetAllCredentials
+// from well-known environment variables instead of JSON, for 12-factor deployments and CI pipelines.
+//
+// A credential type is included only when all of its required variables are set; others are skipped.
+//
+//	GitHub (ghcr.io):       GHCR_TOKEN, and GHCR_USERNAME (falls back to GITHUB_ACTOR)
+//	DockerHub:              DOCKERHUB_USERNAME, DOCKERHUB_TOKEN
+//	Azure ACR (password):   AZURE_TENANT_ID, AZURE_CLIENT_ID, AZURE_CLIENT_SECRET, ACR_REGISTRY
+//	Azure ACR (cert):       AZURE_TENANT_ID, AZURE_CLIENT_ID, AZURE_CLIENT_CERTIFICATE_PATH, ACR_REGISTRY
+//	                        (optional AZURE_CLIENT_CERTIFICATE_PASSWORD; used only without AZURE_CLIENT_SECRET)
+//	Google (GCR):           GCR_SERVICE_ACCOUNT_JSON, or a key file path in GOOGLE_APPLICATION_CREDENTIALS;
+//	                        GCR_REGISTRY (defaults to gcr.io)
+//	Quay:                   QUAY_USERNAME, QUAY_TOKEN, optional QUAY_REGISTRY
+//	GitLab registry:        GITLAB_REGISTRY_USERNAME, GITLAB_REGISTRY_TOKEN, optional GITLAB_REGISTRY; inside
+//	                        GitLab CI these fall back to CI_REGISTRY_USER, CI_REGISTRY_PASSWORD, CI_REGISTRY
+//
+// The Azure token scope is read from ACR_SCOPE and defaults to "registry:catalog:*", as in GetAllCredentials.
+package utils
+
+import (
+	"fmt"
+	"os"
+)
+
+// GetAllCredentialsFromEnv assembles a CredentialsInput from environment variables (see the list above)
+// and returns the same registry -> base64("username:password") map as GetAllCredentials.
+func GetAllCredentialsFromEnv() (map[string]string, error) {
+	return getCredentials(credentialsInputFromEnv(), os.Getenv("ACR_SCOPE"))
+}
+
+// credentialsInputFromEnv reads every supported credential type whose required variables are present.
+func credentialsInputFromEnv() *CredentialsInput {
+	input := &CredentialsInput{}
+
+	if token := os.Getenv("GHCR_TOKEN"); token != "" {
+		if username := envOr("GHCR_USERNAME", "GITHUB_ACTOR"); username != "" {
+			input.GitHub = CredentialsList[GitHubCredentials]{{Username: username, Token: token}}
+		}
+	}
+
+	if username, token := os.Getenv("DOCKERHUB_USERNAME"), os.Getenv("DOCKERHUB_TOKEN"); username != "" && token != "" {
+		input.DockerHub = CredentialsList[DockerHubCredentials]{{Username: username, Token: token}}
+	}
+
+	tenantID, clientID, registry := os.Getenv("AZURE_TENANT_ID"), os.Getenv("AZURE_CLIENT_ID"), os.Getenv("ACR_REGISTRY")
+	if tenantID != "" && clientID != "" && registry != "" {
+		if secret := os.Getenv("AZURE_CLIENT_SECRET"); secret != "" {
+			input.AzureSPNPassword = CredentialsList[AzureSPNPasswordCredentials]{{
+				TenantID:     tenantID,
+				ClientID:     clientID,
+				ClientSecret: secret,
+				Registry:     registry,
+			}}
+		} else if certPath := os.Getenv("AZURE_CLIENT_CERTIFICATE_PATH"); certPath != "" {
+			input.AzureSPNCertificate = CredentialsList[AzureSPNCertificateCredentials]{{
+				TenantID:     tenantID,
+				ClientID:     clientID,
+				CertPath:     certPath,
+				CertPassword: os.Getenv("AZURE_CLIENT_CERTIFICATE_PASSWORD"),
+				Registry:     registry,
+			}}
+		}
+	}
+
+	if saJSON := googleServiceAccountJSONFromEnv(); saJSON != "" {
+		gcrRegistry := os.Getenv("GCR_REGISTRY")
+		if gcrRegistry == "" {
+			gcrRegistry = "gcr.io"
+		}
+		input.GoogleServiceAccount = CredentialsList[GoogleServiceAccountCredentials]{{ServiceAccountJSON: saJSON, Registry: gcrRegistry}}
+	}
+
+	if username, token := os.Getenv("QUAY_USERNAME"), os.Getenv("QUAY_TOKEN"); username != "" && token != "" {
+		input.Quay = CredentialsList[QuayCredentials]{{Username: username, Token: token, Registry: os.Getenv("QUAY_REGISTRY")}}
+	}
+
+	username, token := envOr("GITLAB_REGISTRY_USERNAME", "CI_REGISTRY_USER"), envOr("GITLAB_REGISTRY_TOKEN", "CI_REGISTRY_PASSWORD")
+	if username != "" && token != "" {
+		input.GitLabRegistry = CredentialsList[GitLabRegistryCredentials]{{Username: username, Token: token, Registry: envOr("GITLAB_REGISTRY", "CI_REGISTRY")}}
+	}
+
+	return input
+}
+
+// googleServiceAccountJSONFromEnv returns GCR_SERVICE_ACCOUNT_JSON, or the contents of the key file named by
+// GOOGLE_APPLICATION_CREDENTIALS. An unreadable key file is reported on stderr and skipped.
+func googleServiceAccountJSONFromEnv() string {
+	if saJSON := os.Getenv("GCR_SERVICE_ACCOUNT_JSON"); saJSON != "" {
+		return saJSON
+	}
+	path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read GOOGLE_APPLICATION_CREDENTIALS file: %v\n", err)
+		return ""
+	}
+	return string(data)
+}
+
+// envOr returns the value of the first set environment variable among keys.
+func envOr(keys ...string) string {
+	for _, key := range keys {
+		if val := os.Getenv(key); val != "" {
+			return val
+		}
+	}
+	return ""
+}
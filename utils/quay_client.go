@@ -0,0 +1,101 @@
+// quay_client.go
+
+// This file provides a small client for listing repositories and tags in a Quay registry (quay.io or
+// self-hosted). Requests go through a resilient-bridge SDK with the Quay adapter, so throttling and
+// retries are handled, and Quay's body-based pagination is followed with adapters.QuayNextPage.
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/adapters"
+)
+
+// QuayRepository is a repository entry from Quay's /api/v1/repository listing.
+type QuayRepository struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// QuayTag is a tag entry from Quay's /api/v1/repository/{repo}/tag/ listing.
+type QuayTag struct {
+	Name           string `json:"name"`
+	ManifestDigest string `json:"manifest_digest"`
+	LastModified   string `json:"last_modified"`
+	Size           int64  `json:"size"`
+}
+
+// QuayClient lists repositories and tags from a Quay registry.
+type QuayClient struct {
+	sdk *resilientbridge.ResilientBridge
+}
+
+// NewQuayClient creates a QuayClient for quay.io using an OAuth or robot token.
+// Use NewQuayClientForHost for self-hosted Quay.
+func NewQuayClient(token string) *QuayClient {
+	return NewQuayClientForHost(token, adapters.QuayDefaultHost)
+}
+
+// NewQuayClientForHost creates a QuayClient for the Quay instance at host.
+func NewQuayClientForHost(token, host string) *QuayClient {
+	sdk := resilientbridge.NewResilientBridge()
+	sdk.RegisterProvider("quay", adapters.NewQuayAdapter(token, host), &resilientbridge.ProviderConfig{
+		UseProviderLimits: true,
+		MaxRetries:        3,
+	})
+	return &QuayClient{sdk: sdk}
+}
+
+// ListRepositories returns all repositories in namespace (an organization or user).
+func (c *QuayClient) ListRepositories(ctx context.Context, namespace string) ([]QuayRepository, error) {
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: "/repository?namespace=" + url.QueryEscape(namespace),
+	}
+
+	var repos []QuayRepository
+	err := c.sdk.Paginate(ctx, "quay", req, &resilientbridge.PaginateOptions{NextPage: adapters.QuayNextPage}, func(resp *resilientbridge.NormalizedResponse) error {
+		var page struct {
+			Repositories []QuayRepository `json:"repositories"`
+		}
+		if err := json.Unmarshal(resp.Data, &page); err != nil {
+			return fmt.Errorf("error decoding Quay repositories: %w", err)
+		}
+		repos = append(repos, page.Repositories...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// ListTags returns the active tags of the repository namespace/name.
+func (c *QuayClient) ListTags(ctx context.Context, namespace, name string) ([]QuayTag, error) {
+	req := &resilientbridge.NormalizedRequest{
+		Method:   "GET",
+		Endpoint: fmt.Sprintf("/repository/%s/%s/tag/?onlyActiveTags=true&limit=100&page=1", url.PathEscape(namespace), url.PathEscape(name)),
+	}
+
+	var tags []QuayTag
+	err := c.sdk.Paginate(ctx, "quay", req, &resilientbridge.PaginateOptions{NextPage: adapters.QuayNextPage}, func(resp *resilientbridge.NormalizedResponse) error {
+		var page struct {
+			Tags []QuayTag `json:"tags"`
+		}
+		if err := json.Unmarshal(resp.Data, &page); err != nil {
+			return fmt.Errorf("error decoding Quay tags: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
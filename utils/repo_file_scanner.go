@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	resilientbridge "github.com/opengovern/resilient-bridge"
+	"github.com/opengovern/resilient-bridge/github"
+)
+
+const (
+	// DEFAULT_SCAN_PARALLEL is the number of directories ScanRepoFiles samples concurrently by default.
+	DEFAULT_SCAN_PARALLEL = 5
+
+	// MAX_SEARCH_PAGES is GitHub code search's cap: 1000 results at 100 per page.
+	MAX_SEARCH_PAGES = 10
+)
+
+// ScanConfig describes which files ScanRepoFiles looks for and how matches are confirmed.
+type ScanConfig struct {
+	// Extensions and Filenames select files via the code search "extension:" and "filename:"
+	// qualifiers (e.g. "tf", or "Dockerfile"). At least one of them is required.
+	Extensions []string
+	Filenames  []string
+
+	// Keywords optionally narrows matches to files containing any of the given terms.
+	Keywords []string
+
+	// CheckExtensions lists extensions whose matches must be confirmed by Classify, using the
+	// same two-phase directory sampling as the model detector. Other matches are kept as-is.
+	CheckExtensions map[string]bool
+
+	// Classify confirms a file; it defaults to IsBinaryFileForItem.
+	Classify ClassifyFunc
+
+	// Client, if set, sends the searches and the default Classify's requests, with its base URL (GitHub
+	// Enterprise Server) and Accept media types; otherwise they go to api.github.com through the SDK.
+	Client *github.Client
+
+	// Cache, if set, memoizes Classify results by repository, path, and blob sha.
+	Cache *ClassifierCache
+
+	MaxParallel int // Directories sampled concurrently; defaults to DEFAULT_SCAN_PARALLEL
+	MaxPages    int // Search pages (of 100) per query; defaults to MAX_SEARCH_PAGES
+	Verbose     bool
+}
+
+// ---------------------------------------------------------------------
+// Generic Repository File Scanning
+// ---------------------------------------------------------------------
+
+// ScanRepoFiles searches each repository ("owner/name") for files matching cfg, confirms the
+// CheckExtensions matches with cfg.Classify using the model detector's directory sampling, and
+// returns the kept files grouped per repository and extension. This is the machinery behind the
+// ML model detector, usable for Dockerfiles, Terraform, secrets, and so on.
+func ScanRepoFiles(sdk *resilientbridge.ResilientBridge, repos []string, cfg ScanConfig) (map[string]*RepoOutput, error) {
+	if len(cfg.Extensions) == 0 && len(cfg.Filenames) == 0 {
+		return nil, fmt.Errorf("ScanConfig needs at least one extension or filename")
+	}
+	client := cfg.Client
+	if client == nil {
+		client = github.NewClient(sdk)
+	}
+	classify := cfg.Classify
+	if classify == nil {
+		classify = func(_ *resilientbridge.ResilientBridge, item Item, verbose bool) (bool, error) {
+			return IsBinaryFileForItemWithClient(client, item, verbose)
+		}
+	}
+	classify = cfg.Cache.Wrap(classify)
+	maxParallel := cfg.MaxParallel
+	if maxParallel <= 0 {
+		maxParallel = DEFAULT_SCAN_PARALLEL
+	}
+
+	seen := make(map[string]bool)
+	var items []Item
+	for _, repo := range repos {
+		for _, query := range buildScanQueries(repo, cfg) {
+			found, err := searchAll(client, query, cfg.MaxPages, cfg.Verbose)
+			if err != nil {
+				return nil, fmt.Errorf("search %q: %w", query, err)
+			}
+			for _, it := range found.Items {
+				key := it.Repository.FullName + "|" + it.Path
+				if !seen[key] {
+					seen[key] = true
+					items = append(items, it)
+				}
+			}
+		}
+	}
+
+	groups := GatherDirectories(items, cfg.Verbose)
+	kept := sampleAndFilterDirectories(sdk, groups, maxParallel, cfg.Verbose, cfg.CheckExtensions, classify)
+	return CreateDetailedRepoExtensionMap(kept), nil
+}
+
+// buildScanQueries returns one code search query per (extension or filename) and keyword.
+func buildScanQueries(repo string, cfg ScanConfig) []string {
+	var selectors []string
+	for _, ext := range cfg.Extensions {
+		selectors = append(selectors, "extension:"+strings.TrimPrefix(ext, "."))
+	}
+	for _, name := range cfg.Filenames {
+		selectors = append(selectors, "filename:"+name)
+	}
+
+	keywords := cfg.Keywords
+	if len(keywords) == 0 {
+		keywords = []string{""}
+	}
+
+	var queries []string
+	for _, sel := range selectors {
+		for _, kw := range keywords {
+			q := "repo:" + repo + " " + sel
+			if kw != "" {
+				q += " " + kw
+			}
+			queries = append(queries, q)
+		}
+	}
+	return queries
+}
@@ -0,0 +1,12 @@
+// version.go
+// ----------
+// This file records the SDK version, which is reported to providers in DefaultUserAgent.
+package resilientbridge
+
+// Version is the resilient-bridge release this tree corresponds to.
+const Version = "0.1.0"
+
+// DefaultUserAgent is sent as the User-Agent of every request that sets none and whose provider has no
+// ProviderConfig.UserAgent. GitHub (among others) rejects some requests without a User-Agent and asks
+// automated clients to identify themselves.
+const DefaultUserAgent = "resilient-bridge/" + Version + " (+https://github.com/opengovern/resilient-bridge)"
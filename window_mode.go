@@ -0,0 +1,43 @@
+// window_mode.go
+// --------------
+// This file defines WindowMode, which selects how the SDK's own request windows (ProviderConfig.EndpointLimits)
+// advance:
+//   - WindowSliding (default) counts the requests sent during the last WindowSecs, recomputed on every
+//     request. A slot frees up WindowSecs after the request that took it, so load is spread evenly, but
+//     the local window never lines up with a provider that resets its quota at fixed times.
+//   - WindowFixedReset counts requests in consecutive fixed windows of WindowSecs that are aligned to the
+//     reset time the adapter reports through ParseRateLimitInfo (ResetRequestsAt of the request's call
+//     type), e.g. GitHub's hourly reset. When the provider resets, the whole local window is available
+//     again at once instead of trickling back one slot at a time. Until a reset time is known, windows are
+//     aligned to multiples of WindowSecs since the Unix epoch.
+//
+// The trade-off: WindowFixedReset matches documented "N requests per hour, reset at X" semantics and uses
+// the full budget of each window, but allows up to twice the limit in a short span around a window edge,
+// which sliding windows never do. Prefer WindowSliding for providers that limit over a rolling period.
+package resilientbridge
+
+import "time"
+
+// WindowMode selects how EndpointLimits windows advance.
+type WindowMode int
+
+const (
+	// WindowSliding counts requests over the last WindowSecs (default).
+	WindowSliding WindowMode = iota
+	// WindowFixedReset counts requests in fixed windows aligned to the provider's reported reset time.
+	WindowFixedReset
+)
+
+// fixedWindowStart returns the start of the fixed window of length window containing now, aligned to
+// resetAtMs (Unix ms) when it is non-nil, otherwise to the Unix epoch.
+func fixedWindowStart(now time.Time, window time.Duration, resetAtMs *int64) time.Time {
+	var offset time.Duration
+	if resetAtMs != nil {
+		offset = time.Duration(*resetAtMs) * time.Millisecond % window
+	}
+	since := (time.Duration(now.UnixNano()) - offset) % window
+	if since < 0 {
+		since += window
+	}
+	return now.Add(-since)
+}